@@ -0,0 +1,69 @@
+package jewerly
+
+import (
+	"errors"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+var (
+	ErrCartNotFound         = errors.New("cart not found")
+	ErrCartEmpty            = errors.New("cart is empty")
+	ErrCartItemsUnavailable = errors.New("cart has items that are out of stock")
+	ErrProductNotFound      = errors.New("product not found")
+)
+
+// CartIdentity is either anonymous cart token or signed in customer, or both right after sign in.
+type CartIdentity struct {
+	Token  string
+	UserId null.Int
+}
+
+type Cart struct {
+	Id        int        `json:"-" db:"id"`
+	Token     string     `json:"token" db:"token"`
+	UserId    null.Int   `json:"-" db:"user_id"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	Items     []CartItem `json:"items"`
+
+	// TotalCost is calculated with the actual prices of items in stock.
+	TotalCost      Money `json:"total_cost"`
+	HasUnavailable bool  `json:"has_unavailable"`
+}
+
+type CartItem struct {
+	ProductId     int         `json:"product_id" db:"product_id"`
+	Title         string      `json:"title" db:"title"`
+	Price         Money       `json:"price" db:"price"`
+	Quantity      int         `json:"quantity" db:"quantity"`
+	InStock       bool        `json:"in_stock" db:"in_stock"`
+	VariantId     null.Int    `json:"variant_id" db:"variant_id"`
+	VariantOption null.String `json:"variant_option" db:"option_type"`
+	VariantValue  null.String `json:"variant_value" db:"option_value"`
+	Images        []Image     `json:"images"`
+}
+
+type AddCartItemInput struct {
+	ProductId int      `json:"product_id" binding:"required,min=1"`
+	VariantId null.Int `json:"variant_id"`
+	Quantity  int      `json:"quantity" binding:"required,min=1"`
+}
+
+type UpdateCartItemInput struct {
+	Quantity int `json:"quantity" binding:"required,min=1"`
+}
+
+type CheckoutInput struct {
+	FirstName      string `json:"first_name" binding:"required"`
+	LastName       string `json:"last_name" binding:"required"`
+	AdditionalName string `json:"additional_name"`
+	Email          string `json:"email" binding:"email,required"`
+	Phone          string `json:"phone"`
+	Country        string `json:"country" binding:"required"`
+	Address        string `json:"address" binding:"required"`
+	PostalCode     string `json:"postal_code" binding:"required"`
+	PromoCode      string `json:"promo_code"`
+	Currency       string `json:"currency"`
+
+	ShippingMethodId int `json:"shipping_method_id" binding:"min=0"`
+}
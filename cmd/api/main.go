@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 	"github.com/minio/minio-go"
 	"github.com/sirupsen/logrus"
@@ -10,12 +13,15 @@ import (
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/config"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/email"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/handler"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/invoice"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/payment"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository/postgres"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/scheduler"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/service"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/storage"
 	"io"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
@@ -58,18 +64,39 @@ func main() {
 		logrus.Fatalf("Error occurred on storage initialization: %s\n", err.Error())
 	}
 
-	apiKey := os.Getenv("PAYMENT_API_KEY")
-	if apiKey == "" {
-		logrus.Fatalln("Payment credentials are empty")
+	provider := viper.GetString("payments.provider")
+
+	// callbacks are verified with the secret token passed in the callback url,
+	// fake provider is the only sender of its callbacks, so it gets a random token for the process
+	callbackToken := os.Getenv("PAYMENT_CALLBACK_TOKEN")
+	if callbackToken == "" && provider == "fake" {
+		logrus.Warnln("Payment callback token is empty, random token is generated for the fake provider")
+		callbackToken = uuid.New().String()
+	}
+
+	if callbackToken == "" {
+		logrus.Fatalln("Payment callback token is empty")
 	}
 
-	paymentProvider := payment.NewIsracardProvider(
-		viper.GetString("payments.endpoint"), apiKey,
-		viper.GetString("payments.return_url"), viper.GetString("payments.callback_url"))
+	callbackURL := viper.GetString("payments.callback_url") + "?" + url.Values{"token": {callbackToken}}.Encode()
+
+	paymentProvider, err := payment.NewProvider(payment.Config{
+		Provider:      provider,
+		Endpoint:      viper.GetString(fmt.Sprintf("payments.%s.endpoint", provider)),
+		APIKey:        os.Getenv("PAYMENT_API_KEY"),
+		WebhookSecret: os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+		CallbackURL:   callbackURL,
+		ReturnURL:     viper.GetString("payments.return_url"),
+		CancelURL:     viper.GetString("payments.cancel_url"),
+	})
+	if err != nil {
+		logrus.Fatalf("Error occurred on payment provider initialization: %s\n", err.Error())
+	}
 
+	// emails aren't sent without the password, but the rest of the api works, e.g. for local checkout testing
 	emailPassword := os.Getenv("EMAIL_PASSWORD")
-	if apiKey == "" {
-		logrus.Fatalln("Email password is empty")
+	if emailPassword == "" {
+		logrus.Warnln("Email password is empty")
 	}
 
 	emailSender := email.NewSMTPClient(
@@ -78,6 +105,19 @@ func main() {
 		viper.GetString("email.sender.email"),
 		emailPassword)
 
+	// transactions are queried between min_age and max_age since the order, the job does nothing with empty window
+	reconciliationMinAge := viper.GetDuration("payments.reconciliation.min_age")
+	reconciliationMaxAge := viper.GetDuration("payments.reconciliation.max_age")
+	if reconciliationMinAge < 0 || reconciliationMinAge >= reconciliationMaxAge {
+		logrus.Fatalf("Payment reconciliation min_age %s should be less than max_age %s\n", reconciliationMinAge, reconciliationMaxAge)
+	}
+
+	// confirmation emails are sent without the lookup link when ttl isn't set
+	orderLookupTokenTTL := viper.GetDuration("order_lookup_token_ttl")
+	if orderLookupTokenTTL <= 0 {
+		logrus.Fatalf("Order lookup token ttl should be positive, got %s\n", orderLookupTokenTTL)
+	}
+
 	// Init Dependecies
 	repos := repository.NewRepository(db)
 	services := service.NewServices(service.Dependencies{
@@ -103,20 +143,80 @@ func main() {
 		PaymentInfoCustomerTemplate: viper.GetString("email.templates.payment_info_customer"),
 		PaymentInfoCustomerSubject:  viper.GetString("email.subjects.payment_info_customer"),
 
+		ShippingInfoCustomerTemplate: viper.GetString("email.templates.shipping_info_customer"),
+		ShippingInfoCustomerSubject:  viper.GetString("email.subjects.shipping_info_customer"),
+
+		RefundInfoCustomerTemplate: viper.GetString("email.templates.refund_info_customer"),
+		RefundInfoCustomerSubject:  viper.GetString("email.subjects.refund_info_customer"),
+
+		EmailVerificationTemplate: viper.GetString("email.templates.email_verification"),
+		EmailVerificationSubject:  viper.GetString("email.subjects.email_verification"),
+
+		PasswordResetTemplate: viper.GetString("email.templates.password_reset"),
+		PasswordResetSubject:  viper.GetString("email.subjects.password_reset"),
+
+		CartReminderTemplate: viper.GetString("email.templates.cart_reminder"),
+		CartReminderSubject:  viper.GetString("email.subjects.cart_reminder"),
+
+		PaymentReminderTemplate: viper.GetString("email.templates.payment_reminder"),
+		PaymentReminderSubject:  viper.GetString("email.subjects.payment_reminder"),
+
 		EmailSender: emailSender,
 
-		MinimalOrderSum: float32(viper.GetFloat64("minimal_order_sum")),
+		MinimalOrderSum: jewerly.MoneyFromFloat(viper.GetFloat64("minimal_order_sum")),
+		OrderLookupURL:  viper.GetString("order_lookup_url"),
+
+		OrderLookupTokenTTL: orderLookupTokenTTL,
+
+		StockReservationTTL: viper.GetDuration("stock.reservation_ttl"),
+
+		PaymentCallbackToken:    callbackToken,
+		PaymentAuthorizationTTL: viper.GetDuration("payments.authorization_ttl"),
+
+		ReconciliationMinAge: reconciliationMinAge,
+		ReconciliationMaxAge: reconciliationMaxAge,
+
+		EmailVerificationURL: viper.GetString("email_verification_url"),
+		ResetPasswordURL:     viper.GetString("reset_password_url"),
+
+		CartURL:            viper.GetString("cart_url"),
+		UnsubscribeURL:     viper.GetString("unsubscribe_url"),
+		CartReminderDelay:  viper.GetDuration("reminders.cart_delay"),
+		OrderReminderDelay: viper.GetDuration("reminders.order_delay"),
+		ReminderMaxAge:     viper.GetDuration("reminders.max_age"),
+
+		InvoiceNumberPrefix: viper.GetString("invoice.number_prefix"),
+		InvoiceSeller: invoice.Seller{
+			Name:    viper.GetString("invoice.seller.name"),
+			Address: viper.GetString("invoice.seller.address"),
+			TaxId:   viper.GetString("invoice.seller.tax_id"),
+			Email:   viper.GetString("invoice.seller.email"),
+		},
 	})
 	handlers := handler.NewHandler(services)
+	router := handlers.Init()
+
+	// fake provider hosts its payment page on the api server, so checkout can be tested locally
+	if page, ok := paymentProvider.(*payment.FakeProvider); ok {
+		router.Any(payment.FakePagePath+"/:sale", gin.WrapH(page))
+	}
 
 	// Create & Run HTTP Server
 	server := jewerly.NewServer()
 	go func() {
-		if err := server.Run(viper.GetString("port"), handlers.Init()); err != nil {
+		if err := server.Run(viper.GetString("port"), router); err != nil {
 			logrus.Errorf("Error occurred while running server: %s\n", err.Error())
 		}
 	}()
 
+	// Run background jobs
+	jobs := scheduler.NewScheduler()
+	jobs.Add("reminders", viper.GetDuration("reminders.interval"), services.Reminder.SendReminders)
+	jobs.Add("stock-reservations", viper.GetDuration("stock.release_interval"), services.Order.CancelExpiredOrders)
+	jobs.Add("stale-authorizations", viper.GetDuration("payments.void_interval"), services.Order.VoidStaleAuthorizations)
+	jobs.Add("payment-reconciliation", viper.GetDuration("payments.reconciliation.interval"), services.Reconciliation.Reconcile)
+	jobs.Start()
+
 	logrus.Info("Application Started")
 
 	// graceful shutdown
@@ -132,6 +232,10 @@ func main() {
 		logrus.Errorf("error occurred while shutting down http server: %s\n", err.Error())
 	}
 
+	if err := jobs.Stop(ctx); err != nil {
+		logrus.Errorf("error occurred while stopping background jobs: %s\n", err.Error())
+	}
+
 	if err := db.Close(); err != nil {
 		logrus.Errorf("error occurred while closing db connection: %s\n", err.Error())
 	}
@@ -0,0 +1,79 @@
+package jewerly
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	CurrencyUSD = "USD"
+	CurrencyEUR = "EUR"
+	CurrencyUAH = "UAH"
+	CurrencyILS = "ILS"
+
+	// BaseCurrency is the currency product prices, promo code amounts and order limits are set in.
+	BaseCurrency = CurrencyUSD
+)
+
+var (
+	ErrInvalidCurrency         = errors.New("invalid currency")
+	ErrExchangeRateNotFound    = errors.New("exchange rate for the currency is not set")
+	ErrBaseCurrencyRate        = errors.New("exchange rate of the base currency can't be changed")
+	ErrInvalidExchangeRate     = errors.New("exchange rate should be positive")
+	ErrInvalidExchangeRateFile = errors.New("invalid exchange rates file, expected lines in format: currency,rate")
+)
+
+var Currencies = map[string]bool{
+	CurrencyUSD: true,
+	CurrencyEUR: true,
+	CurrencyUAH: true,
+	CurrencyILS: true,
+}
+
+// ExchangeRate is the amount of the currency for 1 unit of the base currency.
+type ExchangeRate struct {
+	Currency  string    `json:"currency" db:"currency"`
+	Rate      float64   `json:"rate" db:"rate"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Convert converts amount in the base currency to the rate currency, result is rounded to cents.
+func (r ExchangeRate) Convert(amount Money) Money {
+	return Money(math.Round(float64(amount) * r.Rate))
+}
+
+func (r ExchangeRate) Validate() error {
+	if !Currencies[r.Currency] {
+		return ErrInvalidCurrency
+	}
+
+	if r.Currency == BaseCurrency {
+		return ErrBaseCurrencyRate
+	}
+
+	if r.Rate <= 0 {
+		return ErrInvalidExchangeRate
+	}
+
+	return nil
+}
+
+type SetExchangeRateInput struct {
+	Rate float64 `json:"rate" binding:"required"`
+}
+
+// NormalizeCurrency makes currency codes case insensitive.
+func NormalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+// GetCurrencyFromQuery falls back to the base currency for unknown currencies, the same way as the language query.
+func GetCurrencyFromQuery(query string) string {
+	if currency := NormalizeCurrency(query); Currencies[currency] {
+		return currency
+	}
+
+	return BaseCurrency
+}
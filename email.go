@@ -13,19 +13,31 @@ type OrderInfoEmailInput struct {
 	PostalCode        string
 	Email             string
 	CardMask          string
-	TotalCost         string
+	TotalCost         Money
+	Currency          string
+	PromoCode         string
+	Discount          Money
+	FreeShipping      bool
+	ShippingMethod    string
+	ShippingCost      Money
+	NetAmount         Money
+	TaxAmount         Money
+	TaxRate           float64
+	TaxInclusive      bool
 	TransactionId     string
 	TransactionStatus string
 	OrderedAt         time.Time
 	OrderedAtFormated string
 	Products          []ProductInfo
+	LookupURL         string
 }
 
 type ProductInfo struct {
 	Id       int
 	Title    string
+	Variant  string
 	Quantity int
-	Price    float32
+	Price    Money
 	ImageURL string
 }
 
@@ -34,9 +46,60 @@ type PaymentInfoEmailInput struct {
 	OrderId       int
 	CardMask      string
 	CardBrand     string
-	Price         float32
+	Price         Money
 	Currency      string
 	BuyerName     string
 	BuyerEmail    string
 	Status        string
+	NetAmount     Money
+	TaxAmount     Money
+	TaxRate       float64
+	TaxInclusive  bool
+
+	// InvoiceFile is attached to the customer email when the invoice is issued.
+	InvoiceNumber string
+	InvoiceFile   []byte
+}
+
+type ShippingInfoEmailInput struct {
+	OrderId        int
+	FirstName      string
+	LastName       string
+	Email          string
+	Country        string
+	Address        string
+	PostalCode     string
+	Carrier        string
+	TrackingNumber string
+	TrackingURL    string
+}
+
+// RefundEmailInput amounts are in the order currency, Items are the returned products.
+type RefundEmailInput struct {
+	OrderId   int
+	FirstName string
+	Email     string
+	Amount    Money
+	Refunded  Money
+	TotalCost Money
+	Currency  string
+	Reason    string
+	Full      bool
+	Items     []ProductInfo
+}
+
+type UserTokenEmailInput struct {
+	FirstName string
+	Email     string
+	URL       string
+}
+
+type ReminderEmailInput struct {
+	OrderId        int
+	FirstName      string
+	Email          string
+	TotalCost      Money
+	Currency       string
+	CheckoutURL    string
+	UnsubscribeURL string
 }
@@ -0,0 +1,27 @@
+package jewerly
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvoiceNotFound = errors.New("invoice not found")
+
+// Invoice is issued once the order is paid, numbers are sequential without gaps.
+// The file is kept in the private storage, because it contains customer details.
+type Invoice struct {
+	Id        int       `json:"id" db:"id"`
+	OrderId   int       `json:"order_id" db:"order_id"`
+	Number    int       `json:"number" db:"number"`
+	FileName  string    `json:"-" db:"file_name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// DisplayNumber is the number printed on the invoice, it's formatted by the service with the configured prefix.
+	DisplayNumber string `json:"display_number" db:"-"`
+}
+
+// FormatInvoiceNumber returns invoice number as it's printed on the invoice, e.g. SR-000042.
+func FormatInvoiceNumber(prefix string, number int) string {
+	return fmt.Sprintf("%s%06d", prefix, number)
+}
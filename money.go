@@ -0,0 +1,234 @@
+package jewerly
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// minorUnits is the number of minor units in the major one, all supported currencies have cents.
+const minorUnits = 100
+
+var ErrInvalidMoney = errors.New("invalid money amount")
+
+// Money is an amount in minor units of the currency, so sums and conversions don't lose cents.
+// Currency of the amount is the one of the product or order it belongs to, base currency by default.
+// It's stored in DECIMAL(10, 2) columns and marshaled to JSON as a decimal number.
+type Money int64
+
+// MoneyFromFloat rounds the amount to cents, it should be used only for values that aren't prices,
+// like configuration values.
+func MoneyFromFloat(amount float64) Money {
+	return Money(math.Round(amount * minorUnits))
+}
+
+// ParseMoney parses decimal amount exactly, amounts with more than 2 fraction digits are rounded half away from zero.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	parts := strings.SplitN(s, ".", 2)
+	if parts[0] == "" && (len(parts) == 1 || parts[1] == "") {
+		return 0, ErrInvalidMoney
+	}
+
+	major, err := parseDigits(parts[0])
+	if err != nil {
+		return 0, err
+	}
+
+	var minor, roundDigit int64
+	if len(parts) == 2 {
+		fraction := parts[1]
+		if _, err := parseDigits(fraction); err != nil {
+			return 0, err
+		}
+
+		fraction += "00"
+		minor, _ = parseDigits(fraction[:2])
+		if len(fraction) > 2 {
+			roundDigit, _ = parseDigits(fraction[2:3])
+		}
+	}
+
+	if major > (math.MaxInt64-minorUnits)/minorUnits {
+		return 0, ErrInvalidMoney
+	}
+
+	amount := major*minorUnits + minor
+	if roundDigit >= 5 {
+		amount++
+	}
+
+	if negative {
+		amount = -amount
+	}
+
+	return Money(amount), nil
+}
+
+func parseDigits(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, ErrInvalidMoney
+		}
+	}
+
+	value, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidMoney
+	}
+
+	return value, nil
+}
+
+// MinorUnits is the amount in cents, payment providers accept amounts in this form.
+func (m Money) MinorUnits() int64 {
+	return int64(m)
+}
+
+// Mul returns the amount for quantity of items.
+func (m Money) Mul(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// Percent returns percent of the amount rounded to cents.
+func (m Money) Percent(percent float64) Money {
+	return Money(math.Round(float64(m) * percent / 100))
+}
+
+// String formats the amount with 2 fraction digits, the way it's stored in database.
+func (m Money) String() string {
+	sign := ""
+	amount := int64(m)
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, amount/minorUnits, amount%minorUnits)
+}
+
+// MarshalJSON keeps the amount a number in API responses, trailing zeros are omitted: 199.9, 200.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strings.TrimRight(strings.TrimRight(m.String(), "0"), ".")), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	amount, err := ParseMoney(string(bytes.Trim(data, `"`)))
+	if err != nil {
+		return err
+	}
+
+	*m = amount
+
+	return nil
+}
+
+// Scan reads DECIMAL column, which is returned by the driver as text, without converting it to float.
+func (m *Money) Scan(src interface{}) error {
+	var (
+		amount Money
+		err    error
+	)
+
+	switch value := src.(type) {
+	case []byte:
+		amount, err = ParseMoney(string(value))
+	case string:
+		amount, err = ParseMoney(value)
+	case int64:
+		amount = Money(value * minorUnits)
+	case int:
+		amount = Money(value * minorUnits)
+	case float64:
+		amount, err = ParseMoney(strconv.FormatFloat(value, 'f', -1, 64))
+	case nil:
+		amount = 0
+	default:
+		err = fmt.Errorf("can't scan %T into money", src)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*m = amount
+
+	return nil
+}
+
+// Value passes the amount as decimal text, so postgres gets the exact value.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// NullMoney is an optional amount, e.g. sale price or a field of the update input.
+type NullMoney struct {
+	Money Money
+	Valid bool
+}
+
+func NullMoneyFrom(m Money) NullMoney {
+	return NullMoney{Money: m, Valid: true}
+}
+
+func (m NullMoney) MarshalJSON() ([]byte, error) {
+	if !m.Valid {
+		return []byte("null"), nil
+	}
+
+	return m.Money.MarshalJSON()
+}
+
+func (m *NullMoney) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*m = NullMoney{}
+		return nil
+	}
+
+	if err := m.Money.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	m.Valid = true
+
+	return nil
+}
+
+func (m *NullMoney) Scan(src interface{}) error {
+	if src == nil {
+		*m = NullMoney{}
+		return nil
+	}
+
+	if err := m.Money.Scan(src); err != nil {
+		return err
+	}
+
+	m.Valid = true
+
+	return nil
+}
+
+func (m NullMoney) Value() (driver.Value, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+
+	return m.Money.Value()
+}
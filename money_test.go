@@ -0,0 +1,120 @@
+package jewerly
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	testTable := []struct {
+		name    string
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{name: "Cents", input: "19.99", want: 1999},
+		{name: "One Fraction Digit", input: "0.1", want: 10},
+		{name: "No Fraction", input: "100", want: 10000},
+		{name: "Trailing Dot", input: "5.", want: 500},
+		{name: "Leading Dot", input: ".5", want: 50},
+		{name: "Negative", input: "-5.50", want: -550},
+		{name: "Rounded Up", input: "1.005", want: 101},
+		{name: "Rounded Down", input: "1.0049", want: 100},
+		{name: "Max Decimal", input: "99999999.99", want: 9999999999},
+		{name: "Empty", input: "", wantErr: true},
+		{name: "Dot Only", input: ".", wantErr: true},
+		{name: "Letters", input: "abc", wantErr: true},
+		{name: "Two Dots", input: "1.2.3", wantErr: true},
+		{name: "Exponent", input: "1e2", wantErr: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := ParseMoney(testCase.input)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+// TestMoney_DecimalRoundTrip checks that amounts written to DECIMAL(10, 2) columns are read back unchanged,
+// including values which can't be represented exactly as floats.
+func TestMoney_DecimalRoundTrip(t *testing.T) {
+	amounts := []Money{0, 1, 10, 99, 1999, 2990, 10001, 33333, 123456789, 9999999999}
+	for i := Money(0); i < 100000; i += 7 {
+		amounts = append(amounts, i)
+	}
+
+	for _, amount := range amounts {
+		value, err := amount.Value()
+		assert.NoError(t, err)
+
+		// postgres driver returns DECIMAL columns as text
+		var scanned Money
+		assert.NoError(t, scanned.Scan([]byte(value.(string))))
+		assert.Equal(t, amount, scanned)
+
+		data, err := json.Marshal(amount)
+		assert.NoError(t, err)
+
+		var unmarshaled Money
+		assert.NoError(t, json.Unmarshal(data, &unmarshaled))
+		assert.Equal(t, amount, unmarshaled)
+	}
+}
+
+func TestMoney_Totals(t *testing.T) {
+	price, err := ParseMoney("19.99")
+	assert.NoError(t, err)
+
+	// float32(19.99) * 100 is 1998.9999 and was truncated to 1998 cents
+	assert.Equal(t, int64(1999), price.MinorUnits())
+
+	total := price.Mul(3) + Money(1)
+	assert.Equal(t, "59.98", total.String())
+
+	rate := ExchangeRate{Currency: CurrencyUAH, Rate: 27.85}
+	assert.Equal(t, "556.72", rate.Convert(price).String())
+	assert.Equal(t, "2.00", price.Percent(10).String())
+}
+
+func TestMoney_JSON(t *testing.T) {
+	testTable := []struct {
+		name  string
+		input interface{}
+		want  string
+	}{
+		{name: "Cents", input: Money(1999), want: "19.99"},
+		{name: "Trailing Zero", input: Money(1990), want: "19.9"},
+		{name: "Whole", input: Money(20000), want: "200"},
+		{name: "Zero", input: Money(0), want: "0"},
+		{name: "Negative", input: Money(-50), want: "-0.5"},
+		{name: "Null", input: NullMoney{}, want: "null"},
+		{name: "Not Null", input: NullMoneyFrom(1), want: "0.01"},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			data, err := json.Marshal(testCase.input)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, string(data))
+		})
+	}
+}
+
+func TestNullMoney_Scan(t *testing.T) {
+	var m NullMoney
+	assert.NoError(t, m.Scan(nil))
+	assert.Equal(t, NullMoney{}, m)
+
+	assert.NoError(t, m.Scan([]byte("149.90")))
+	assert.Equal(t, NullMoneyFrom(14990), m)
+
+	assert.Error(t, m.Scan([]byte("abc")))
+}
@@ -3,6 +3,7 @@ package jewerly
 import (
 	"errors"
 	"gopkg.in/guregu/null.v3"
+	"net/url"
 	"time"
 )
 
@@ -14,12 +15,69 @@ const (
 	TransactionStatusRefunded   = "Payment Refunded"
 	TransactionStatusChargeback = "Payment Chargeback"
 	TransactionStatusReverted   = "Payment Reverted"
+
+	TransactionStatusPartiallyRefunded = "Payment Partially Refunded"
+	TransactionStatusVoided            = "Payment Voided"
+
+	OrderStatusNew        = "new"
+	OrderStatusAuthorized = "authorized"
+	OrderStatusPaid       = "paid"
+	OrderStatusPacked     = "packed"
+	OrderStatusShipped    = "shipped"
+	OrderStatusDelivered  = "delivered"
+	OrderStatusCancelled  = "cancelled"
+	OrderStatusRefunded   = "refunded"
+
+	// StatusChangedByPayment is recorded as the author of status changes derived from payment callbacks
+	StatusChangedByPayment = "payment"
+	// StatusChangedByTimeout is recorded when unpaid order is cancelled after stock reservation expires
+	StatusChangedByTimeout = "timeout"
 )
 
 var (
-	ErrOrderSumLow = errors.New("order sum is too low")
+	ErrOrderSumLow           = errors.New("order sum is too low")
+	ErrOrderNotFound         = errors.New("order not found")
+	ErrInvalidOrderStatus    = errors.New("invalid order status")
+	ErrOrderStatusTransition = errors.New("order status transition is not allowed")
+	ErrInvalidOrderToken     = errors.New("invalid order token")
+	ErrInsufficientStock     = errors.New("not enough items in stock")
+
+	ErrInvalidCallbackToken  = errors.New("invalid payment callback token")
+	ErrTransactionNotFound   = errors.New("transaction not found")
+	ErrPaymentAmountMismatch = errors.New("payment amount or currency doesn't match the order")
+	ErrDuplicateCallback     = errors.New("payment callback is already processed")
+	ErrCallbackIgnored       = errors.New("payment callback doesn't change the payment status")
+	ErrOrderNotAuthorized    = errors.New("order payment isn't authorized")
+	ErrPaymentAuthorized     = errors.New("order payment is authorized, it has to be captured or voided")
 )
 
+// orderStatusTransitions lists statuses an order can move to from the current one,
+// cancelled and refunded orders are final. Authorized payment is either captured, making the order paid, or voided.
+var orderStatusTransitions = map[string][]string{
+	OrderStatusNew:        {OrderStatusAuthorized, OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusAuthorized: {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:       {OrderStatusPacked, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusPacked:     {OrderStatusShipped, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusShipped:    {OrderStatusDelivered, OrderStatusRefunded},
+	OrderStatusDelivered:  {OrderStatusRefunded},
+	OrderStatusCancelled:  {},
+	OrderStatusRefunded:   {},
+}
+
+func ValidateOrderStatusTransition(from, to string) error {
+	if _, ok := orderStatusTransitions[to]; !ok {
+		return ErrInvalidOrderStatus
+	}
+
+	for _, status := range orderStatusTransitions[from] {
+		if status == to {
+			return nil
+		}
+	}
+
+	return ErrOrderStatusTransition
+}
+
 type CreateOrderInput struct {
 	Items          []OrderItem `json:"items" binding:"required"`
 	FirstName      string      `json:"first_name"  binding:"required"`
@@ -30,8 +88,23 @@ type CreateOrderInput struct {
 	Country        string      `json:"country"  binding:"required"`
 	Address        string      `json:"address"  binding:"required"`
 	PostalCode     string      `json:"postal_code"  binding:"required"`
+	PromoCode      string      `json:"promo_code"`
+	Currency       string      `json:"currency"`
 	TransactionID  string
-	TotalCost      float32
+	TotalCost      Money
+	UserId         null.Int      `json:"-"`
+	Discount       OrderDiscount `json:"-"`
+
+	// ExchangeRate is the rate of the order currency at the moment of ordering, totals are stored in the order currency.
+	ExchangeRate float64 `json:"-"`
+
+	// Shipping is the chosen method with calculated cost, it's included in TotalCost.
+	// Method can be omitted while no shipping zones are configured, shipping isn't charged then.
+	ShippingMethodId int           `json:"shipping_method_id"`
+	Shipping         OrderShipping `json:"-"`
+
+	// Tax is the breakdown of TotalCost by the destination country tax rate.
+	Tax OrderTax `json:"-"`
 }
 
 func (i CreateOrderInput) Validate() error {
@@ -39,6 +112,10 @@ func (i CreateOrderInput) Validate() error {
 		return errors.New("order should have at least 1 item")
 	}
 
+	if i.Currency != "" && !Currencies[NormalizeCurrency(i.Currency)] {
+		return ErrInvalidCurrency
+	}
+
 	for _, item := range i.Items {
 		if err := item.Validate(); err != nil {
 			return err
@@ -49,12 +126,19 @@ func (i CreateOrderInput) Validate() error {
 }
 
 type OrderItem struct {
-	ProductId int `json:"product_id" db:"product_id"  binding:"required,min=1"`
-	Quantity  int `json:"quantity" db:"quantity" binding:"required,min=1"`
+	ProductId int      `json:"product_id" db:"product_id"  binding:"required,min=1"`
+	Quantity  int      `json:"quantity" db:"quantity" binding:"required,min=1"`
+	VariantId null.Int `json:"variant_id" db:"variant_id"`
+
+	// Price is the unit price in the base currency at the moment of ordering, it's set by the service.
+	Price Money `json:"-" db:"price"`
+
+	// MadeToOrder item is produced after the order, so it doesn't reserve stock. It's set by the service.
+	MadeToOrder bool `json:"-" db:"made_to_order"`
 }
 
 func (i OrderItem) Validate() error {
-	if i.ProductId < 1 || i.Quantity < 1 {
+	if i.ProductId < 1 || i.Quantity < 1 || (i.VariantId.Valid && i.VariantId.Int64 < 1) {
 		return errors.New("order item is invalid")
 	}
 
@@ -80,10 +164,21 @@ type TransactionCallbackInput struct {
 	SalePaidDate       string `form:"sale_paid_date"`
 	SaleReleaseDate    string `form:"sale_release_date"`
 	SaleInvoiceURL     string `form:"sale_invoice_url"`
+
+	// Token is the secret from the callback URL query, it proves the callback is sent by the provider.
+	Token string `form:"-"`
+
+	// SaleId identifies the sale in the provider, it's required to refund the payment.
+	SaleId string `form:"payme_sale_id"`
+
+	// EventId identifies the provider notification together with the status, so statuses that can repeat for the sale,
+	// like partial refunds, are processed once per notification. Refund callback has the provider refund id in it.
+	EventId string `form:"payme_transaction_id"`
 }
 
 type Order struct {
 	Id             int           `json:"id" db:"id"`
+	UserId         null.Int      `json:"user_id" db:"user_id"`
 	OrderedAt      time.Time     `json:"ordered_at" db:"ordered_at"`
 	FirstName      string        `json:"first_name" db:"first_name"`
 	LastName       string        `json:"last_name" db:"last_name"`
@@ -92,9 +187,73 @@ type Order struct {
 	Address        string        `json:"address" db:"address"`
 	Email          string        `json:"email" db:"email"`
 	PostalCode     string        `json:"postal_code" db:"postal_code"`
-	TotalCost      float32       `json:"total_cost" db:"total_cost"`
+	TotalCost      Money         `json:"total_cost" db:"total_cost"`
+	PromoCode      null.String   `json:"promo_code" db:"promo_code"`
+	Discount       Money         `json:"discount" db:"discount"`
+	FreeShipping   bool          `json:"free_shipping" db:"free_shipping"`
+	Currency       string        `json:"currency" db:"currency"`
+	ExchangeRate   float64       `json:"exchange_rate" db:"exchange_rate"`
 	Items          []OrderItem   `json:"items"`
 	Transactions   []Transaction `json:"transactions"`
+
+	Status          string              `json:"status" db:"status"`
+	StatusUpdatedAt time.Time           `json:"status_updated_at" db:"status_updated_at"`
+	StatusUpdatedBy null.String         `json:"status_updated_by" db:"status_updated_by"`
+	StatusHistory   []OrderStatusChange `json:"status_history,omitempty"`
+
+	Carrier        null.String `json:"carrier" db:"carrier"`
+	TrackingNumber null.String `json:"tracking_number" db:"tracking_number"`
+	TrackingURL    null.String `json:"tracking_url" db:"tracking_url"`
+	ShippedAt      null.Time   `json:"shipped_at" db:"shipped_at"`
+
+	// ShippingCost is included in TotalCost, method name is kept in case the method is changed or deleted.
+	ShippingMethodId null.Int    `json:"shipping_method_id" db:"shipping_method_id"`
+	ShippingMethod   null.String `json:"shipping_method" db:"shipping_method"`
+	ShippingCost     Money       `json:"shipping_cost" db:"shipping_cost"`
+
+	// NetAmount and TaxAmount sum up to TotalCost, TaxRate is in percents.
+	NetAmount    Money   `json:"net_amount" db:"net_amount"`
+	TaxAmount    Money   `json:"tax_amount" db:"tax_amount"`
+	TaxRate      float64 `json:"tax_rate" db:"tax_rate"`
+	TaxInclusive bool    `json:"tax_inclusive" db:"tax_inclusive"`
+
+	Refunds []Refund `json:"refunds,omitempty"`
+}
+
+type OrderStatusChange struct {
+	Status    string    `json:"status" db:"status"`
+	ChangedBy string    `json:"changed_by" db:"changed_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type UpdateOrderStatusInput struct {
+	Status string `json:"status" binding:"required"`
+}
+
+func (i UpdateOrderStatusInput) Validate() error {
+	if _, ok := orderStatusTransitions[i.Status]; !ok {
+		return ErrInvalidOrderStatus
+	}
+
+	return nil
+}
+
+type ShipOrderInput struct {
+	Carrier        string `json:"carrier" binding:"required"`
+	TrackingNumber string `json:"tracking_number" binding:"required"`
+	TrackingURL    string `json:"tracking_url"`
+}
+
+func (i ShipOrderInput) Validate() error {
+	if i.TrackingURL == "" {
+		return nil
+	}
+
+	if u, err := url.Parse(i.TrackingURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.New("invalid tracking url")
+	}
+
+	return nil
 }
 
 type Transaction struct {
@@ -104,12 +263,79 @@ type Transaction struct {
 	CreatedAt     time.Time   `json:"created_at" db:"created_at"`
 }
 
+// Total isn't calculated for pages requested with a cursor.
 type OrderList struct {
-	Data  []Order `json:"data"`
-	Total int     `json:"total"`
+	Data       []Order `json:"data"`
+	Total      int     `json:"total"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+type OrderLookupInput struct {
+	OrderId  int
+	Email    string
+	Token    string
+	Language string
+}
+
+func (i OrderLookupInput) Validate() error {
+	if i.Token == "" && (i.OrderId <= 0 || i.Email == "") {
+		return errors.New("order id and email or token are required")
+	}
+
+	return nil
+}
+
+// CustomerOrder is an order view that is safe to show to a customer without authorization.
+type CustomerOrder struct {
+	Id                int                 `json:"id"`
+	OrderedAt         time.Time           `json:"ordered_at"`
+	FirstName         string              `json:"first_name"`
+	LastName          string              `json:"last_name"`
+	Country           string              `json:"country"`
+	Address           string              `json:"address"`
+	PostalCode        string              `json:"postal_code"`
+	TotalCost         Money               `json:"total_cost"`
+	PromoCode         null.String         `json:"promo_code"`
+	Discount          Money               `json:"discount"`
+	FreeShipping      bool                `json:"free_shipping"`
+	Currency          string              `json:"currency"`
+	ShippingMethod    null.String         `json:"shipping_method"`
+	ShippingCost      Money               `json:"shipping_cost"`
+	NetAmount         Money               `json:"net_amount"`
+	TaxAmount         Money               `json:"tax_amount"`
+	TaxRate           float64             `json:"tax_rate"`
+	TaxInclusive      bool                `json:"tax_inclusive"`
+	Status            string              `json:"status"`
+	StatusUpdatedAt   time.Time           `json:"status_updated_at"`
+	TransactionStatus string              `json:"transaction_status"`
+	Items             []CustomerOrderItem `json:"items"`
+	Carrier           null.String         `json:"carrier"`
+	TrackingNumber    null.String         `json:"tracking_number"`
+	TrackingURL       null.String         `json:"tracking_url"`
+	ShippedAt         null.Time           `json:"shipped_at"`
+}
+
+type CustomerOrderItem struct {
+	ProductId     int         `json:"product_id" db:"product_id"`
+	Title         string      `json:"title" db:"title"`
+	Price         Money       `json:"price" db:"price"`
+	Quantity      int         `json:"quantity" db:"quantity"`
+	VariantId     null.Int    `json:"variant_id" db:"variant_id"`
+	VariantOption null.String `json:"variant_option" db:"option_type"`
+	VariantValue  null.String `json:"variant_value" db:"option_value"`
+	Images        []Image     `json:"images"`
+}
+
+// Total isn't calculated for pages requested with a cursor.
+type CustomerOrderList struct {
+	Data       []CustomerOrder `json:"data"`
+	Total      int             `json:"total"`
+	NextCursor string          `json:"next_cursor,omitempty"`
 }
 
 type GetAllOrdersFilters struct {
 	Offset int
 	Limit  int
+	Cursor string
+	UserId null.Int
 }
@@ -2,11 +2,14 @@ package email
 
 import (
 	"bytes"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"github.com/sirupsen/logrus"
 	"html/template"
+	"mime/multipart"
 	"net/mail"
+	"net/textproto"
 )
 
 type Email struct {
@@ -17,6 +20,14 @@ type Email struct {
 
 	Subject string
 	Body    string
+
+	Attachments []Attachment
+}
+
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
 }
 
 type Sender interface {
@@ -35,9 +46,70 @@ func (m *Email) EmailBytes() ([]byte, error) {
 		msg += fmt.Sprintf("%s: %s\r\n", k, v)
 	}
 
-	msg += "\r\n" + m.Body
+	if len(m.Attachments) == 0 {
+		msg += "\r\n" + m.Body
+		return []byte(msg), nil
+	}
 
-	return []byte(msg), nil
+	body, err := m.multipartBody()
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(msg), body...), nil
+}
+
+// multipartBody writes Content-Type header with the boundary and the body with HTML part followed by attachments.
+func (m *Email) multipartBody() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+
+	fmt.Fprintf(buf, "MIME-Version: 1.0\r\nContent-Type: multipart/mixed; boundary=%s\r\n\r\n", writer.Boundary())
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {`text/html; charset="UTF-8"`}})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := part.Write([]byte(m.Body)); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range m.Attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {fmt.Sprintf("%s; name=%q", attachment.ContentType, attachment.Name)},
+			"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", attachment.Name)},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := part.Write(encodeBase64Lines(attachment.Data)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// encodeBase64Lines splits encoded data to lines of 76 characters, as required for MIME.
+func encodeBase64Lines(data []byte) []byte {
+	const lineLength = 76
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	buf := new(bytes.Buffer)
+	for len(encoded) > lineLength {
+		buf.WriteString(encoded[:lineLength] + "\r\n")
+		encoded = encoded[lineLength:]
+	}
+	buf.WriteString(encoded)
+
+	return buf.Bytes()
 }
 
 func (m *Email) GenerateBodyFromHTML(templateFileName string, data interface{}) error {
@@ -86,7 +158,11 @@ func (m *Email) generateHeaders() map[string]string {
 	header["To"] = to.String()
 	header["From"] = from.String()
 	header["Subject"] = m.Subject
-	header["Content-Type"] = `text/html; charset="UTF-8"`
+
+	// multipart Content-Type is written with the body, because it includes the boundary
+	if len(m.Attachments) == 0 {
+		header["Content-Type"] = `text/html; charset="UTF-8"`
+	}
 
 	return header
 }
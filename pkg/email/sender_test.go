@@ -1,8 +1,14 @@
 package email
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/mail"
 	"strings"
 	"testing"
 )
@@ -17,6 +23,11 @@ func TestEmail_EmailBytes(t *testing.T) {
 			name:  "OK",
 			email: Email{ToEmail: "test@test.com", ToName: "Test", FromEmail: "zhashkevychmaksim@gmail.com", FromName: "Maksim", Body: "hey yo!", Subject: "HEY!"},
 		},
+		{
+			name: "With Attachment",
+			email: Email{ToEmail: "test@test.com", ToName: "Test", FromEmail: "zhashkevychmaksim@gmail.com", FromName: "Maksim", Body: "hey yo!", Subject: "HEY!",
+				Attachments: []Attachment{{Name: "invoice.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4")}}},
+		},
 		{
 			name:       "Empty To email",
 			email:      Email{ToEmail: "", ToName: "Test", FromEmail: "zhashkevychmaksim@gmail.com", FromName: "Maksim", Body: "hey yo!", Subject: "HEY!"},
@@ -92,3 +103,47 @@ func TestEmail_EmailBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestEmail_EmailBytes_Attachments(t *testing.T) {
+	data := bytes.Repeat([]byte("%PDF-1.4 invoice"), 20)
+	email := Email{ToEmail: "test@test.com", ToName: "Test", FromEmail: "zhashkevychmaksim@gmail.com", FromName: "Maksim", Body: "hey yo!", Subject: "HEY!",
+		Attachments: []Attachment{{Name: "SR-000001.pdf", ContentType: "application/pdf", Data: data}}}
+
+	got, err := email.EmailBytes()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(got))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	assert.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+
+	part, err := reader.NextPart()
+	if !assert.NoError(t, err) {
+		return
+	}
+	body, _ := ioutil.ReadAll(part)
+	assert.Equal(t, `text/html; charset="UTF-8"`, part.Header.Get("Content-Type"))
+	assert.Equal(t, "hey yo!", string(body))
+
+	part, err = reader.NextPart()
+	if !assert.NoError(t, err) {
+		return
+	}
+	encoded, _ := ioutil.ReadAll(part)
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+	assert.NoError(t, err)
+	assert.Equal(t, "SR-000001.pdf", part.FileName())
+	assert.Equal(t, data, decoded)
+
+	for _, line := range strings.Split(string(encoded), "\r\n") {
+		assert.LessOrEqual(t, len(line), 76)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"io"
 	"net/http"
 	"strconv"
 )
@@ -126,6 +127,139 @@ func (h *Handler) deleteProduct(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+// bulkSaleRoute serves /products/bulk-sale, gin router doesn't allow a static path segment
+// next to the wildcard one of /products/:id/variants.
+func (h *Handler) bulkSaleRoute(c *gin.Context) {
+	if c.Param("id") != "bulk-sale" {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	h.bulkSale(c)
+}
+
+// bulkSale sets sale prices for all products of the category.
+func (h *Handler) bulkSale(c *gin.Context) {
+	var inp jewerly.BulkSaleInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind bulkSaleInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := inp.Validate(); err != nil {
+		logrus.Errorf("Failed to validate input body: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	updated, err := h.services.Product.SetCategorySale(inp)
+	if err != nil {
+		logrus.Errorf("Failed to set category sale: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"updated": updated,
+	})
+}
+
+// Product Variants Handlers
+func (h *Handler) createVariant(c *gin.Context) {
+	var inp jewerly.CreateVariantInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind createVariantInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := inp.Validate(); err != nil {
+		logrus.Errorf("Failed to validate input body: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	productId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := h.services.Product.CreateVariant(productId, inp)
+	if err != nil {
+		logrus.Errorf("Failed to create product variant: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) updateVariant(c *gin.Context) {
+	var inp jewerly.UpdateVariantInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind updateVariantInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := inp.Validate(); err != nil {
+		logrus.Errorf("Failed to validate input body: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	productId, variantId, err := getVariantParams(c)
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.services.Product.UpdateVariant(productId, variantId, inp); err != nil {
+		logrus.Errorf("Failed to update product variant: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) deleteVariant(c *gin.Context) {
+	productId, variantId, err := getVariantParams(c)
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.services.Product.DeleteVariant(productId, variantId); err != nil {
+		logrus.Errorf("Failed to delete product variant: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func getVariantParams(c *gin.Context) (int, int, error) {
+	productId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	variantId, err := strconv.Atoi(c.Param("variant_id"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return productId, variantId, nil
+}
+
 func (h *Handler) getAllProducts(c *gin.Context) {
 	products, err := h.services.Product.GetAll(getProductFilters(c))
 	if err != nil {
@@ -137,6 +271,34 @@ func (h *Handler) getAllProducts(c *gin.Context) {
 	c.JSON(http.StatusOK, products)
 }
 
+// getProductOrSearch serves both /products/search and /products/:id,
+// gin router doesn't allow a static path segment next to the wildcard one.
+func (h *Handler) getProductOrSearch(c *gin.Context) {
+	if c.Param("id") == "search" {
+		h.searchProducts(c)
+		return
+	}
+
+	h.getProduct(c)
+}
+
+func (h *Handler) searchProducts(c *gin.Context) {
+	filters := getSearchFilters(c)
+	if filters.Query == "" {
+		newErrorResponse(c, http.StatusBadRequest, errors.New("empty search query"))
+		return
+	}
+
+	products, err := h.services.Product.Search(filters)
+	if err != nil {
+		logrus.Errorf("Failed to search products: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+}
+
 func (h *Handler) getProduct(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -152,8 +314,9 @@ func (h *Handler) getProduct(c *gin.Context) {
 	}
 
 	language := jewerly.GetLanguageFromQuery(c.Query("language"))
+	currency := jewerly.GetCurrencyFromQuery(c.Query("currency"))
 
-	product, err := h.services.Product.GetById(id, language)
+	product, err := h.services.Product.GetById(id, language, currency)
 	if err != nil {
 		logrus.Errorf("Failed to delete product: %s\n", err.Error())
 		newErrorResponse(c, getStatusCode(err), err)
@@ -229,3 +392,160 @@ func (h *Handler) getOrder(c *gin.Context) {
 
 	c.JSON(http.StatusOK, order)
 }
+
+func (h *Handler) updateOrderStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var inp jewerly.UpdateOrderStatusInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind updateOrderStatusInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := inp.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.services.Order.UpdateStatus(id, inp.Status, getAdminLogin(c)); err != nil {
+		logrus.Errorf("Failed to update order status: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) shipOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var inp jewerly.ShipOrderInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind shipOrderInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := inp.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.services.Order.Ship(id, inp, getAdminLogin(c)); err != nil {
+		logrus.Errorf("Failed to set order shipment: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) refundOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var inp jewerly.RefundOrderInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind refundOrderInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := inp.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	refundId, err := h.services.Order.Refund(id, inp, getAdminLogin(c))
+	if err != nil {
+		logrus.Errorf("Failed to refund order: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]interface{}{
+		"id": refundId,
+	})
+}
+
+// completeOrderRefund saves the pending refund, which is made in the payment provider, but wasn't saved because of an error.
+func (h *Handler) completeOrderRefund(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	refundId, err := strconv.Atoi(c.Param("refund_id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse refund id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// body is optional, provider refund id may be unknown to the admin
+	var inp jewerly.CompleteRefundInput
+	if err := c.ShouldBindJSON(&inp); err != nil && err != io.EOF {
+		logrus.Errorf("Failed to bind completeRefundInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := h.services.Order.CompleteRefund(id, refundId, inp); err != nil {
+		logrus.Errorf("Failed to complete order refund: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) captureOrderPayment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.services.Order.Capture(id, getAdminLogin(c)); err != nil {
+		logrus.Errorf("Failed to capture order payment: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) voidOrderPayment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.services.Order.Void(id, getAdminLogin(c)); err != nil {
+		logrus.Errorf("Failed to void order payment: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
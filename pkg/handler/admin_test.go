@@ -133,7 +133,7 @@ func TestHandler_createProduct(t *testing.T) {
 					Ukrainian: "Матеріал",
 					Russian:   "Материал",
 				},
-				Price:      199.99,
+				Price:      19999,
 				Code:       "ABC123",
 				ImageIds:   []int{1},
 				CategoryId: jewerly.CategoryBracelets,
@@ -283,7 +283,7 @@ func TestHandler_updateProduct(t *testing.T) {
 					Ukrainian: "Матеріал",
 					Russian:   "Материал",
 				},
-				Price:      null.NewFloat(199.99, true),
+				Price:      jewerly.NullMoneyFrom(19999),
 				Code:       null.NewString("ABC123", true),
 				CategoryId: newCategory(jewerly.CategoryBracelets),
 			},
@@ -307,7 +307,7 @@ func TestHandler_updateProduct(t *testing.T) {
 					Ukrainian: "Матеріал",
 					Russian:   "Материал",
 				},
-				Price:      null.NewFloat(199.99, true),
+				Price:      jewerly.NullMoneyFrom(19999),
 				Code:       null.NewString("ABC123", true),
 				CategoryId: newCategory(jewerly.CategoryBracelets),
 			},
@@ -326,7 +326,7 @@ func TestHandler_updateProduct(t *testing.T) {
 					Ukrainian: "Матеріал",
 					Russian:   "Материал",
 				},
-				Price:      null.NewFloat(199.99, true),
+				Price:      jewerly.NullMoneyFrom(19999),
 				Code:       null.NewString("ABC123", true),
 				CategoryId: newCategory(jewerly.CategoryBracelets),
 			},
@@ -340,7 +340,7 @@ func TestHandler_updateProduct(t *testing.T) {
 			fixturePath: "./fixtures/products/update.no_materials.json",
 			id:          1,
 			inputProduct: jewerly.UpdateProductInput{
-				Price:      null.NewFloat(199.99, true),
+				Price:      jewerly.NullMoneyFrom(19999),
 				Code:       null.NewString("ABC123", true),
 				CategoryId: newCategory(jewerly.CategoryBracelets),
 			},
@@ -495,13 +495,15 @@ func TestHandler_deleteProduct(t *testing.T) {
 
 func TestHandler_getProduct(t *testing.T) {
 	// Init Test Data
-	type mockBehavior func(r *mock_service.MockProduct, product jewerly.ProductResponse, id int, language string)
+	type mockBehavior func(r *mock_service.MockProduct, product jewerly.ProductResponse, id int, language, currency string)
 
 	testCases := []struct {
 		name                 string
 		id                   int
 		language             string
 		languageQuery        string
+		currency             string
+		currencyQuery        string
 		product              jewerly.ProductResponse
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
@@ -512,15 +514,16 @@ func TestHandler_getProduct(t *testing.T) {
 			id:            1,
 			language:      jewerly.English,
 			languageQuery: "en",
-			mockBehavior: func(r *mock_service.MockProduct, product jewerly.ProductResponse, id int, language string) {
-				r.EXPECT().GetById(id, language).Return(product, nil)
+			currency:      jewerly.BaseCurrency,
+			mockBehavior: func(r *mock_service.MockProduct, product jewerly.ProductResponse, id int, language, currency string) {
+				r.EXPECT().GetById(id, language, currency).Return(product, nil)
 			},
 			product: jewerly.ProductResponse{
 				Id:          1,
 				Title:       "product",
 				Description: "description",
 				Material:    "material",
-				Price:       199.99,
+				Price:       19999,
 				Code:        null.NewString("ABC123", true),
 				Images: []jewerly.Image{
 					{
@@ -530,23 +533,25 @@ func TestHandler_getProduct(t *testing.T) {
 				},
 				CategoryId: jewerly.CategoryRings,
 				InStock:    true,
+				Stock:      1,
 			},
 			expectedStatusCode:   200,
-			expectedResponseBody: `{"id":1,"title":"product","description":"description","material":"material","price":199.99,"code":"ABC123","images":[{"id":1,"url":"http://image","alt_text":null}],"category_id":1,"in_stock":true}`,
+			expectedResponseBody: `{"id":1,"title":"product","description":"description","material":"material","price":199.99,"code":"ABC123","images":[{"id":1,"url":"http://image","alt_text":null}],"category_id":1,"in_stock":true,"stock":1,"weight":0,"made_to_order":false,"sale_price":null,"sale_starts_at":null,"sale_ends_at":null,"on_sale":false,"currency":"","variants":null}`,
 		},
 		{
 			name:     "No Language Query",
 			id:       1,
 			language: jewerly.English,
-			mockBehavior: func(r *mock_service.MockProduct, product jewerly.ProductResponse, id int, language string) {
-				r.EXPECT().GetById(id, language).Return(product, nil)
+			currency: jewerly.BaseCurrency,
+			mockBehavior: func(r *mock_service.MockProduct, product jewerly.ProductResponse, id int, language, currency string) {
+				r.EXPECT().GetById(id, language, currency).Return(product, nil)
 			},
 			product: jewerly.ProductResponse{
 				Id:          1,
 				Title:       "product",
 				Description: "description",
 				Material:    "material",
-				Price:       199.99,
+				Price:       19999,
 				Code:        null.NewString("ABC123", true),
 				Images: []jewerly.Image{
 					{
@@ -556,13 +561,37 @@ func TestHandler_getProduct(t *testing.T) {
 				},
 				CategoryId: jewerly.CategoryRings,
 				InStock:    true,
+				Stock:      1,
 			},
 			expectedStatusCode:   200,
-			expectedResponseBody: `{"id":1,"title":"product","description":"description","material":"material","price":199.99,"code":"ABC123","images":[{"id":1,"url":"http://image","alt_text":null}],"category_id":1,"in_stock":true}`,
+			expectedResponseBody: `{"id":1,"title":"product","description":"description","material":"material","price":199.99,"code":"ABC123","images":[{"id":1,"url":"http://image","alt_text":null}],"category_id":1,"in_stock":true,"stock":1,"weight":0,"made_to_order":false,"sale_price":null,"sale_starts_at":null,"sale_ends_at":null,"on_sale":false,"currency":"","variants":null}`,
 		},
 		{
-			name:                 "Id is 0",
-			mockBehavior:         func(r *mock_service.MockProduct, product jewerly.ProductResponse, id int, language string) {},
+			name:          "Currency Query",
+			id:            1,
+			language:      jewerly.English,
+			languageQuery: "en",
+			currency:      jewerly.CurrencyUAH,
+			currencyQuery: "uah",
+			mockBehavior: func(r *mock_service.MockProduct, product jewerly.ProductResponse, id int, language, currency string) {
+				r.EXPECT().GetById(id, language, currency).Return(product, nil)
+			},
+			product: jewerly.ProductResponse{
+				Id:         1,
+				Title:      "product",
+				Price:      549963,
+				CategoryId: jewerly.CategoryRings,
+				InStock:    true,
+				Stock:      1,
+				Currency:   jewerly.CurrencyUAH,
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1,"title":"product","description":"","material":"","price":5499.63,"code":null,"images":null,"category_id":1,"in_stock":true,"stock":1,"weight":0,"made_to_order":false,"sale_price":null,"sale_starts_at":null,"sale_ends_at":null,"on_sale":false,"currency":"UAH","variants":null}`,
+		},
+		{
+			name: "Id is 0",
+			mockBehavior: func(r *mock_service.MockProduct, product jewerly.ProductResponse, id int, language, currency string) {
+			},
 			expectedStatusCode:   400,
 			expectedResponseBody: `{"error":"id can't be zero"}`,
 		},
@@ -570,8 +599,9 @@ func TestHandler_getProduct(t *testing.T) {
 			name:     "Service Error",
 			id:       1,
 			language: jewerly.English,
-			mockBehavior: func(r *mock_service.MockProduct, product jewerly.ProductResponse, id int, language string) {
-				r.EXPECT().GetById(id, language).Return(product, errors.New("failed to get product"))
+			currency: jewerly.BaseCurrency,
+			mockBehavior: func(r *mock_service.MockProduct, product jewerly.ProductResponse, id int, language, currency string) {
+				r.EXPECT().GetById(id, language, currency).Return(product, errors.New("failed to get product"))
 			},
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"error":"failed to get product"}`,
@@ -585,7 +615,7 @@ func TestHandler_getProduct(t *testing.T) {
 			defer c.Finish()
 
 			product := mock_service.NewMockProduct(c)
-			test.mockBehavior(product, test.product, test.id, test.language)
+			test.mockBehavior(product, test.product, test.id, test.language, test.currency)
 
 			services := &service.Services{Product: product}
 			handler := Handler{services}
@@ -596,7 +626,168 @@ func TestHandler_getProduct(t *testing.T) {
 
 			// Create Request
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", fmt.Sprintf("/product/%d?language=%s", test.id, test.languageQuery), nil)
+			req := httptest.NewRequest("GET", fmt.Sprintf("/product/%d?language=%s&currency=%s", test.id, test.languageQuery, test.currencyQuery), nil)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getAllProducts(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockProduct, filters jewerly.GetAllProductsFilters)
+
+	testCases := []struct {
+		name                 string
+		query                string
+		filters              jewerly.GetAllProductsFilters
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:    "Ok",
+			query:   "?sort=price_asc&limit=1",
+			filters: jewerly.GetAllProductsFilters{Language: jewerly.English, Currency: jewerly.BaseCurrency, Limit: 1, Sort: jewerly.SortPriceAsc},
+			mockBehavior: func(r *mock_service.MockProduct, filters jewerly.GetAllProductsFilters) {
+				r.EXPECT().GetAll(filters).Return(jewerly.ProductsList{Total: 2, NextCursor: "eyJpZCI6MX0"}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":null,"total":2,"next_cursor":"eyJpZCI6MX0"}`,
+		},
+		{
+			name:    "Invalid Cursor",
+			query:   "?sort=price_asc&cursor=eyJzIjoibmV3ZXN0IiwiaWQiOjF9",
+			filters: jewerly.GetAllProductsFilters{Language: jewerly.English, Currency: jewerly.BaseCurrency, Limit: 20, Sort: jewerly.SortPriceAsc, Cursor: "eyJzIjoibmV3ZXN0IiwiaWQiOjF9"},
+			mockBehavior: func(r *mock_service.MockProduct, filters jewerly.GetAllProductsFilters) {
+				r.EXPECT().GetAll(filters).Return(jewerly.ProductsList{}, jewerly.ErrInvalidCursor)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid cursor"}`,
+		},
+		{
+			name:    "Service Error",
+			filters: jewerly.GetAllProductsFilters{Language: jewerly.English, Currency: jewerly.BaseCurrency, Limit: 20},
+			mockBehavior: func(r *mock_service.MockProduct, filters jewerly.GetAllProductsFilters) {
+				r.EXPECT().GetAll(filters).Return(jewerly.ProductsList{}, errors.New("failed to get products"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"failed to get products"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			product := mock_service.NewMockProduct(c)
+			test.mockBehavior(product, test.filters)
+
+			services := &service.Services{Product: product}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/products", handler.getAllProducts)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/products"+test.query, nil)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_searchProducts(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockProduct, filters jewerly.SearchProductsFilters)
+
+	testCases := []struct {
+		name                 string
+		query                string
+		filters              jewerly.SearchProductsFilters
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:    "Ok",
+			query:   "?q=silver+ring&language=ru&limit=10",
+			filters: jewerly.SearchProductsFilters{Query: "silver ring", Language: jewerly.Russian, Currency: jewerly.BaseCurrency, Limit: 10},
+			mockBehavior: func(r *mock_service.MockProduct, filters jewerly.SearchProductsFilters) {
+				r.EXPECT().Search(filters).Return(jewerly.ProductsList{
+					Products: []jewerly.ProductResponse{{Id: 1, Title: "Ring", Price: 10000, CategoryId: jewerly.CategoryRings}},
+					Total:    1,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":1,"title":"Ring","description":"","material":"","price":100,"code":null,"images":null,"category_id":1,"in_stock":false,"stock":0,"weight":0,"made_to_order":false,"sale_price":null,"sale_starts_at":null,"sale_ends_at":null,"on_sale":false,"currency":"","variants":null}],"total":1}`,
+		},
+		{
+			name:    "Ok - Invalid Limit And Offset",
+			query:   "?q=ring&limit=-1&offset=abc",
+			filters: jewerly.SearchProductsFilters{Query: "ring", Language: jewerly.English, Currency: jewerly.BaseCurrency, Limit: 20},
+			mockBehavior: func(r *mock_service.MockProduct, filters jewerly.SearchProductsFilters) {
+				r.EXPECT().Search(filters).Return(jewerly.ProductsList{}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":null,"total":0}`,
+		},
+		{
+			name:                 "Empty Query",
+			mockBehavior:         func(r *mock_service.MockProduct, filters jewerly.SearchProductsFilters) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"empty search query"}`,
+		},
+		{
+			name:                 "Blank Query",
+			query:                "?q=++&language=en",
+			mockBehavior:         func(r *mock_service.MockProduct, filters jewerly.SearchProductsFilters) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"empty search query"}`,
+		},
+		{
+			name:    "Service Error",
+			query:   "?q=ring",
+			filters: jewerly.SearchProductsFilters{Query: "ring", Language: jewerly.English, Currency: jewerly.BaseCurrency, Limit: 20},
+			mockBehavior: func(r *mock_service.MockProduct, filters jewerly.SearchProductsFilters) {
+				r.EXPECT().Search(filters).Return(jewerly.ProductsList{}, errors.New("failed to search products"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"failed to search products"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			product := mock_service.NewMockProduct(c)
+			test.mockBehavior(product, test.filters)
+
+			services := &service.Services{Product: product}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/products/:id", handler.getProductOrSearch)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/products/search"+test.query, nil)
 
 			// Make Request
 			r.ServeHTTP(w, req)
@@ -752,3 +943,661 @@ func uploadRequest(url string, file *os.File, stat os.FileInfo) (*http.Request,
 
 	return req, nil
 }
+
+func TestHandler_updateOrderStatus(t *testing.T) {
+	// Init Test Data
+	type mockBehavior func(r *mock_service.MockOrder, id int, status string)
+
+	testCases := []struct {
+		name                 string
+		id                   int
+		status               string
+		body                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Ok",
+			id:     1,
+			status: "packed",
+			body:   `{"status":"packed"}`,
+			mockBehavior: func(r *mock_service.MockOrder, id int, status string) {
+				r.EXPECT().UpdateStatus(id, status, "admin").Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:                 "Empty Status",
+			id:                   1,
+			body:                 `{}`,
+			mockBehavior:         func(r *mock_service.MockOrder, id int, status string) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:                 "Unknown Status",
+			id:                   1,
+			body:                 `{"status":"lost"}`,
+			mockBehavior:         func(r *mock_service.MockOrder, id int, status string) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid order status"}`,
+		},
+		{
+			name:   "Invalid Transition",
+			id:     1,
+			status: "delivered",
+			body:   `{"status":"delivered"}`,
+			mockBehavior: func(r *mock_service.MockOrder, id int, status string) {
+				r.EXPECT().UpdateStatus(id, status, "admin").Return(jewerly.ErrOrderStatusTransition)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"error":"order status transition is not allowed"}`,
+		},
+		{
+			name:   "Not Found",
+			id:     1,
+			status: "paid",
+			body:   `{"status":"paid"}`,
+			mockBehavior: func(r *mock_service.MockOrder, id int, status string) {
+				r.EXPECT().UpdateStatus(id, status, "admin").Return(jewerly.ErrOrderNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"order not found"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			order := mock_service.NewMockOrder(c)
+			test.mockBehavior(order, test.id, test.status)
+
+			services := &service.Services{Order: order}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.PUT("/orders/:id/status", func(c *gin.Context) {
+				c.Set(adminCtx, "admin")
+			}, handler.updateOrderStatus)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/orders/%d/status", test.id), bytes.NewBufferString(test.body))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_shipOrder(t *testing.T) {
+	// Init Test Data
+	type mockBehavior func(r *mock_service.MockOrder, id int, input jewerly.ShipOrderInput)
+
+	testCases := []struct {
+		name                 string
+		id                   int
+		body                 string
+		input                jewerly.ShipOrderInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			id:    1,
+			body:  `{"carrier":"DHL","tracking_number":"123","tracking_url":"https://dhl.com/123"}`,
+			input: jewerly.ShipOrderInput{Carrier: "DHL", TrackingNumber: "123", TrackingURL: "https://dhl.com/123"},
+			mockBehavior: func(r *mock_service.MockOrder, id int, input jewerly.ShipOrderInput) {
+				r.EXPECT().Ship(id, input, "admin").Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:                 "Missing Tracking Number",
+			id:                   1,
+			body:                 `{"carrier":"DHL"}`,
+			mockBehavior:         func(r *mock_service.MockOrder, id int, input jewerly.ShipOrderInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:                 "Invalid Tracking URL",
+			id:                   1,
+			body:                 `{"carrier":"DHL","tracking_number":"123","tracking_url":"javascript:alert(1)"}`,
+			mockBehavior:         func(r *mock_service.MockOrder, id int, input jewerly.ShipOrderInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid tracking url"}`,
+		},
+		{
+			name:  "Order Not Packed",
+			id:    1,
+			body:  `{"carrier":"DHL","tracking_number":"123"}`,
+			input: jewerly.ShipOrderInput{Carrier: "DHL", TrackingNumber: "123"},
+			mockBehavior: func(r *mock_service.MockOrder, id int, input jewerly.ShipOrderInput) {
+				r.EXPECT().Ship(id, input, "admin").Return(jewerly.ErrOrderStatusTransition)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"error":"order status transition is not allowed"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			order := mock_service.NewMockOrder(c)
+			test.mockBehavior(order, test.id, test.input)
+
+			services := &service.Services{Order: order}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.PUT("/orders/:id/shipment", func(c *gin.Context) {
+				c.Set(adminCtx, "admin")
+			}, handler.shipOrder)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/orders/%d/shipment", test.id), bytes.NewBufferString(test.body))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_refundOrder(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockOrder, id int, input jewerly.RefundOrderInput)
+
+	testCases := []struct {
+		name                 string
+		id                   int
+		body                 string
+		input                jewerly.RefundOrderInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			id:    1,
+			body:  `{"amount":10.5,"reason":"damaged","items":[{"product_id":2,"quantity":1}]}`,
+			input: jewerly.RefundOrderInput{Amount: jewerly.NullMoneyFrom(1050), Reason: "damaged", Items: []jewerly.OrderItem{{ProductId: 2, Quantity: 1}}},
+			mockBehavior: func(r *mock_service.MockOrder, id int, input jewerly.RefundOrderInput) {
+				r.EXPECT().Refund(id, input, "admin").Return(3, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":3}`,
+		},
+		{
+			name:  "Full Refund",
+			id:    1,
+			body:  `{"reason":"cancelled by customer"}`,
+			input: jewerly.RefundOrderInput{Reason: "cancelled by customer"},
+			mockBehavior: func(r *mock_service.MockOrder, id int, input jewerly.RefundOrderInput) {
+				r.EXPECT().Refund(id, input, "admin").Return(4, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":4}`,
+		},
+		{
+			name:                 "Missing Reason",
+			id:                   1,
+			body:                 `{"amount":10}`,
+			mockBehavior:         func(r *mock_service.MockOrder, id int, input jewerly.RefundOrderInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:                 "Negative Amount",
+			id:                   1,
+			body:                 `{"amount":-10,"reason":"damaged"}`,
+			mockBehavior:         func(r *mock_service.MockOrder, id int, input jewerly.RefundOrderInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"refund amount should be positive"}`,
+		},
+		{
+			name:  "Amount Exceeded",
+			id:    1,
+			body:  `{"amount":1000,"reason":"damaged"}`,
+			input: jewerly.RefundOrderInput{Amount: jewerly.NullMoneyFrom(100000), Reason: "damaged"},
+			mockBehavior: func(r *mock_service.MockOrder, id int, input jewerly.RefundOrderInput) {
+				r.EXPECT().Refund(id, input, "admin").Return(0, jewerly.ErrRefundAmountExceeded)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"refund amount exceeds the paid amount that isn't refunded yet"}`,
+		},
+		{
+			name:  "Order Not Paid",
+			id:    1,
+			body:  `{"reason":"damaged"}`,
+			input: jewerly.RefundOrderInput{Reason: "damaged"},
+			mockBehavior: func(r *mock_service.MockOrder, id int, input jewerly.RefundOrderInput) {
+				r.EXPECT().Refund(id, input, "admin").Return(0, jewerly.ErrRefundNotAvailable)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"error":"order payment can't be refunded"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			order := mock_service.NewMockOrder(c)
+			test.mockBehavior(order, test.id, test.input)
+
+			services := &service.Services{Order: order}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/orders/:id/refund", func(c *gin.Context) {
+				c.Set(adminCtx, "admin")
+			}, handler.refundOrder)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/orders/%d/refund", test.id), bytes.NewBufferString(test.body))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_completeOrderRefund(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockOrder, input jewerly.CompleteRefundInput)
+
+	testCases := []struct {
+		name                 string
+		path                 string
+		body                 string
+		input                jewerly.CompleteRefundInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			path:  "/orders/1/refunds/3/complete",
+			body:  `{"provider_refund_id":"rf_3"}`,
+			input: jewerly.CompleteRefundInput{ProviderRefundId: "rf_3"},
+			mockBehavior: func(r *mock_service.MockOrder, input jewerly.CompleteRefundInput) {
+				r.EXPECT().CompleteRefund(1, 3, input).Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "Ok - Empty Body",
+			path: "/orders/1/refunds/3/complete",
+			mockBehavior: func(r *mock_service.MockOrder, input jewerly.CompleteRefundInput) {
+				r.EXPECT().CompleteRefund(1, 3, input).Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:                 "Invalid Refund Id",
+			path:                 "/orders/1/refunds/abc/complete",
+			mockBehavior:         func(r *mock_service.MockOrder, input jewerly.CompleteRefundInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+		},
+		{
+			name:                 "Invalid Body",
+			path:                 "/orders/1/refunds/3/complete",
+			body:                 `{"provider_refund_id":3}`,
+			mockBehavior:         func(r *mock_service.MockOrder, input jewerly.CompleteRefundInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name: "Refund Not Pending",
+			path: "/orders/1/refunds/3/complete",
+			mockBehavior: func(r *mock_service.MockOrder, input jewerly.CompleteRefundInput) {
+				r.EXPECT().CompleteRefund(1, 3, input).Return(jewerly.ErrRefundNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"pending refund not found"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			order := mock_service.NewMockOrder(c)
+			test.mockBehavior(order, test.input)
+
+			services := &service.Services{Order: order}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/orders/:id/refunds/:refund_id/complete", handler.completeOrderRefund)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", test.path, bytes.NewBufferString(test.body))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_captureOrderPayment(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockOrder, id int)
+
+	testCases := []struct {
+		name                 string
+		id                   int
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			id:   1,
+			mockBehavior: func(r *mock_service.MockOrder, id int) {
+				r.EXPECT().Capture(id, "admin").Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "Not Authorized",
+			id:   1,
+			mockBehavior: func(r *mock_service.MockOrder, id int) {
+				r.EXPECT().Capture(id, "admin").Return(jewerly.ErrOrderNotAuthorized)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"error":"order payment isn't authorized"}`,
+		},
+		{
+			name: "Provider Error",
+			id:   1,
+			mockBehavior: func(r *mock_service.MockOrder, id int) {
+				r.EXPECT().Capture(id, "admin").Return(errors.New("capture-sale fail: sale expired"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"capture-sale fail: sale expired"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			order := mock_service.NewMockOrder(c)
+			test.mockBehavior(order, test.id)
+
+			services := &service.Services{Order: order}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/orders/:id/capture", func(c *gin.Context) {
+				c.Set(adminCtx, "admin")
+			}, handler.captureOrderPayment)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/orders/%d/capture", test.id), nil)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_voidOrderPayment(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockOrder, id int)
+
+	testCases := []struct {
+		name                 string
+		id                   int
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			id:   1,
+			mockBehavior: func(r *mock_service.MockOrder, id int) {
+				r.EXPECT().Void(id, "admin").Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "Order Not Found",
+			id:   2,
+			mockBehavior: func(r *mock_service.MockOrder, id int) {
+				r.EXPECT().Void(id, "admin").Return(jewerly.ErrOrderNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"order not found"}`,
+		},
+		{
+			name: "Not Authorized",
+			id:   1,
+			mockBehavior: func(r *mock_service.MockOrder, id int) {
+				r.EXPECT().Void(id, "admin").Return(jewerly.ErrOrderNotAuthorized)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"error":"order payment isn't authorized"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			order := mock_service.NewMockOrder(c)
+			test.mockBehavior(order, test.id)
+
+			services := &service.Services{Order: order}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/orders/:id/void", func(c *gin.Context) {
+				c.Set(adminCtx, "admin")
+			}, handler.voidOrderPayment)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/orders/%d/void", test.id), nil)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_createVariant(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockProduct, productId int, input jewerly.CreateVariantInput)
+
+	testCases := []struct {
+		name                 string
+		productId            int
+		inputBody            string
+		input                jewerly.CreateVariantInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			productId: 1,
+			inputBody: `{"sku":"R1-17","option":"size","value":"17","price_delta":10,"stock":2}`,
+			input:     jewerly.CreateVariantInput{SKU: "R1-17", Option: jewerly.VariantOptionSize, Value: "17", PriceDelta: 1000, Stock: 2},
+			mockBehavior: func(r *mock_service.MockProduct, productId int, input jewerly.CreateVariantInput) {
+				r.EXPECT().CreateVariant(productId, input).Return(5, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":5}`,
+		},
+		{
+			name:                 "Invalid Option",
+			productId:            1,
+			inputBody:            `{"sku":"R1-gem","option":"gem","value":"ruby"}`,
+			mockBehavior:         func(r *mock_service.MockProduct, productId int, input jewerly.CreateVariantInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid variant option"}`,
+		},
+		{
+			name:                 "Missing SKU",
+			productId:            1,
+			inputBody:            `{"option":"size","value":"17"}`,
+			mockBehavior:         func(r *mock_service.MockProduct, productId int, input jewerly.CreateVariantInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:      "Already Exists",
+			productId: 1,
+			inputBody: `{"sku":"R1-17","option":"size","value":"17"}`,
+			input:     jewerly.CreateVariantInput{SKU: "R1-17", Option: jewerly.VariantOptionSize, Value: "17"},
+			mockBehavior: func(r *mock_service.MockProduct, productId int, input jewerly.CreateVariantInput) {
+				r.EXPECT().CreateVariant(productId, input).Return(0, jewerly.ErrVariantAlreadyExists)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"error":"product variant with such sku or option already exists"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			product := mock_service.NewMockProduct(c)
+			test.mockBehavior(product, test.productId, test.input)
+
+			services := &service.Services{Product: product}
+			handler := Handler{services}
+
+			r := gin.New()
+			r.POST("/products/:id/variants", handler.createVariant)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/products/%d/variants", test.productId),
+				bytes.NewBufferString(test.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_bulkSale(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockProduct, input jewerly.BulkSaleInput)
+
+	testCases := []struct {
+		name                 string
+		inputBody            string
+		input                jewerly.BulkSaleInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"category_id":1,"discount_percent":15}`,
+			input:     jewerly.BulkSaleInput{CategoryId: jewerly.CategoryRings, DiscountPercent: 15},
+			mockBehavior: func(r *mock_service.MockProduct, input jewerly.BulkSaleInput) {
+				r.EXPECT().SetCategorySale(input).Return(4, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"updated":4}`,
+		},
+		{
+			name:                 "Invalid Discount",
+			inputBody:            `{"category_id":1,"discount_percent":100}`,
+			mockBehavior:         func(r *mock_service.MockProduct, input jewerly.BulkSaleInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"discount percent should be between 0 and 100"}`,
+		},
+		{
+			name:                 "Invalid Period",
+			inputBody:            `{"category_id":1,"discount_percent":10,"starts_at":"2021-01-10T00:00:00Z","ends_at":"2021-01-01T00:00:00Z"}`,
+			mockBehavior:         func(r *mock_service.MockProduct, input jewerly.BulkSaleInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"sale price should be positive and lower than price, sale should end after it starts"}`,
+		},
+		{
+			name:                 "Invalid Category",
+			inputBody:            `{"category_id":100,"discount_percent":10}`,
+			mockBehavior:         func(r *mock_service.MockProduct, input jewerly.BulkSaleInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid category"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			product := mock_service.NewMockProduct(c)
+			test.mockBehavior(product, test.input)
+
+			services := &service.Services{Product: product}
+			handler := Handler{services}
+
+			r := gin.New()
+			r.POST("/products/:id", handler.bulkSaleRoute)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/products/bulk-sale", bytes.NewBufferString(test.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"gopkg.in/guregu/null.v3"
+	"net/http"
+	"strconv"
+)
+
+const cartTokenHeader = "X-Cart-Token"
+
+func getCartIdentity(c *gin.Context) jewerly.CartIdentity {
+	identity := jewerly.CartIdentity{Token: c.GetHeader(cartTokenHeader)}
+	if userId, ok := getUserId(c); ok {
+		identity.UserId = null.IntFrom(userId)
+	}
+
+	return identity
+}
+
+// getCartItemParams returns product id from the path and optional variant id from the query.
+func getCartItemParams(c *gin.Context) (int, null.Int, error) {
+	id, err := strconv.Atoi(c.Param("product_id"))
+	if err != nil || id < 1 {
+		return 0, null.Int{}, errors.New("invalid product id")
+	}
+
+	if c.Query("variant_id") == "" {
+		return id, null.Int{}, nil
+	}
+
+	variantId, err := strconv.Atoi(c.Query("variant_id"))
+	if err != nil || variantId < 1 {
+		return 0, null.Int{}, errors.New("invalid variant id")
+	}
+
+	return id, null.IntFrom(int64(variantId)), nil
+}
+
+func (h *Handler) getCart(c *gin.Context) {
+	cart, err := h.services.Cart.Get(getCartIdentity(c), jewerly.GetLanguageFromQuery(c.Query("language")))
+	if err != nil {
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+func (h *Handler) addCartItem(c *gin.Context) {
+	var inp jewerly.AddCartItemInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	cart, err := h.services.Cart.AddItem(getCartIdentity(c), inp, jewerly.GetLanguageFromQuery(c.Query("language")))
+	if err != nil {
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+func (h *Handler) updateCartItem(c *gin.Context) {
+	productId, variantId, err := getCartItemParams(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var inp jewerly.UpdateCartItemInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	cart, err := h.services.Cart.UpdateItem(getCartIdentity(c), productId, variantId, inp, jewerly.GetLanguageFromQuery(c.Query("language")))
+	if err != nil {
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+func (h *Handler) deleteCartItem(c *gin.Context) {
+	productId, variantId, err := getCartItemParams(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	cart, err := h.services.Cart.DeleteItem(getCartIdentity(c), productId, variantId, jewerly.GetLanguageFromQuery(c.Query("language")))
+	if err != nil {
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+func (h *Handler) clearCart(c *gin.Context) {
+	if err := h.services.Cart.Clear(getCartIdentity(c)); err != nil {
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) checkoutCart(c *gin.Context) {
+	var inp jewerly.CheckoutInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	url, err := h.services.Cart.Checkout(getCartIdentity(c), inp)
+	if err != nil {
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"url": url,
+	})
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/service"
+	mock_service "github.com/zhashkevych/jewelry-shop-backend/pkg/service/mocks"
+	"gopkg.in/guregu/null.v3"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_addCartItem(t *testing.T) {
+	type mockBehaviour func(s *mock_service.MockCart, identity jewerly.CartIdentity, input jewerly.AddCartItemInput)
+
+	testTable := []struct {
+		name                 string
+		cartToken            string
+		userId               int64
+		inputBody            string
+		identity             jewerly.CartIdentity
+		input                jewerly.AddCartItemInput
+		mockBehavior         mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok New Cart",
+			inputBody: `{"product_id":1,"quantity":2}`,
+			input:     jewerly.AddCartItemInput{ProductId: 1, Quantity: 2},
+			mockBehavior: func(s *mock_service.MockCart, identity jewerly.CartIdentity, input jewerly.AddCartItemInput) {
+				s.EXPECT().AddItem(identity, input, jewerly.English).Return(jewerly.Cart{Token: "token", TotalCost: 20000,
+					Items: []jewerly.CartItem{{ProductId: 1, Title: "Ring", Price: 10000, Quantity: 2, InStock: true}}}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{"token":"token","updated_at":"0001-01-01T00:00:00Z","items":[{"product_id":1,"title":"Ring","price":100,` +
+				`"quantity":2,"in_stock":true,"variant_id":null,"variant_option":null,"variant_value":null,"images":null}],"total_cost":200,"has_unavailable":false}`,
+		},
+		{
+			name:      "Ok Signed In",
+			cartToken: "token",
+			userId:    1,
+			inputBody: `{"product_id":1,"quantity":1}`,
+			identity:  jewerly.CartIdentity{Token: "token", UserId: null.IntFrom(1)},
+			input:     jewerly.AddCartItemInput{ProductId: 1, Quantity: 1},
+			mockBehavior: func(s *mock_service.MockCart, identity jewerly.CartIdentity, input jewerly.AddCartItemInput) {
+				s.EXPECT().AddItem(identity, input, jewerly.English).Return(jewerly.Cart{Token: "token"}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"token":"token","updated_at":"0001-01-01T00:00:00Z","items":null,"total_cost":0,"has_unavailable":false}`,
+		},
+		{
+			name:                 "Quantity Zero",
+			inputBody:            `{"product_id":1,"quantity":0}`,
+			mockBehavior:         func(s *mock_service.MockCart, identity jewerly.CartIdentity, input jewerly.AddCartItemInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:      "Product Not Found",
+			cartToken: "token",
+			inputBody: `{"product_id":100,"quantity":1}`,
+			identity:  jewerly.CartIdentity{Token: "token"},
+			input:     jewerly.AddCartItemInput{ProductId: 100, Quantity: 1},
+			mockBehavior: func(s *mock_service.MockCart, identity jewerly.CartIdentity, input jewerly.AddCartItemInput) {
+				s.EXPECT().AddItem(identity, input, jewerly.English).Return(jewerly.Cart{}, jewerly.ErrProductNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"product not found"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			cart := mock_service.NewMockCart(c)
+			testCase.mockBehavior(cart, testCase.identity, testCase.input)
+
+			services := &service.Services{Cart: cart}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/cart/items", func(c *gin.Context) {
+				if testCase.userId != 0 {
+					c.Set(userCtx, testCase.userId)
+				}
+			}, handler.addCartItem)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/cart/items", bytes.NewBufferString(testCase.inputBody))
+			if testCase.cartToken != "" {
+				req.Header.Set(cartTokenHeader, testCase.cartToken)
+			}
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_checkoutCart(t *testing.T) {
+	type mockBehaviour func(s *mock_service.MockCart, identity jewerly.CartIdentity, input jewerly.CheckoutInput)
+
+	input := jewerly.CheckoutInput{
+		FirstName:  "Vasya",
+		LastName:   "Pupkin",
+		Email:      "vasya@pupkin.com",
+		Country:    "UA",
+		Address:    "st. Khreshatyk, Kiev",
+		PostalCode: "12303",
+
+		ShippingMethodId: 1,
+	}
+	body := `{"first_name":"Vasya","last_name":"Pupkin","email":"vasya@pupkin.com","country":"UA","address":"st. Khreshatyk, Kiev","postal_code":"12303",` +
+		`"shipping_method_id":1}`
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: body,
+			mockBehavior: func(s *mock_service.MockCart, identity jewerly.CartIdentity, input jewerly.CheckoutInput) {
+				s.EXPECT().Checkout(identity, input).Return("http://payment.link", nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"url":"http://payment.link"}`,
+		},
+		{
+			name:                 "Email Invalid",
+			inputBody:            `{"first_name":"Vasya","last_name":"Pupkin","email":"vasya","country":"UA","address":"Kiev","postal_code":"12303"}`,
+			mockBehavior:         func(s *mock_service.MockCart, identity jewerly.CartIdentity, input jewerly.CheckoutInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:      "Cart Empty",
+			inputBody: body,
+			mockBehavior: func(s *mock_service.MockCart, identity jewerly.CartIdentity, input jewerly.CheckoutInput) {
+				s.EXPECT().Checkout(identity, input).Return("", jewerly.ErrCartEmpty)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"cart is empty"}`,
+		},
+		{
+			name:      "Items Unavailable",
+			inputBody: body,
+			mockBehavior: func(s *mock_service.MockCart, identity jewerly.CartIdentity, input jewerly.CheckoutInput) {
+				s.EXPECT().Checkout(identity, input).Return("", jewerly.ErrCartItemsUnavailable)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"error":"cart has items that are out of stock"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			cart := mock_service.NewMockCart(c)
+			testCase.mockBehavior(cart, jewerly.CartIdentity{Token: "token"}, input)
+
+			services := &service.Services{Cart: cart}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/cart/checkout", handler.checkoutCart)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/cart/checkout", bytes.NewBufferString(testCase.inputBody))
+			req.Header.Set(cartTokenHeader, "token")
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"net/http"
+)
+
+const maxRatesFileSize = 1 << 20 // 1 megabyte
+
+func (h *Handler) getExchangeRates(c *gin.Context) {
+	rates, err := h.services.Currency.GetRates()
+	if err != nil {
+		logrus.Errorf("Failed to get exchange rates: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
+
+func (h *Handler) setExchangeRate(c *gin.Context) {
+	var inp jewerly.SetExchangeRateInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind setExchangeRateInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := h.services.Currency.SetRate(c.Param("currency"), inp.Rate); err != nil {
+		logrus.Errorf("Failed to set exchange rate: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// importExchangeRates sets rates from uploaded CSV file with "currency,rate" lines.
+func (h *Handler) importExchangeRates(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxRatesFileSize)
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		logrus.Errorf("Failed to get exchange rates file: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	defer file.Close()
+
+	imported, err := h.services.Currency.ImportRates(file)
+	if err != nil {
+		logrus.Errorf("Failed to import exchange rates: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"imported": imported,
+	})
+}
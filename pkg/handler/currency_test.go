@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/service"
+	mock_service "github.com/zhashkevych/jewelry-shop-backend/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_setExchangeRate(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockCurrency, currency string, rate float64)
+
+	testTable := []struct {
+		name                 string
+		currency             string
+		inputBody            string
+		rate                 float64
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			currency:  "uah",
+			inputBody: `{"rate":27.85}`,
+			rate:      27.85,
+			mockBehavior: func(s *mock_service.MockCurrency, currency string, rate float64) {
+				s.EXPECT().SetRate(currency, rate).Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:                 "Empty Rate",
+			currency:             "uah",
+			inputBody:            `{}`,
+			mockBehavior:         func(s *mock_service.MockCurrency, currency string, rate float64) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:      "Invalid Currency",
+			currency:  "gbp",
+			inputBody: `{"rate":0.72}`,
+			rate:      0.72,
+			mockBehavior: func(s *mock_service.MockCurrency, currency string, rate float64) {
+				s.EXPECT().SetRate(currency, rate).Return(jewerly.ErrInvalidCurrency)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid currency"}`,
+		},
+		{
+			name:      "Base Currency",
+			currency:  "usd",
+			inputBody: `{"rate":2}`,
+			rate:      2,
+			mockBehavior: func(s *mock_service.MockCurrency, currency string, rate float64) {
+				s.EXPECT().SetRate(currency, rate).Return(jewerly.ErrBaseCurrencyRate)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"exchange rate of the base currency can't be changed"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			currency := mock_service.NewMockCurrency(c)
+			testCase.mockBehavior(currency, testCase.currency, testCase.rate)
+
+			services := &service.Services{Currency: currency}
+			handler := Handler{services}
+
+			r := gin.New()
+			r.PUT("/exchange-rates/:currency", handler.setExchangeRate)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/exchange-rates/"+testCase.currency, bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	jewerly "github.com/zhashkevych/jewelry-shop-backend"
 	"gopkg.in/guregu/null.v3"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -15,6 +16,7 @@ const (
 func getProductFilters(c *gin.Context) jewerly.GetAllProductsFilters {
 	filters := jewerly.GetAllProductsFilters{
 		Language: jewerly.GetLanguageFromQuery(c.Query("language")),
+		Currency: jewerly.GetCurrencyFromQuery(c.Query("currency")),
 	}
 
 	limit, err := strconv.Atoi(c.Query("limit"))
@@ -31,22 +33,84 @@ func getProductFilters(c *gin.Context) jewerly.GetAllProductsFilters {
 		filters.Offset = offset
 	}
 
-	categoryId, err := strconv.Atoi(c.Query("category"))
-	if err != nil {
-		return filters
+	filters.CategoryIds = getCategoryIds(c)
+
+	if minPrice, err := strconv.ParseFloat(c.Query("min_price"), 64); err == nil && minPrice >= 0 {
+		filters.MinPrice = null.FloatFrom(minPrice)
+	}
+
+	if maxPrice, err := strconv.ParseFloat(c.Query("max_price"), 64); err == nil && maxPrice >= 0 {
+		filters.MaxPrice = null.FloatFrom(maxPrice)
+	}
+
+	if inStock, err := strconv.ParseBool(c.Query("in_stock")); err == nil {
+		filters.InStock = null.BoolFrom(inStock)
+	}
+
+	if material := strings.TrimSpace(c.Query("material")); material != "" {
+		filters.Material = null.StringFrom(material)
 	}
 
-	if err := jewerly.Category(categoryId).Validate(); err != nil {
-		return filters
+	if sort := c.Query("sort"); jewerly.ProductSorts[sort] {
+		filters.Sort = sort
 	}
 
-	filters.CategoryId = null.NewInt(int64(categoryId), true)
+	filters.Cursor = c.Query("cursor")
+
+	return filters
+}
+
+// getCategoryIds accepts both repeated (category=1&category=2) and comma separated (category=1,2) values,
+// invalid categories are skipped.
+func getCategoryIds(c *gin.Context) []int {
+	var ids []int
+
+	for _, value := range c.QueryArray("category") {
+		for _, part := range strings.Split(value, ",") {
+			categoryId, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				continue
+			}
+
+			if err := jewerly.Category(categoryId).Validate(); err != nil {
+				continue
+			}
+
+			ids = append(ids, categoryId)
+		}
+	}
+
+	return ids
+}
+
+func getSearchFilters(c *gin.Context) jewerly.SearchProductsFilters {
+	filters := jewerly.SearchProductsFilters{
+		Query:    strings.TrimSpace(c.Query("q")),
+		Language: jewerly.GetLanguageFromQuery(c.Query("language")),
+		Currency: jewerly.GetCurrencyFromQuery(c.Query("currency")),
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit <= 0 {
+		filters.Limit = defaultLimit
+	} else {
+		filters.Limit = limit
+	}
+
+	offset, err := strconv.Atoi(c.Query("offset"))
+	if err != nil || offset < 0 {
+		filters.Offset = defaultOffset
+	} else {
+		filters.Offset = offset
+	}
 
 	return filters
 }
 
 func getOrderFilters(c *gin.Context) jewerly.GetAllOrdersFilters {
-	var filters jewerly.GetAllOrdersFilters
+	filters := jewerly.GetAllOrdersFilters{
+		Cursor: c.Query("cursor"),
+	}
 
 	limit, err := strconv.Atoi(c.Query("limit"))
 	if err != nil || limit <= 0{
@@ -63,3 +127,24 @@ func getOrderFilters(c *gin.Context) jewerly.GetAllOrdersFilters {
 	}
 	return filters
 }
+
+func getPaymentDiscrepancyFilters(c *gin.Context) jewerly.PaymentDiscrepancyFilters {
+	filters := jewerly.PaymentDiscrepancyFilters{
+		Limit:  defaultLimit,
+		Offset: defaultOffset,
+	}
+
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 {
+		filters.Limit = limit
+	}
+
+	if offset, err := strconv.Atoi(c.Query("offset")); err == nil && offset >= 0 {
+		filters.Offset = offset
+	}
+
+	if resolved, err := strconv.ParseBool(c.Query("resolved")); err == nil {
+		filters.Resolved = null.BoolFrom(resolved)
+	}
+
+	return filters
+}
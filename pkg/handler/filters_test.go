@@ -26,10 +26,11 @@ func Test_getProductFilters(t *testing.T) {
 			offset:   "10",
 			category: "1",
 			expected: jewerly.GetAllProductsFilters{
-				Language:   jewerly.English,
-				Offset:     10,
-				Limit:      10,
-				CategoryId: null.NewInt(1, true),
+				Language:    jewerly.English,
+				Currency:    jewerly.BaseCurrency,
+				Offset:      10,
+				Limit:       10,
+				CategoryIds: []int{1},
 			},
 		},
 		{
@@ -39,10 +40,11 @@ func Test_getProductFilters(t *testing.T) {
 			offset:   "10",
 			category: "1",
 			expected: jewerly.GetAllProductsFilters{
-				Language:   jewerly.English,
-				Offset:     10,
-				Limit:      10,
-				CategoryId: null.NewInt(1, true),
+				Language:    jewerly.English,
+				Currency:    jewerly.BaseCurrency,
+				Offset:      10,
+				Limit:       10,
+				CategoryIds: []int{1},
 			},
 		},
 		{
@@ -52,10 +54,11 @@ func Test_getProductFilters(t *testing.T) {
 			offset:   "10",
 			category: "1",
 			expected: jewerly.GetAllProductsFilters{
-				Language:   jewerly.English,
-				Offset:     10,
-				Limit:      20,
-				CategoryId: null.NewInt(1, true),
+				Language:    jewerly.English,
+				Currency:    jewerly.BaseCurrency,
+				Offset:      10,
+				Limit:       20,
+				CategoryIds: []int{1},
 			},
 		},
 		{
@@ -65,10 +68,11 @@ func Test_getProductFilters(t *testing.T) {
 			offset:   "",
 			category: "1",
 			expected: jewerly.GetAllProductsFilters{
-				Language:   jewerly.English,
-				Offset:     0,
-				Limit:      20,
-				CategoryId: null.NewInt(1, true),
+				Language:    jewerly.English,
+				Currency:    jewerly.BaseCurrency,
+				Offset:      0,
+				Limit:       20,
+				CategoryIds: []int{1},
 			},
 		},
 		{
@@ -78,9 +82,10 @@ func Test_getProductFilters(t *testing.T) {
 			offset:   "",
 			category: "",
 			expected: jewerly.GetAllProductsFilters{
-				Language:   jewerly.English,
-				Offset:     0,
-				Limit:      20,
+				Language: jewerly.English,
+				Currency: jewerly.BaseCurrency,
+				Offset:   0,
+				Limit:    20,
 			},
 		},
 		{
@@ -90,9 +95,10 @@ func Test_getProductFilters(t *testing.T) {
 			offset:   "",
 			category: "",
 			expected: jewerly.GetAllProductsFilters{
-				Language:   jewerly.Russian,
-				Offset:     0,
-				Limit:      20,
+				Language: jewerly.Russian,
+				Currency: jewerly.BaseCurrency,
+				Offset:   0,
+				Limit:    20,
 			},
 		},
 		{
@@ -102,9 +108,10 @@ func Test_getProductFilters(t *testing.T) {
 			offset:   "",
 			category: "",
 			expected: jewerly.GetAllProductsFilters{
-				Language:   jewerly.Ukraininan,
-				Offset:     0,
-				Limit:      20,
+				Language: jewerly.Ukraininan,
+				Currency: jewerly.BaseCurrency,
+				Offset:   0,
+				Limit:    20,
 			},
 		},
 		{
@@ -114,9 +121,10 @@ func Test_getProductFilters(t *testing.T) {
 			offset:   "",
 			category: "",
 			expected: jewerly.GetAllProductsFilters{
-				Language:   jewerly.Ukraininan,
-				Offset:     0,
-				Limit:      20,
+				Language: jewerly.Ukraininan,
+				Currency: jewerly.BaseCurrency,
+				Offset:   0,
+				Limit:    20,
 			},
 		},
 		{
@@ -126,9 +134,10 @@ func Test_getProductFilters(t *testing.T) {
 			offset:   "",
 			category: "",
 			expected: jewerly.GetAllProductsFilters{
-				Language:   jewerly.Ukraininan,
-				Offset:     0,
-				Limit:      20,
+				Language: jewerly.Ukraininan,
+				Currency: jewerly.BaseCurrency,
+				Offset:   0,
+				Limit:    20,
 			},
 		},
 		{
@@ -138,9 +147,10 @@ func Test_getProductFilters(t *testing.T) {
 			offset:   "-10",
 			category: "",
 			expected: jewerly.GetAllProductsFilters{
-				Language:   jewerly.Ukraininan,
-				Offset:     0,
-				Limit:      20,
+				Language: jewerly.Ukraininan,
+				Currency: jewerly.BaseCurrency,
+				Offset:   0,
+				Limit:    20,
 			},
 		},
 		{
@@ -150,9 +160,10 @@ func Test_getProductFilters(t *testing.T) {
 			offset:   "",
 			category: "10",
 			expected: jewerly.GetAllProductsFilters{
-				Language:   jewerly.Ukraininan,
-				Offset:     0,
-				Limit:      20,
+				Language: jewerly.Ukraininan,
+				Currency: jewerly.BaseCurrency,
+				Offset:   0,
+				Limit:    20,
 			},
 		},
 	}
@@ -175,64 +186,208 @@ func Test_getProductFilters(t *testing.T) {
 	}
 }
 
+func Test_getProductFilters_Options(t *testing.T) {
+	testTable := []struct {
+		name     string
+		query    string
+		expected jewerly.GetAllProductsFilters
+	}{
+		{
+			name:  "Ok - Multiple Categories",
+			query: "category=1&category=3",
+			expected: jewerly.GetAllProductsFilters{
+				Language:    jewerly.English,
+				Currency:    jewerly.BaseCurrency,
+				Limit:       20,
+				CategoryIds: []int{1, 3},
+			},
+		},
+		{
+			name:  "Ok - Comma Separated Categories",
+			query: "category=1,2,10",
+			expected: jewerly.GetAllProductsFilters{
+				Language:    jewerly.English,
+				Currency:    jewerly.BaseCurrency,
+				Limit:       20,
+				CategoryIds: []int{1, 2},
+			},
+		},
+		{
+			name:  "Ok - Price Range",
+			query: "min_price=100&max_price=250.5",
+			expected: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Currency: jewerly.BaseCurrency,
+				Limit:    20,
+				MinPrice: null.FloatFrom(100),
+				MaxPrice: null.FloatFrom(250.5),
+			},
+		},
+		{
+			name:  "Ok - Negative Price",
+			query: "min_price=-100&max_price=abc",
+			expected: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Currency: jewerly.BaseCurrency,
+				Limit:    20,
+			},
+		},
+		{
+			name:  "Ok - In Stock",
+			query: "in_stock=true",
+			expected: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Currency: jewerly.BaseCurrency,
+				Limit:    20,
+				InStock:  null.BoolFrom(true),
+			},
+		},
+		{
+			name:  "Ok - Material",
+			query: "material=silver&language=ru",
+			expected: jewerly.GetAllProductsFilters{
+				Language: jewerly.Russian,
+				Currency: jewerly.BaseCurrency,
+				Limit:    20,
+				Material: null.StringFrom("silver"),
+			},
+		},
+		{
+			name:  "Ok - Sort",
+			query: "sort=price_desc",
+			expected: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Currency: jewerly.BaseCurrency,
+				Limit:    20,
+				Sort:     jewerly.SortPriceDesc,
+			},
+		},
+		{
+			name:  "Ok - Cursor",
+			query: "sort=newest&cursor=eyJpZCI6MX0",
+			expected: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Currency: jewerly.BaseCurrency,
+				Limit:    20,
+				Sort:     jewerly.SortNewest,
+				Cursor:   "eyJpZCI6MX0",
+			},
+		},
+		{
+			name:  "Ok - Currency",
+			query: "currency=eur&min_price=100",
+			expected: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Currency: jewerly.CurrencyEUR,
+				Limit:    20,
+				MinPrice: null.FloatFrom(100),
+			},
+		},
+		{
+			name:  "Ok - Unknown Currency",
+			query: "currency=gbp",
+			expected: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Currency: jewerly.BaseCurrency,
+				Limit:    20,
+			},
+		},
+		{
+			name:  "Ok - Invalid Sort",
+			query: "sort=cheapest",
+			expected: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Currency: jewerly.BaseCurrency,
+				Limit:    20,
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := &gin.Context{
+				Request: &http.Request{
+					URL: &url.URL{
+						RawQuery: testCase.query,
+					},
+				},
+			}
+
+			result := getProductFilters(ctx)
+
+			assert.Equal(t, result, testCase.expected)
+		})
+	}
+}
+
 func Test_getOrderFilters(t *testing.T) {
 	testTable := []struct {
 		name          string
 		limit, offset string
+		cursor        string
 		expected      jewerly.GetAllOrdersFilters
 	}{
 		{
-			name:     "Ok",
-			limit:    "10",
-			offset:   "10",
+			name:   "Ok",
+			limit:  "10",
+			offset: "10",
 			expected: jewerly.GetAllOrdersFilters{
-				Offset:     10,
-				Limit:      10,
+				Offset: 10,
+				Limit:  10,
 			},
 		},
 		{
-			name:     "Ok - Empty Limit",
-			limit:    "",
-			offset:   "10",
+			name:   "Ok - Empty Limit",
+			limit:  "",
+			offset: "10",
 			expected: jewerly.GetAllOrdersFilters{
-				Offset:     10,
-				Limit:      20,
+				Offset: 10,
+				Limit:  20,
 			},
 		},
 		{
-			name:     "Ok - Empty Offset",
-			limit:    "",
-			offset:   "",
+			name:   "Ok - Empty Offset",
+			limit:  "",
+			offset: "",
 			expected: jewerly.GetAllOrdersFilters{
-				Offset:     0,
-				Limit:      20,
+				Offset: 0,
+				Limit:  20,
 			},
 		},
 		{
-			name:     "Ok - Zero Limit",
-			limit:    "0",
-			offset:   "",
+			name:   "Ok - Zero Limit",
+			limit:  "0",
+			offset: "",
 			expected: jewerly.GetAllOrdersFilters{
-				Offset:     0,
-				Limit:      20,
+				Offset: 0,
+				Limit:  20,
 			},
 		},
 		{
-			name:     "Ok - Negative Limit",
-			limit:    "-10",
-			offset:   "",
+			name:   "Ok - Negative Limit",
+			limit:  "-10",
+			offset: "",
 			expected: jewerly.GetAllOrdersFilters{
-				Offset:     0,
-				Limit:      20,
+				Offset: 0,
+				Limit:  20,
 			},
 		},
 		{
-			name:     "Ok - Negative Offset",
-			limit:    "10",
-			offset:   "-10",
+			name:   "Ok - Negative Offset",
+			limit:  "10",
+			offset: "-10",
+			expected: jewerly.GetAllOrdersFilters{
+				Offset: 0,
+				Limit:  10,
+			},
+		},
+		{
+			name:   "Ok - Cursor",
+			limit:  "10",
+			cursor: "eyJpZCI6MTB9",
 			expected: jewerly.GetAllOrdersFilters{
-				Offset:     0,
-				Limit:      10,
+				Limit:  10,
+				Cursor: "eyJpZCI6MTB9",
 			},
 		},
 	}
@@ -242,7 +397,7 @@ func Test_getOrderFilters(t *testing.T) {
 			ctx := &gin.Context{
 				Request: &http.Request{
 					URL: &url.URL{
-						RawQuery: fmt.Sprintf("limit=%s&offset=%s", testCase.limit, testCase.offset),
+						RawQuery: fmt.Sprintf("limit=%s&offset=%s&cursor=%s", testCase.limit, testCase.offset, testCase.cursor),
 					},
 				},
 			}
@@ -252,4 +407,84 @@ func Test_getOrderFilters(t *testing.T) {
 			assert.Equal(t, result, testCase.expected)
 		})
 	}
-}
\ No newline at end of file
+}
+func Test_getSearchFilters(t *testing.T) {
+	testTable := []struct {
+		name          string
+		query         string
+		language      string
+		limit, offset string
+		expected      jewerly.SearchProductsFilters
+	}{
+		{
+			name:     "Ok",
+			query:    "ring",
+			language: "en",
+			limit:    "10",
+			offset:   "10",
+			expected: jewerly.SearchProductsFilters{
+				Query:    "ring",
+				Language: jewerly.English,
+				Currency: jewerly.BaseCurrency,
+				Offset:   10,
+				Limit:    10,
+			},
+		},
+		{
+			name:     "Ok - Language ru",
+			query:    "кольцо",
+			language: "ru",
+			expected: jewerly.SearchProductsFilters{
+				Query:    "кольцо",
+				Language: jewerly.Russian,
+				Currency: jewerly.BaseCurrency,
+				Offset:   0,
+				Limit:    20,
+			},
+		},
+		{
+			name:     "Ok - Trimmed Query",
+			query:    "  silver ring ",
+			language: "ua",
+			expected: jewerly.SearchProductsFilters{
+				Query:    "silver ring",
+				Language: jewerly.Ukraininan,
+				Currency: jewerly.BaseCurrency,
+				Offset:   0,
+				Limit:    20,
+			},
+		},
+		{
+			name:   "Empty Query",
+			limit:  "-10",
+			offset: "-10",
+			expected: jewerly.SearchProductsFilters{
+				Language: jewerly.English,
+				Currency: jewerly.BaseCurrency,
+				Offset:   0,
+				Limit:    20,
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := &gin.Context{
+				Request: &http.Request{
+					URL: &url.URL{
+						RawQuery: url.Values{
+							"q":        []string{testCase.query},
+							"language": []string{testCase.language},
+							"limit":    []string{testCase.limit},
+							"offset":   []string{testCase.offset},
+						}.Encode(),
+					},
+				},
+			}
+
+			result := getSearchFilters(ctx)
+
+			assert.Equal(t, result, testCase.expected)
+		})
+	}
+}
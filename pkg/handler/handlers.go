@@ -30,7 +30,7 @@ func (h *Handler) Init() *gin.Engine {
 
 	// todo move to config
 	config.AllowHeaders = append(config.AllowHeaders, "Access-Control-Request-Headers", "Authorization", "X-Forwarded-For",
-		"Host", "User-Agent", "Accept")
+		"Host", "User-Agent", "Accept", cartTokenHeader)
 	router.Use(cors.New(config))
 
 	// Init router
@@ -49,6 +49,11 @@ func (h *Handler) initPublicRoutes(router *gin.Engine) {
 	auth := router.Group("/auth")
 	{
 		auth.POST("/admin/sign-in", h.adminSignIn)
+		auth.POST("/sign-up", h.userSignUp)
+		auth.POST("/sign-in", h.userSignIn)
+		auth.POST("/verify-email", h.verifyEmail)
+		auth.POST("/forgot-password", h.forgotPassword)
+		auth.POST("/reset-password", h.resetPassword)
 	}
 
 	payment := router.Group("/payment")
@@ -63,12 +68,30 @@ func (h *Handler) initAPIRoutes(router *gin.Engine) {
 		products := api.Group("/products")
 		{
 			products.GET("", h.getAllProducts)
-			products.GET("/:id", h.getProduct)
+			products.GET("/:id", h.getProductOrSearch)
 		}
 
-		api.POST("/order", h.placeOrder)
+		api.POST("/order", h.optionalUserIdentity, h.placeOrder)
+		api.GET("/orders/lookup", h.lookupOrder)
+		api.POST("/shipping/options", h.getShippingOptions)
 
 		api.GET("/settings", h.getSettings)
+		api.POST("/unsubscribe", h.unsubscribe)
+
+		cart := api.Group("/cart", h.optionalUserIdentity)
+		{
+			cart.GET("", h.getCart)
+			cart.DELETE("", h.clearCart)
+			cart.POST("/items", h.addCartItem)
+			cart.PUT("/items/:product_id", h.updateCartItem)
+			cart.DELETE("/items/:product_id", h.deleteCartItem)
+			cart.POST("/checkout", h.checkoutCart)
+		}
+
+		me := api.Group("/me", h.userIdentity)
+		{
+			me.GET("/orders", h.getUserOrders)
+		}
 	}
 }
 
@@ -76,13 +99,60 @@ func (h *Handler) initAdminRoutes(router *gin.Engine) {
 	admin := router.Group("/admin", h.adminIdentity)
 	{
 		admin.POST("/products", h.createProduct)
+		admin.POST("/products/:id", h.bulkSaleRoute)
 		admin.GET("/products", h.getAllProducts)
 		admin.GET("/products/:id", h.getProduct)
 		admin.PUT("/products/:id", h.updateProduct)
 		admin.DELETE("/products/:id", h.deleteProduct)
+		admin.POST("/products/:id/variants", h.createVariant)
+		admin.PUT("/products/:id/variants/:variant_id", h.updateVariant)
+		admin.DELETE("/products/:id/variants/:variant_id", h.deleteVariant)
 
 		admin.GET("/orders", h.getAllOrders)
 		admin.GET("/orders/:id", h.getOrder)
+		admin.PUT("/orders/:id/status", h.updateOrderStatus)
+		admin.PUT("/orders/:id/shipment", h.shipOrder)
+		admin.POST("/orders/:id/refund", h.refundOrder)
+		admin.POST("/orders/:id/refunds/:refund_id/complete", h.completeOrderRefund)
+		admin.POST("/orders/:id/capture", h.captureOrderPayment)
+		admin.POST("/orders/:id/void", h.voidOrderPayment)
+		admin.GET("/orders/:id/invoice", h.getOrderInvoice)
+
+		admin.GET("/payments/discrepancies", h.getPaymentDiscrepancies)
+
+		promoCodes := admin.Group("/promo-codes")
+		{
+			promoCodes.POST("", h.createPromoCode)
+			promoCodes.GET("", h.getAllPromoCodes)
+			promoCodes.GET("/:id", h.getPromoCode)
+			promoCodes.PUT("/:id", h.updatePromoCode)
+			promoCodes.DELETE("/:id", h.deletePromoCode)
+		}
+
+		exchangeRates := admin.Group("/exchange-rates")
+		{
+			exchangeRates.GET("", h.getExchangeRates)
+			exchangeRates.POST("/import", h.importExchangeRates)
+			exchangeRates.PUT("/:currency", h.setExchangeRate)
+		}
+
+		taxRates := admin.Group("/tax-rates")
+		{
+			taxRates.GET("", h.getTaxRates)
+			taxRates.PUT("/:country", h.setTaxRate)
+			taxRates.DELETE("/:country", h.deleteTaxRate)
+		}
+
+		shipping := admin.Group("/shipping")
+		{
+			shipping.POST("/zones", h.createShippingZone)
+			shipping.GET("/zones", h.getShippingZones)
+			shipping.PUT("/zones/:id", h.updateShippingZone)
+			shipping.DELETE("/zones/:id", h.deleteShippingZone)
+			shipping.POST("/methods", h.createShippingMethod)
+			shipping.PUT("/methods/:id", h.updateShippingMethod)
+			shipping.DELETE("/methods/:id", h.deleteShippingMethod)
+		}
 
 		settings := admin.Group("/settings")
 		{
@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/service"
+	"testing"
+)
+
+func TestHandler_Init(t *testing.T) {
+	handler := NewHandler(&service.Services{})
+
+	// gin panics on conflicting routes
+	assert.NotPanics(t, func() {
+		handler.Init()
+	})
+}
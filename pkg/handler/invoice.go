@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+// getOrderInvoice returns PDF invoice of the paid order.
+func (h *Handler) getOrderInvoice(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	invoice, file, err := h.services.Invoice.GetByOrderId(id)
+	if err != nil {
+		logrus.Errorf("Failed to get order invoice: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", invoice.DisplayNumber+".pdf"))
+	c.Data(http.StatusOK, "application/pdf", file)
+}
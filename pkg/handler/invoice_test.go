@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/service"
+	mock_service "github.com/zhashkevych/jewelry-shop-backend/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_getOrderInvoice(t *testing.T) {
+	// Init Test Data
+	type mockBehavior func(r *mock_service.MockInvoice, id int)
+
+	testCases := []struct {
+		name                 string
+		id                   string
+		orderId              int
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedHeader       string
+	}{
+		{
+			name:    "Ok",
+			id:      "7",
+			orderId: 7,
+			mockBehavior: func(r *mock_service.MockInvoice, id int) {
+				r.EXPECT().GetByOrderId(id).Return(jewerly.Invoice{Id: 1, OrderId: id, Number: 42, DisplayNumber: "SR-000042"}, []byte("%PDF-1.4"), nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: "%PDF-1.4",
+			expectedHeader:       `attachment; filename="SR-000042.pdf"`,
+		},
+		{
+			name:                 "Invalid Id",
+			id:                   "abc",
+			mockBehavior:         func(r *mock_service.MockInvoice, id int) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+		},
+		{
+			name:    "Not Found",
+			id:      "7",
+			orderId: 7,
+			mockBehavior: func(r *mock_service.MockInvoice, id int) {
+				r.EXPECT().GetByOrderId(id).Return(jewerly.Invoice{}, nil, jewerly.ErrInvoiceNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"invoice not found"}`,
+		},
+		{
+			name:    "Storage Error",
+			id:      "7",
+			orderId: 7,
+			mockBehavior: func(r *mock_service.MockInvoice, id int) {
+				r.EXPECT().GetByOrderId(id).Return(jewerly.Invoice{}, nil, errors.New("storage failure"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"storage failure"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			invoice := mock_service.NewMockInvoice(c)
+			test.mockBehavior(invoice, test.orderId)
+
+			services := &service.Services{Invoice: invoice}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/orders/:id/invoice", handler.getOrderInvoice)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/orders/%s/invoice", test.id), nil)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+			assert.Equal(t, test.expectedHeader, w.Header().Get("Content-Disposition"))
+		})
+	}
+}
@@ -8,36 +8,86 @@ import (
 )
 
 const (
-	AccessToken  = "Authorization"
+	AccessToken = "Authorization"
+
+	adminCtx = "admin"
+	userCtx  = "userId"
 )
 
 func (h *Handler) adminIdentity(c *gin.Context) {
+	token, err := getBearerToken(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	login, err := h.services.Admin.ParseToken(token)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	c.Set(adminCtx, login)
+}
+
+func (h *Handler) userIdentity(c *gin.Context) {
+	token, err := getBearerToken(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	userId, err := h.services.User.ParseToken(token)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	c.Set(userCtx, userId)
+}
+
+// optionalUserIdentity lets guests through and identifies customers that are signed in.
+func (h *Handler) optionalUserIdentity(c *gin.Context) {
+	if c.Request.Header.Get(AccessToken) == "" {
+		return
+	}
+
+	h.userIdentity(c)
+}
+
+func getBearerToken(c *gin.Context) (string, error) {
 	header := c.Request.Header.Get(AccessToken)
 
 	if header == "" {
-		newErrorResponse(c, http.StatusUnauthorized, errors.New("empty auth header"))
-		return
+		return "", errors.New("empty auth header")
 	}
 
 	headerParts := strings.Split(header, " ")
 	if len(headerParts) != 2 {
-		newErrorResponse(c, http.StatusUnauthorized, errors.New("invalid auth header"))
-		return
+		return "", errors.New("invalid auth header")
 	}
 
 	if headerParts[0] != "Bearer" {
-		newErrorResponse(c, http.StatusUnauthorized, errors.New("invalid auth header"))
-		return
+		return "", errors.New("invalid auth header")
 	}
 
 	if headerParts[1] == "" {
-		newErrorResponse(c, http.StatusUnauthorized, errors.New("invalid token"))
-		return
+		return "", errors.New("invalid token")
 	}
 
-	err := h.services.Admin.ParseToken(headerParts[1])
-	if err != nil {
-		newErrorResponse(c, http.StatusUnauthorized, err)
-		return
+	return headerParts[1], nil
+}
+
+func getAdminLogin(c *gin.Context) string {
+	return c.GetString(adminCtx)
+}
+
+func getUserId(c *gin.Context) (int64, bool) {
+	id, ok := c.Get(userCtx)
+	if !ok {
+		return 0, false
 	}
-}
\ No newline at end of file
+
+	userId, ok := id.(int64)
+	return userId, ok
+}
@@ -30,7 +30,7 @@ func TestHandler_adminIdentity(t *testing.T) {
 			headerValue: "Bearer token",
 			token:       "token",
 			mockBehavior: func(r *mock_service.MockAdmin, token string) {
-				r.EXPECT().ParseToken(token).Return( nil)
+				r.EXPECT().ParseToken(token).Return("admin", nil)
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: "ok",
@@ -68,7 +68,7 @@ func TestHandler_adminIdentity(t *testing.T) {
 			headerValue: "Bearer token",
 			token:       "token",
 			mockBehavior: func(r *mock_service.MockAdmin, token string) {
-				r.EXPECT().ParseToken(token).Return(errors.New("invalid token"))
+				r.EXPECT().ParseToken(token).Return("", errors.New("invalid token"))
 			},
 			expectedStatusCode:   401,
 			expectedResponseBody: `{"error":"invalid token"}`,
@@ -106,3 +106,81 @@ func TestHandler_adminIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_optionalUserIdentity(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_service.MockUser, token string)
+
+	testTable := []struct {
+		name                 string
+		headerValue          string
+		token                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Customer",
+			headerValue: "Bearer token",
+			token:       "token",
+			mockBehavior: func(r *mock_service.MockUser, token string) {
+				r.EXPECT().ParseToken(token).Return(int64(7), nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: "7",
+		},
+		{
+			name:                 "Guest",
+			mockBehavior:         func(r *mock_service.MockUser, token string) {},
+			expectedStatusCode:   200,
+			expectedResponseBody: "guest",
+		},
+		{
+			name:        "Expired Token",
+			headerValue: "Bearer token",
+			token:       "token",
+			mockBehavior: func(r *mock_service.MockUser, token string) {
+				r.EXPECT().ParseToken(token).Return(int64(0), errors.New("token is expired"))
+			},
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"error":"token is expired"}`,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			user := mock_service.NewMockUser(c)
+			test.mockBehavior(user, test.token)
+
+			services := &service.Services{User: user}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/identity", handler.optionalUserIdentity, func(c *gin.Context) {
+				if id, ok := getUserId(c); ok {
+					c.String(200, "%d", id)
+					return
+				}
+				c.String(200, "guest")
+			})
+
+			// Init Test Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/identity", nil)
+			if test.headerValue != "" {
+				req.Header.Set("Authorization", test.headerValue)
+			}
+
+			r.ServeHTTP(w, req)
+
+			// Asserts
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"gopkg.in/guregu/null.v3"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 func (h *Handler) placeOrder(c *gin.Context) {
@@ -19,6 +22,10 @@ func (h *Handler) placeOrder(c *gin.Context) {
 		return
 	}
 
+	if userId, ok := getUserId(c); ok {
+		inp.UserId = null.IntFrom(userId)
+	}
+
 	url, err := h.services.Order.Create(inp)
 	if err != nil {
 		newErrorResponse(c, getStatusCode(err), err)
@@ -29,3 +36,33 @@ func (h *Handler) placeOrder(c *gin.Context) {
 		"url": url,
 	})
 }
+
+func (h *Handler) lookupOrder(c *gin.Context) {
+	inp := jewerly.OrderLookupInput{
+		Email:    strings.TrimSpace(c.Query("email")),
+		Token:    c.Query("token"),
+		Language: jewerly.GetLanguageFromQuery(c.Query("language")),
+	}
+
+	if id := c.Query("id"); id != "" {
+		orderId, err := strconv.Atoi(id)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, errors.New("invalid order id"))
+			return
+		}
+		inp.OrderId = orderId
+	}
+
+	if err := inp.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	order, err := h.services.Order.Lookup(inp)
+	if err != nil {
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
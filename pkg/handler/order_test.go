@@ -29,8 +29,8 @@ func TestHandler_placeOrder(t *testing.T) {
 			fixturePath: "./fixtures/orders/ok.json",
 			orderInput: jewerly.CreateOrderInput{
 				Items: []jewerly.OrderItem{
-					{1, 3},
-					{2, 3},
+					{ProductId: 1, Quantity: 3},
+					{ProductId: 2, Quantity: 3},
 				},
 				FirstName:      "Vasya",
 				LastName:       "Pupkin",
@@ -40,6 +40,8 @@ func TestHandler_placeOrder(t *testing.T) {
 				Country:        "UA",
 				Address:        "st. Khreshatyk, Kiev",
 				PostalCode:     "12303",
+
+				ShippingMethodId: 1,
 			},
 			mockBehavior: func(s *mock_service.MockOrder, input jewerly.CreateOrderInput) {
 				s.EXPECT().Create(input).Return("http://payment.link", nil)
@@ -124,6 +126,29 @@ func TestHandler_placeOrder(t *testing.T) {
 			expectedStatusCode:   400,
 			expectedResponseBody: `{"error":"invalid input body"}`,
 		},
+		{
+			name:        "Shipping Method Empty",
+			fixturePath: "./fixtures/orders/shipping_method.empty.json",
+			orderInput: jewerly.CreateOrderInput{
+				Items: []jewerly.OrderItem{
+					{ProductId: 1, Quantity: 3},
+					{ProductId: 2, Quantity: 3},
+				},
+				FirstName:      "Vasya",
+				LastName:       "Pupkin",
+				AdditionalName: "Aleksandrovich",
+				Email:          "vasya@pupkin.com",
+				Phone:          "+380950515344",
+				Country:        "UA",
+				Address:        "st. Khreshatyk, Kiev",
+				PostalCode:     "12303",
+			},
+			mockBehavior: func(s *mock_service.MockOrder, input jewerly.CreateOrderInput) {
+				s.EXPECT().Create(input).Return("", jewerly.ErrShippingMethodRequired)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"shipping method is required"}`,
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -160,3 +185,104 @@ func TestHandler_placeOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_lookupOrder(t *testing.T) {
+	type mockBehaviour func(s *mock_service.MockOrder, input jewerly.OrderLookupInput)
+
+	testTable := []struct {
+		name                 string
+		query                string
+		input                jewerly.OrderLookupInput
+		mockBehavior         mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok By Email",
+			query: "id=1&email=vasya@pupkin.com",
+			input: jewerly.OrderLookupInput{OrderId: 1, Email: "vasya@pupkin.com", Language: jewerly.English},
+			mockBehavior: func(s *mock_service.MockOrder, input jewerly.OrderLookupInput) {
+				s.EXPECT().Lookup(input).Return(jewerly.CustomerOrder{Id: 1, Status: "shipped"}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{"id":1,"ordered_at":"0001-01-01T00:00:00Z","first_name":"","last_name":"","country":"","address":"",` +
+				`"postal_code":"","total_cost":0,"promo_code":null,"discount":0,"free_shipping":false,"currency":"","shipping_method":null,"shipping_cost":0,"net_amount":0,"tax_amount":0,"tax_rate":0,"tax_inclusive":false,"status":"shipped","status_updated_at":"0001-01-01T00:00:00Z","transaction_status":"",` +
+				`"items":null,"carrier":null,"tracking_number":null,"tracking_url":null,"shipped_at":null}`,
+		},
+		{
+			name:  "Ok By Token",
+			query: "token=token&language=ru",
+			input: jewerly.OrderLookupInput{Token: "token", Language: jewerly.Russian},
+			mockBehavior: func(s *mock_service.MockOrder, input jewerly.OrderLookupInput) {
+				s.EXPECT().Lookup(input).Return(jewerly.CustomerOrder{Id: 1}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{"id":1,"ordered_at":"0001-01-01T00:00:00Z","first_name":"","last_name":"","country":"","address":"",` +
+				`"postal_code":"","total_cost":0,"promo_code":null,"discount":0,"free_shipping":false,"currency":"","shipping_method":null,"shipping_cost":0,"net_amount":0,"tax_amount":0,"tax_rate":0,"tax_inclusive":false,"status":"","status_updated_at":"0001-01-01T00:00:00Z","transaction_status":"",` +
+				`"items":null,"carrier":null,"tracking_number":null,"tracking_url":null,"shipped_at":null}`,
+		},
+		{
+			name:                 "Email Missing",
+			query:                "id=1",
+			mockBehavior:         func(s *mock_service.MockOrder, input jewerly.OrderLookupInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"order id and email or token are required"}`,
+		},
+		{
+			name:                 "Invalid Id",
+			query:                "id=abc&email=vasya@pupkin.com",
+			mockBehavior:         func(s *mock_service.MockOrder, input jewerly.OrderLookupInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid order id"}`,
+		},
+		{
+			name:  "Not Found",
+			query: "id=1&email=petya@pupkin.com",
+			input: jewerly.OrderLookupInput{OrderId: 1, Email: "petya@pupkin.com", Language: jewerly.English},
+			mockBehavior: func(s *mock_service.MockOrder, input jewerly.OrderLookupInput) {
+				s.EXPECT().Lookup(input).Return(jewerly.CustomerOrder{}, jewerly.ErrOrderNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"order not found"}`,
+		},
+		{
+			name:  "Invalid Token",
+			query: "token=token",
+			input: jewerly.OrderLookupInput{Token: "token", Language: jewerly.English},
+			mockBehavior: func(s *mock_service.MockOrder, input jewerly.OrderLookupInput) {
+				s.EXPECT().Lookup(input).Return(jewerly.CustomerOrder{}, jewerly.ErrInvalidOrderToken)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid order token"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			order := mock_service.NewMockOrder(c)
+			testCase.mockBehavior(order, testCase.input)
+
+			services := &service.Services{Order: order}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/orders/lookup", handler.lookupOrder)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/orders/lookup?"+testCase.query, nil)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -7,17 +7,41 @@ import (
 	"net/http"
 )
 
+// callback responds with error status to rejected callbacks, provider retries delivery until it gets 200.
 func (h *Handler) callback(c *gin.Context) {
-	var inp jewerly.TransactionCallbackInput
+	inp, err := h.services.Order.ParseCallback(c.Request)
+	if err == jewerly.ErrCallbackIgnored {
+		c.Status(http.StatusOK)
+		return
+	}
 
-	if err := c.ShouldBind(&inp); err != nil {
-		logrus.Errorf("failed to bind response: %s\n", err.Error())
+	if err != nil {
+		logrus.Errorf("failed to parse payment callback: %s\n", err.Error())
 		c.AbortWithStatus(http.StatusBadRequest)
 		return
 	}
 
+	inp.Token = c.Query("token")
+
 	logrus.Debugf("input: %+v", inp)
 
-	h.services.Order.ProcessCallback(inp)
+	if err := h.services.Order.ProcessCallback(inp); err != nil {
+		logrus.Errorf("failed to process payment callback: %s\n", err.Error())
+		c.AbortWithStatus(getStatusCode(err))
+		return
+	}
+
 	c.Status(http.StatusOK)
 }
+
+// getPaymentDiscrepancies returns differences with the payment provider found by the reconciliation, newest first.
+func (h *Handler) getPaymentDiscrepancies(c *gin.Context) {
+	discrepancies, err := h.services.Reconciliation.GetDiscrepancies(getPaymentDiscrepancyFilters(c))
+	if err != nil {
+		logrus.Errorf("Failed to get payment discrepancies: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, discrepancies)
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/service"
+	mock_service "github.com/zhashkevych/jewelry-shop-backend/pkg/service/mocks"
+	"gopkg.in/guregu/null.v3"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_callback(t *testing.T) {
+	// Init Test Data
+	type mockBehavior func(r *mock_service.MockOrder, input jewerly.TransactionCallbackInput)
+
+	body := "notify_type=sale-complete&transaction_id=0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11&price=26500&currency=ILS"
+	input := jewerly.TransactionCallbackInput{
+		NotifyType:    "sale-complete",
+		TransactionID: "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11",
+		Price:         26500,
+		Currency:      "ILS",
+	}
+
+	testCases := []struct {
+		name               string
+		query              string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name:  "Ok",
+			query: "?token=secret",
+			mockBehavior: func(r *mock_service.MockOrder, input jewerly.TransactionCallbackInput) {
+				r.EXPECT().ParseCallback(gomock.Any()).Return(input, nil)
+				input.Token = "secret"
+				r.EXPECT().ProcessCallback(input).Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:  "Invalid Token",
+			query: "?token=wrong",
+			mockBehavior: func(r *mock_service.MockOrder, input jewerly.TransactionCallbackInput) {
+				r.EXPECT().ParseCallback(gomock.Any()).Return(input, nil)
+				input.Token = "wrong"
+				r.EXPECT().ProcessCallback(input).Return(jewerly.ErrInvalidCallbackToken)
+			},
+			expectedStatusCode: 401,
+		},
+		{
+			name:  "Unknown Transaction",
+			query: "?token=secret",
+			mockBehavior: func(r *mock_service.MockOrder, input jewerly.TransactionCallbackInput) {
+				r.EXPECT().ParseCallback(gomock.Any()).Return(input, nil)
+				input.Token = "secret"
+				r.EXPECT().ProcessCallback(input).Return(jewerly.ErrTransactionNotFound)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name:  "Amount Mismatch",
+			query: "?token=secret",
+			mockBehavior: func(r *mock_service.MockOrder, input jewerly.TransactionCallbackInput) {
+				r.EXPECT().ParseCallback(gomock.Any()).Return(input, nil)
+				input.Token = "secret"
+				r.EXPECT().ProcessCallback(input).Return(jewerly.ErrPaymentAmountMismatch)
+			},
+			expectedStatusCode: 400,
+		},
+		{
+			name:  "Invalid Notification",
+			query: "?token=secret",
+			mockBehavior: func(r *mock_service.MockOrder, input jewerly.TransactionCallbackInput) {
+				r.EXPECT().ParseCallback(gomock.Any()).Return(jewerly.TransactionCallbackInput{}, errors.New("invalid signature"))
+			},
+			expectedStatusCode: 400,
+		},
+		{
+			name:  "Ignored Notification",
+			query: "?token=secret",
+			mockBehavior: func(r *mock_service.MockOrder, input jewerly.TransactionCallbackInput) {
+				r.EXPECT().ParseCallback(gomock.Any()).Return(jewerly.TransactionCallbackInput{}, jewerly.ErrCallbackIgnored)
+			},
+			expectedStatusCode: 200,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			order := mock_service.NewMockOrder(c)
+			test.mockBehavior(order, input)
+
+			services := &service.Services{Order: order}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/payment/callback", handler.callback)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/payment/callback"+test.query, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestHandler_getPaymentDiscrepancies(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockReconciliation, filters jewerly.PaymentDiscrepancyFilters)
+
+	createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name                 string
+		query                string
+		filters              jewerly.PaymentDiscrepancyFilters
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:    "Ok",
+			query:   "?resolved=false&limit=10",
+			filters: jewerly.PaymentDiscrepancyFilters{Limit: 10, Resolved: null.BoolFrom(false)},
+			mockBehavior: func(r *mock_service.MockReconciliation, filters jewerly.PaymentDiscrepancyFilters) {
+				r.EXPECT().GetDiscrepancies(filters).Return([]jewerly.PaymentDiscrepancy{
+					{Id: 1, OrderId: 5, TransactionId: "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11", Kind: jewerly.DiscrepancyCancelledOrderPaid,
+						LocalStatus: "created", ProviderStatus: "sale-complete", Details: "payment is received for the cancelled order",
+						CreatedAt: createdAt},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `[{"id":1,"order_id":5,"transaction_id":"0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11","kind":"cancelled_order_paid",` +
+				`"local_status":"created","provider_status":"sale-complete","details":"payment is received for the cancelled order",` +
+				`"resolved":false,"created_at":"2026-10-01T12:00:00Z"}]`,
+		},
+		{
+			name:    "Default Filters",
+			filters: jewerly.PaymentDiscrepancyFilters{Limit: defaultLimit},
+			mockBehavior: func(r *mock_service.MockReconciliation, filters jewerly.PaymentDiscrepancyFilters) {
+				r.EXPECT().GetDiscrepancies(filters).Return([]jewerly.PaymentDiscrepancy{}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[]`,
+		},
+		{
+			name:    "Service Error",
+			filters: jewerly.PaymentDiscrepancyFilters{Limit: defaultLimit},
+			mockBehavior: func(r *mock_service.MockReconciliation, filters jewerly.PaymentDiscrepancyFilters) {
+				r.EXPECT().GetDiscrepancies(filters).Return(nil, errors.New("failed to get discrepancies"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"failed to get discrepancies"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			reconciliation := mock_service.NewMockReconciliation(c)
+			test.mockBehavior(reconciliation, test.filters)
+
+			services := &service.Services{Reconciliation: reconciliation}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/payments/discrepancies", handler.getPaymentDiscrepancies)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/payments/discrepancies"+test.query, nil)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"net/http"
+	"strconv"
+)
+
+func (h *Handler) createPromoCode(c *gin.Context) {
+	var inp jewerly.CreatePromoCodeInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind createPromoCodeInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := inp.Validate(); err != nil {
+		logrus.Errorf("Failed to validate input body: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := h.services.Promo.Create(inp)
+	if err != nil {
+		logrus.Errorf("Failed to create promo code: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) getAllPromoCodes(c *gin.Context) {
+	promoCodes, err := h.services.Promo.GetAll()
+	if err != nil {
+		logrus.Errorf("Failed to get promo codes: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, promoCodes)
+}
+
+func (h *Handler) getPromoCode(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	promoCode, err := h.services.Promo.GetById(id)
+	if err != nil {
+		logrus.Errorf("Failed to get promo code: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, promoCode)
+}
+
+func (h *Handler) updatePromoCode(c *gin.Context) {
+	var inp jewerly.UpdatePromoCodeInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind updatePromoCodeInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := inp.Validate(); err != nil {
+		logrus.Errorf("Failed to validate input body: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.services.Promo.Update(id, inp); err != nil {
+		logrus.Errorf("Failed to update promo code: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) deletePromoCode(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.services.Promo.Delete(id); err != nil {
+		logrus.Errorf("Failed to delete promo code: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
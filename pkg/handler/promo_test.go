@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/service"
+	mock_service "github.com/zhashkevych/jewelry-shop-backend/pkg/service/mocks"
+	"gopkg.in/guregu/null.v3"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_createPromoCode(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockPromo, input jewerly.CreatePromoCodeInput)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		input                jewerly.CreatePromoCodeInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"code":"rings10","type":"percentage","percent":10,"category_ids":[1],"per_customer_limit":1}`,
+			input: jewerly.CreatePromoCodeInput{Code: "rings10", Type: jewerly.PromoTypePercentage, Percent: 10,
+				CategoryIds: []jewerly.Category{jewerly.CategoryRings}, PerCustomerLimit: null.IntFrom(1)},
+			mockBehavior: func(s *mock_service.MockPromo, input jewerly.CreatePromoCodeInput) {
+				s.EXPECT().Create(input).Return(1, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:      "Ok Fixed",
+			inputBody: `{"code":"minus50","type":"fixed","amount":49.99}`,
+			input:     jewerly.CreatePromoCodeInput{Code: "minus50", Type: jewerly.PromoTypeFixed, Amount: 4999},
+			mockBehavior: func(s *mock_service.MockPromo, input jewerly.CreatePromoCodeInput) {
+				s.EXPECT().Create(input).Return(2, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":2}`,
+		},
+		{
+			name:                 "Invalid Type",
+			inputBody:            `{"code":"rings10","type":"gift","percent":10}`,
+			mockBehavior:         func(s *mock_service.MockPromo, input jewerly.CreatePromoCodeInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid promo code type"}`,
+		},
+		{
+			name:                 "Invalid Percentage",
+			inputBody:            `{"code":"rings10","type":"percentage","percent":120}`,
+			mockBehavior:         func(s *mock_service.MockPromo, input jewerly.CreatePromoCodeInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"percentage should be between 0 and 100"}`,
+		},
+		{
+			name:                 "Amount For Percentage",
+			inputBody:            `{"code":"rings10","type":"percentage","percent":10,"amount":50}`,
+			mockBehavior:         func(s *mock_service.MockPromo, input jewerly.CreatePromoCodeInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"percent can be set only for percentage codes and amount for fixed ones"}`,
+		},
+		{
+			name:      "Already Exists",
+			inputBody: `{"code":"free","type":"free_shipping"}`,
+			input:     jewerly.CreatePromoCodeInput{Code: "free", Type: jewerly.PromoTypeFreeShipping},
+			mockBehavior: func(s *mock_service.MockPromo, input jewerly.CreatePromoCodeInput) {
+				s.EXPECT().Create(input).Return(0, jewerly.ErrPromoCodeAlreadyExists)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"error":"promo code already exists"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			promo := mock_service.NewMockPromo(c)
+			testCase.mockBehavior(promo, testCase.input)
+
+			services := &service.Services{Promo: promo}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/promo-codes", handler.createPromoCode)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/promo-codes", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"net/http"
+)
+
+func (h *Handler) unsubscribe(c *gin.Context) {
+	var inp jewerly.UnsubscribeInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := h.services.Reminder.Unsubscribe(inp.Token); err != nil {
+		logrus.WithField("handler", "unsubscribe").Errorf("Failed to unsubscribe: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
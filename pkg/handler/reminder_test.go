@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/service"
+	mock_service "github.com/zhashkevych/jewelry-shop-backend/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_unsubscribe(t *testing.T) {
+	type mockBehaviour func(s *mock_service.MockReminder, token string)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		token                string
+		mockBehavior         mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"token":"token"}`,
+			token:     "token",
+			mockBehavior: func(s *mock_service.MockReminder, token string) {
+				s.EXPECT().Unsubscribe(token).Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:                 "Token Empty",
+			inputBody:            `{}`,
+			mockBehavior:         func(s *mock_service.MockReminder, token string) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:      "Invalid Token",
+			inputBody: `{"token":"token"}`,
+			token:     "token",
+			mockBehavior: func(s *mock_service.MockReminder, token string) {
+				s.EXPECT().Unsubscribe(token).Return(jewerly.ErrInvalidUnsubscribeToken)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid unsubscribe token"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			reminder := mock_service.NewMockReminder(c)
+			testCase.mockBehavior(reminder, testCase.token)
+
+			services := &service.Services{Reminder: reminder}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/unsubscribe", handler.unsubscribe)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/unsubscribe", bytes.NewBufferString(testCase.inputBody))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
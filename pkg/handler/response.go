@@ -12,8 +12,76 @@ type errorResponse struct {
 
 var (
 	statusCodes = map[error]int{
-		jewerly.ErrUserNotFound: http.StatusBadRequest,
-		jewerly.ErrOrderSumLow: http.StatusBadRequest,
+		jewerly.ErrUserNotFound:   http.StatusBadRequest,
+		jewerly.ErrOrderSumLow:    http.StatusBadRequest,
+		jewerly.ErrInvalidCursor:  http.StatusBadRequest,
+		jewerly.ErrNegativeStock:  http.StatusBadRequest,
+		jewerly.ErrNegativeWeight: http.StatusBadRequest,
+		jewerly.ErrInvalidSale:    http.StatusBadRequest,
+
+		jewerly.ErrOrderNotFound:         http.StatusNotFound,
+		jewerly.ErrInvalidOrderStatus:    http.StatusBadRequest,
+		jewerly.ErrOrderStatusTransition: http.StatusConflict,
+		jewerly.ErrInvalidOrderToken:     http.StatusBadRequest,
+		jewerly.ErrInsufficientStock:     http.StatusConflict,
+
+		jewerly.ErrInvalidCallbackToken:  http.StatusUnauthorized,
+		jewerly.ErrTransactionNotFound:   http.StatusNotFound,
+		jewerly.ErrPaymentAmountMismatch: http.StatusBadRequest,
+
+		jewerly.ErrUserAlreadyExists: http.StatusConflict,
+		jewerly.ErrInvalidUserToken:  http.StatusBadRequest,
+
+		jewerly.ErrCartNotFound:         http.StatusNotFound,
+		jewerly.ErrCartEmpty:            http.StatusBadRequest,
+		jewerly.ErrCartItemsUnavailable: http.StatusConflict,
+		jewerly.ErrProductNotFound:      http.StatusNotFound,
+
+		jewerly.ErrInvalidUnsubscribeToken: http.StatusBadRequest,
+
+		jewerly.ErrVariantNotFound:      http.StatusNotFound,
+		jewerly.ErrVariantAlreadyExists: http.StatusConflict,
+		jewerly.ErrVariantRequired:      http.StatusBadRequest,
+		jewerly.ErrInvalidVariantOption: http.StatusBadRequest,
+		jewerly.ErrStockFromVariants:    http.StatusBadRequest,
+
+		jewerly.ErrPromoCodeNotFound:      http.StatusNotFound,
+		jewerly.ErrPromoCodeAlreadyExists: http.StatusConflict,
+		jewerly.ErrInvalidPromoCode:       http.StatusBadRequest,
+		jewerly.ErrPromoCodeUsageLimit:    http.StatusConflict,
+		jewerly.ErrPromoCodeOrderSumLow:   http.StatusBadRequest,
+		jewerly.ErrPromoCodeNotApplicable: http.StatusBadRequest,
+		jewerly.ErrInvalidPromoType:       http.StatusBadRequest,
+		jewerly.ErrPromoValueMismatch:     http.StatusBadRequest,
+
+		jewerly.ErrInvalidCurrency:         http.StatusBadRequest,
+		jewerly.ErrExchangeRateNotFound:    http.StatusBadRequest,
+		jewerly.ErrBaseCurrencyRate:        http.StatusBadRequest,
+		jewerly.ErrInvalidExchangeRate:     http.StatusBadRequest,
+		jewerly.ErrInvalidExchangeRateFile: http.StatusBadRequest,
+
+		jewerly.ErrShippingZoneNotFound:     http.StatusNotFound,
+		jewerly.ErrShippingMethodNotFound:   http.StatusNotFound,
+		jewerly.ErrShippingMethodRequired:   http.StatusBadRequest,
+		jewerly.ErrShippingNotAvailable:     http.StatusBadRequest,
+		jewerly.ErrInvalidShippingType:      http.StatusBadRequest,
+		jewerly.ErrInvalidShippingRates:     http.StatusBadRequest,
+		jewerly.ErrInvalidShippingCountries: http.StatusBadRequest,
+
+		jewerly.ErrTaxRateNotFound: http.StatusNotFound,
+		jewerly.ErrInvalidTaxRate:  http.StatusBadRequest,
+
+		jewerly.ErrInvoiceNotFound: http.StatusNotFound,
+
+		jewerly.ErrRefundNotAvailable:   http.StatusConflict,
+		jewerly.ErrRefundAmountExceeded: http.StatusBadRequest,
+		jewerly.ErrRefundConflict:       http.StatusConflict,
+		jewerly.ErrRefundNotFound:       http.StatusNotFound,
+		jewerly.ErrInvalidRefundAmount:  http.StatusBadRequest,
+		jewerly.ErrInvalidRefundItems:   http.StatusBadRequest,
+
+		jewerly.ErrOrderNotAuthorized: http.StatusConflict,
+		jewerly.ErrPaymentAuthorized:  http.StatusConflict,
 	}
 )
 
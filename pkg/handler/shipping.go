@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"net/http"
+	"strconv"
+)
+
+// getShippingOptions returns shipping methods available for the order items with costs in the currency from query.
+func (h *Handler) getShippingOptions(c *gin.Context) {
+	var inp jewerly.ShippingQuoteInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind shippingQuoteInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := inp.Validate(); err != nil {
+		logrus.Errorf("Failed to validate input body: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	options, err := h.services.Order.GetShippingOptions(inp, jewerly.GetCurrencyFromQuery(c.Query("currency")))
+	if err != nil {
+		logrus.Errorf("Failed to get shipping options: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+func (h *Handler) createShippingZone(c *gin.Context) {
+	var inp jewerly.CreateShippingZoneInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind createShippingZoneInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := inp.Validate(); err != nil {
+		logrus.Errorf("Failed to validate input body: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := h.services.Shipping.CreateZone(inp)
+	if err != nil {
+		logrus.Errorf("Failed to create shipping zone: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) getShippingZones(c *gin.Context) {
+	zones, err := h.services.Shipping.GetZones()
+	if err != nil {
+		logrus.Errorf("Failed to get shipping zones: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, zones)
+}
+
+func (h *Handler) updateShippingZone(c *gin.Context) {
+	var inp jewerly.UpdateShippingZoneInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind updateShippingZoneInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := inp.Validate(); err != nil {
+		logrus.Errorf("Failed to validate input body: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.services.Shipping.UpdateZone(id, inp); err != nil {
+		logrus.Errorf("Failed to update shipping zone: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) deleteShippingZone(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.services.Shipping.DeleteZone(id); err != nil {
+		logrus.Errorf("Failed to delete shipping zone: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) createShippingMethod(c *gin.Context) {
+	var inp jewerly.CreateShippingMethodInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind createShippingMethodInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := inp.Validate(); err != nil {
+		logrus.Errorf("Failed to validate input body: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := h.services.Shipping.CreateMethod(inp)
+	if err != nil {
+		logrus.Errorf("Failed to create shipping method: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) updateShippingMethod(c *gin.Context) {
+	var inp jewerly.UpdateShippingMethodInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind updateShippingMethodInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := inp.Validate(); err != nil {
+		logrus.Errorf("Failed to validate input body: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.services.Shipping.UpdateMethod(id, inp); err != nil {
+		logrus.Errorf("Failed to update shipping method: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) deleteShippingMethod(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logrus.Errorf("Failed to parse id from query: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.services.Shipping.DeleteMethod(id); err != nil {
+		logrus.Errorf("Failed to delete shipping method: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/service"
+	mock_service "github.com/zhashkevych/jewelry-shop-backend/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_createShippingMethod(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockShipping, input jewerly.CreateShippingMethodInput)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		input                jewerly.CreateShippingMethodInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"zone_id":1,"name":"Courier","type":"express","free_from":200,"rates":[{"cost":25},{"min_weight":1000,"cost":40.5}]}`,
+			input: jewerly.CreateShippingMethodInput{
+				ZoneId:   1,
+				Name:     "Courier",
+				Type:     jewerly.ShippingTypeExpress,
+				FreeFrom: jewerly.NullMoneyFrom(20000),
+				Rates:    []jewerly.ShippingRate{{Cost: 2500}, {MinWeight: 1000, Cost: 4050}},
+			},
+			mockBehavior: func(s *mock_service.MockShipping, input jewerly.CreateShippingMethodInput) {
+				s.EXPECT().CreateMethod(input).Return(3, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":3}`,
+		},
+		{
+			name:                 "Invalid Type",
+			inputBody:            `{"zone_id":1,"name":"Drone","type":"drone","rates":[{"cost":25}]}`,
+			mockBehavior:         func(s *mock_service.MockShipping, input jewerly.CreateShippingMethodInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid shipping method type"}`,
+		},
+		{
+			name:                 "Negative Cost",
+			inputBody:            `{"zone_id":1,"name":"Post","type":"standard","rates":[{"cost":-5}]}`,
+			mockBehavior:         func(s *mock_service.MockShipping, input jewerly.CreateShippingMethodInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"shipping method should have at least one rate, rate values can't be negative"}`,
+		},
+		{
+			name:      "Zone Not Found",
+			inputBody: `{"zone_id":7,"name":"Pickup","type":"pickup","rates":[{"cost":0}]}`,
+			input: jewerly.CreateShippingMethodInput{
+				ZoneId: 7,
+				Name:   "Pickup",
+				Type:   jewerly.ShippingTypePickup,
+				Rates:  []jewerly.ShippingRate{{Cost: 0}},
+			},
+			mockBehavior: func(s *mock_service.MockShipping, input jewerly.CreateShippingMethodInput) {
+				s.EXPECT().CreateMethod(input).Return(0, jewerly.ErrShippingZoneNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"shipping zone not found"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			shipping := mock_service.NewMockShipping(c)
+			testCase.mockBehavior(shipping, testCase.input)
+
+			services := &service.Services{Shipping: shipping}
+			handler := Handler{services}
+
+			r := gin.New()
+			r.POST("/shipping/methods", handler.createShippingMethod)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/shipping/methods", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getShippingOptions(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockOrder, input jewerly.ShippingQuoteInput, currency string)
+
+	input := jewerly.ShippingQuoteInput{
+		Country: "IL",
+		Items:   []jewerly.OrderItem{{ProductId: 1, Quantity: 2}},
+	}
+
+	testTable := []struct {
+		name                 string
+		query                string
+		inputBody            string
+		currency             string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			query:     "?currency=ils",
+			inputBody: `{"country":"IL","items":[{"product_id":1,"quantity":2}]}`,
+			currency:  jewerly.CurrencyILS,
+			mockBehavior: func(s *mock_service.MockOrder, input jewerly.ShippingQuoteInput, currency string) {
+				s.EXPECT().GetShippingOptions(input, currency).Return([]jewerly.ShippingOption{
+					{Id: 1, Name: "Israel Post", Type: jewerly.ShippingTypeStandard, Cost: 3250, Currency: currency},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":1,"name":"Israel Post","type":"standard","cost":32.5,"currency":"ILS"}]`,
+		},
+		{
+			name:                 "Country Empty",
+			inputBody:            `{"items":[{"product_id":1,"quantity":2}]}`,
+			mockBehavior:         func(s *mock_service.MockOrder, input jewerly.ShippingQuoteInput, currency string) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:                 "Items Empty",
+			inputBody:            `{"country":"IL","items":[]}`,
+			mockBehavior:         func(s *mock_service.MockOrder, input jewerly.ShippingQuoteInput, currency string) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"order should have at least 1 item"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			order := mock_service.NewMockOrder(c)
+			testCase.mockBehavior(order, input, testCase.currency)
+
+			services := &service.Services{Order: order}
+			handler := Handler{services}
+
+			r := gin.New()
+			r.POST("/shipping/options", handler.getShippingOptions)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/shipping/options"+testCase.query, bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
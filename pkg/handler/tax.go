@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"net/http"
+)
+
+func (h *Handler) getTaxRates(c *gin.Context) {
+	rates, err := h.services.Tax.GetRates()
+	if err != nil {
+		logrus.Errorf("Failed to get tax rates: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
+
+func (h *Handler) setTaxRate(c *gin.Context) {
+	var inp jewerly.SetTaxRateInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.Errorf("Failed to bind setTaxRateInput structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := h.services.Tax.SetRate(c.Param("country"), inp); err != nil {
+		logrus.Errorf("Failed to set tax rate: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) deleteTaxRate(c *gin.Context) {
+	if err := h.services.Tax.DeleteRate(c.Param("country")); err != nil {
+		logrus.Errorf("Failed to delete tax rate: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
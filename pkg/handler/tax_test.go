@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/service"
+	mock_service "github.com/zhashkevych/jewelry-shop-backend/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_setTaxRate(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTax, country string, input jewerly.SetTaxRateInput)
+
+	testTable := []struct {
+		name                 string
+		country              string
+		inputBody            string
+		input                jewerly.SetTaxRateInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			country:   "il",
+			inputBody: `{"rate":17,"inclusive":true}`,
+			input:     jewerly.SetTaxRateInput{Rate: 17, Inclusive: true},
+			mockBehavior: func(s *mock_service.MockTax, country string, input jewerly.SetTaxRateInput) {
+				s.EXPECT().SetRate(country, input).Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:                 "Invalid Body",
+			country:              "il",
+			inputBody:            `{"rate":"seventeen"}`,
+			mockBehavior:         func(s *mock_service.MockTax, country string, input jewerly.SetTaxRateInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:      "Invalid Rate",
+			country:   "de",
+			inputBody: `{"rate":190}`,
+			input:     jewerly.SetTaxRateInput{Rate: 190},
+			mockBehavior: func(s *mock_service.MockTax, country string, input jewerly.SetTaxRateInput) {
+				s.EXPECT().SetRate(country, input).Return(jewerly.ErrInvalidTaxRate)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"tax rate should be between 0 and 100 percent"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			tax := mock_service.NewMockTax(c)
+			testCase.mockBehavior(tax, testCase.country, testCase.input)
+
+			services := &service.Services{Tax: tax}
+			handler := Handler{services}
+
+			r := gin.New()
+			r.PUT("/tax-rates/:country", handler.setTaxRate)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/tax-rates/"+testCase.country, bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
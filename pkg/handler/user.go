@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/service"
+	"net/http"
+)
+
+type userSignInInput struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+func (h *Handler) userSignUp(c *gin.Context) {
+	var inp service.SignUpInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.WithField("handler", "userSignUp").Errorf("Failed to bind sign up structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := h.services.User.SignUp(inp); err != nil {
+		logrus.WithField("handler", "userSignUp").Errorf("Failed to sign up: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+func (h *Handler) userSignIn(c *gin.Context) {
+	var inp userSignInInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		logrus.WithField("handler", "userSignIn").Errorf("Failed to bind sign in structure: %s\n", err.Error())
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	token, err := h.services.User.SignIn(inp.Email, inp.Password)
+	if err != nil {
+		logrus.WithField("handler", "userSignIn").Errorf("Failed to sign in: %s\n", err.Error())
+		newErrorResponse(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, signInResponse{
+		Token: token,
+	})
+}
+
+func (h *Handler) verifyEmail(c *gin.Context) {
+	var inp jewerly.VerifyEmailInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := h.services.User.VerifyEmail(inp.Token); err != nil {
+		logrus.WithField("handler", "verifyEmail").Errorf("Failed to verify email: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) forgotPassword(c *gin.Context) {
+	var inp jewerly.ForgotPasswordInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := h.services.User.ForgotPassword(inp.Email); err != nil {
+		logrus.WithField("handler", "forgotPassword").Errorf("Failed to send password reset: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) resetPassword(c *gin.Context) {
+	var inp jewerly.ResetPasswordInput
+	if err := c.ShouldBindJSON(&inp); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	if err := h.services.User.ResetPassword(inp.Token, inp.Password); err != nil {
+		logrus.WithField("handler", "resetPassword").Errorf("Failed to reset password: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) getUserOrders(c *gin.Context) {
+	userId, _ := getUserId(c)
+
+	orders, err := h.services.Order.GetUserOrders(userId, getOrderFilters(c), jewerly.GetLanguageFromQuery(c.Query("language")))
+	if err != nil {
+		logrus.Errorf("Failed to get user orders: %s\n", err.Error())
+		newErrorResponse(c, getStatusCode(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
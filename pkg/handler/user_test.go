@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/service"
+	mock_service "github.com/zhashkevych/jewelry-shop-backend/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_userSignUp(t *testing.T) {
+	// Init Test Data
+	type mockBehavior func(r *mock_service.MockUser, input service.SignUpInput)
+
+	testCases := []struct {
+		name                 string
+		inputBody            string
+		input                service.SignUpInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"first_name": "Vasya", "last_name": "Pupkin", "email": "vasya@pupkin.com", "password": "qwerty123"}`,
+			input:     service.SignUpInput{FirstName: "Vasya", LastName: "Pupkin", Email: "vasya@pupkin.com", Password: "qwerty123"},
+			mockBehavior: func(r *mock_service.MockUser, input service.SignUpInput) {
+				r.EXPECT().SignUp(input).Return(nil)
+			},
+			expectedStatusCode: 201,
+		},
+		{
+			name:                 "Short Password",
+			inputBody:            `{"first_name": "Vasya", "last_name": "Pupkin", "email": "vasya@pupkin.com", "password": "qwerty"}`,
+			mockBehavior:         func(r *mock_service.MockUser, input service.SignUpInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:                 "Invalid Email",
+			inputBody:            `{"first_name": "Vasya", "last_name": "Pupkin", "email": "vasya", "password": "qwerty123"}`,
+			mockBehavior:         func(r *mock_service.MockUser, input service.SignUpInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:      "Email Taken",
+			inputBody: `{"first_name": "Vasya", "last_name": "Pupkin", "email": "vasya@pupkin.com", "password": "qwerty123"}`,
+			input:     service.SignUpInput{FirstName: "Vasya", LastName: "Pupkin", Email: "vasya@pupkin.com", Password: "qwerty123"},
+			mockBehavior: func(r *mock_service.MockUser, input service.SignUpInput) {
+				r.EXPECT().SignUp(input).Return(jewerly.ErrUserAlreadyExists)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"error":"user with such email already exists"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			user := mock_service.NewMockUser(c)
+			test.mockBehavior(user, test.input)
+
+			services := &service.Services{User: user}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/sign-up", handler.userSignUp)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/sign-up",
+				bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_userSignIn(t *testing.T) {
+	// Init Test Data
+	type mockBehavior func(r *mock_service.MockUser, email, password string)
+
+	testCases := []struct {
+		name                 string
+		email                string
+		password             string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			email:     "vasya@pupkin.com",
+			password:  "qwerty123",
+			inputBody: `{"email": "vasya@pupkin.com", "password": "qwerty123"}`,
+			mockBehavior: func(r *mock_service.MockUser, email, password string) {
+				r.EXPECT().SignIn(email, password).Return("token", nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"token":"token"}`,
+		},
+		{
+			name:                 "Empty Password",
+			inputBody:            `{"email": "vasya@pupkin.com"}`,
+			mockBehavior:         func(r *mock_service.MockUser, email, password string) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:      "Wrong Credentials",
+			email:     "vasya@pupkin.com",
+			password:  "qwerty123",
+			inputBody: `{"email": "vasya@pupkin.com", "password": "qwerty123"}`,
+			mockBehavior: func(r *mock_service.MockUser, email, password string) {
+				r.EXPECT().SignIn(email, password).Return("", jewerly.ErrUserNotFound)
+			},
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"error":"user not found"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			user := mock_service.NewMockUser(c)
+			test.mockBehavior(user, test.email, test.password)
+
+			services := &service.Services{User: user}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/sign-in", handler.userSignIn)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/sign-in",
+				bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_resetPassword(t *testing.T) {
+	// Init Test Data
+	type mockBehavior func(r *mock_service.MockUser, token, password string)
+
+	testCases := []struct {
+		name                 string
+		token                string
+		password             string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			token:     "token",
+			password:  "qwerty123",
+			inputBody: `{"token": "token", "password": "qwerty123"}`,
+			mockBehavior: func(r *mock_service.MockUser, token, password string) {
+				r.EXPECT().ResetPassword(token, password).Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:                 "Short Password",
+			inputBody:            `{"token": "token", "password": "qwerty"}`,
+			mockBehavior:         func(r *mock_service.MockUser, token, password string) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid input body"}`,
+		},
+		{
+			name:      "Invalid Token",
+			token:     "token",
+			password:  "qwerty123",
+			inputBody: `{"token": "token", "password": "qwerty123"}`,
+			mockBehavior: func(r *mock_service.MockUser, token, password string) {
+				r.EXPECT().ResetPassword(token, password).Return(jewerly.ErrInvalidUserToken)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"token is invalid or expired"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Deps
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			user := mock_service.NewMockUser(c)
+			test.mockBehavior(user, test.token, test.password)
+
+			services := &service.Services{User: user}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/reset-password", handler.resetPassword)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/reset-password",
+				bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
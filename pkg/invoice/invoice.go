@@ -0,0 +1,228 @@
+package invoice
+
+import (
+	"fmt"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"strings"
+	"time"
+)
+
+const (
+	marginLeft   = 50
+	marginRight  = pageWidth - 50
+	marginTop    = pageHeight - 60
+	marginBottom = 80
+
+	rowHeight     = 16
+	maxTitleRunes = 60
+)
+
+// table columns are right-aligned, so their x positions are the column ends
+const (
+	columnQuantity = 370
+	columnPrice    = 460
+	columnAmount   = marginRight
+)
+
+type Seller struct {
+	Name    string
+	Address string
+	TaxId   string
+	Email   string
+}
+
+type Buyer struct {
+	Name       string
+	Address    string
+	PostalCode string
+	Country    string
+	Email      string
+}
+
+// Item price is the unit price in the invoice currency.
+type Item struct {
+	Title    string
+	Quantity int
+	Price    jewerly.Money
+}
+
+// Invoice amounts are in the order currency, Net and Tax sum up to Total.
+type Invoice struct {
+	Number   string
+	Date     time.Time
+	OrderId  int
+	Currency string
+
+	Seller Seller
+	Buyer  Buyer
+	Items  []Item
+
+	PromoCode      string
+	Discount       jewerly.Money
+	ShippingMethod string
+	ShippingCost   jewerly.Money
+
+	Net          jewerly.Money
+	Tax          jewerly.Money
+	Total        jewerly.Money
+	TaxRate      float64
+	TaxInclusive bool
+}
+
+// Generate renders the invoice to PDF, items that don't fit the first page are continued on the next ones.
+func Generate(inv Invoice) []byte {
+	doc := &document{}
+	p := doc.addPage()
+
+	p.text(marginLeft, marginTop, fontBold, 20, "INVOICE")
+	p.textRight(marginRight, marginTop+6, fontRegular, 10, "No. "+inv.Number)
+	p.textRight(marginRight, marginTop-8, fontRegular, 10, "Date: "+inv.Date.Format("2006-01-02"))
+	p.textRight(marginRight, marginTop-22, fontRegular, 10, fmt.Sprintf("Order: #%d", inv.OrderId))
+
+	y := float64(marginTop - 60)
+	sellerLines := append(splitLines(inv.Seller.Address), taxIdLine(inv.Seller.TaxId), inv.Seller.Email)
+	buyerLines := append(splitLines(inv.Buyer.Address), strings.TrimSpace(inv.Buyer.PostalCode+" "+inv.Buyer.Country), inv.Buyer.Email)
+
+	p.text(marginLeft, y, fontBold, 10, "Seller")
+	p.text(320, y, fontBold, 10, "Bill to")
+	y -= 14
+	p.text(marginLeft, y, fontBold, 10, inv.Seller.Name)
+	p.text(320, y, fontBold, 10, inv.Buyer.Name)
+
+	sellerY := y
+	for _, line := range sellerLines {
+		if line != "" {
+			sellerY -= 13
+			p.text(marginLeft, sellerY, fontRegular, 10, line)
+		}
+	}
+
+	buyerY := y
+	for _, line := range buyerLines {
+		if line != "" {
+			buyerY -= 13
+			p.text(320, buyerY, fontRegular, 10, line)
+		}
+	}
+
+	y = sellerY
+	if buyerY < y {
+		y = buyerY
+	}
+
+	y = tableHeader(p, y-40)
+
+	for _, item := range inv.Items {
+		if y < marginBottom {
+			p = doc.addPage()
+			y = tableHeader(p, marginTop)
+		}
+
+		p.text(marginLeft, y, fontRegular, 10, truncate(item.Title, maxTitleRunes))
+		p.textRight(columnQuantity, y, fontRegular, 10, fmt.Sprintf("%d", item.Quantity))
+		p.textRight(columnPrice, y, fontRegular, 10, item.Price.String())
+		p.textRight(columnAmount, y, fontRegular, 10, item.Price.Mul(item.Quantity).String())
+		y -= rowHeight
+	}
+
+	summary := summaryRows(inv)
+	if y-float64(len(summary)*rowHeight) < marginBottom {
+		p = doc.addPage()
+		y = marginTop
+	}
+
+	p.line(marginLeft, y+rowHeight-4, marginRight, y+rowHeight-4)
+	y -= 4
+
+	for _, row := range summary {
+		font := fontRegular
+		if row.bold {
+			font = fontBold
+		}
+
+		p.text(300, y, font, 10, row.label)
+		p.textRight(columnAmount, y, font, 10, row.amount.String())
+		y -= rowHeight
+	}
+
+	p.text(marginLeft, marginBottom-40, fontRegular, 8, fmt.Sprintf("All amounts are in %s.", inv.Currency))
+
+	return doc.bytes()
+}
+
+func tableHeader(p *page, y float64) float64 {
+	p.text(marginLeft, y, fontBold, 10, "Item")
+	p.textRight(columnQuantity, y, fontBold, 10, "Qty")
+	p.textRight(columnPrice, y, fontBold, 10, "Price")
+	p.textRight(columnAmount, y, fontBold, 10, "Amount")
+	p.line(marginLeft, y-5, marginRight, y-5)
+
+	return y - rowHeight - 2
+}
+
+type summaryRow struct {
+	label  string
+	amount jewerly.Money
+	bold   bool
+}
+
+// summaryRows lists items subtotal, discount and shipping and then the tax breakdown,
+// inclusive tax is shown as a part of the total, exclusive one is added to the net amount.
+func summaryRows(inv Invoice) []summaryRow {
+	var subtotal jewerly.Money
+	for _, item := range inv.Items {
+		subtotal += item.Price.Mul(item.Quantity)
+	}
+
+	rows := []summaryRow{{label: "Subtotal", amount: subtotal}}
+
+	if inv.Discount > 0 {
+		label := "Discount"
+		if inv.PromoCode != "" {
+			label = fmt.Sprintf("Discount (%s)", inv.PromoCode)
+		}
+		rows = append(rows, summaryRow{label: label, amount: -inv.Discount})
+	}
+
+	if inv.ShippingMethod != "" {
+		rows = append(rows, summaryRow{label: fmt.Sprintf("Shipping (%s)", inv.ShippingMethod), amount: inv.ShippingCost})
+	}
+
+	vat := fmt.Sprintf("VAT %s%%", formatRate(inv.TaxRate))
+	if inv.TaxInclusive {
+		return append(rows,
+			summaryRow{label: "Total", amount: inv.Total, bold: true},
+			summaryRow{label: "Net amount", amount: inv.Net},
+			summaryRow{label: vat + " included", amount: inv.Tax})
+	}
+
+	return append(rows,
+		summaryRow{label: "Net amount", amount: inv.Net},
+		summaryRow{label: vat, amount: inv.Tax},
+		summaryRow{label: "Total", amount: inv.Total, bold: true})
+}
+
+func formatRate(rate float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", rate), "0"), ".")
+}
+
+func taxIdLine(taxId string) string {
+	if taxId == "" {
+		return ""
+	}
+
+	return "Tax ID: " + taxId
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSpace(s), "\n")
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+
+	return string(runes[:max-3]) + "..."
+}
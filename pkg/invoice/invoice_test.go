@@ -0,0 +1,116 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGenerate(t *testing.T) {
+	inv := Invoice{
+		Number:   "SR-000042",
+		Date:     time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC),
+		OrderId:  7,
+		Currency: "ILS",
+		Seller:   Seller{Name: "Silver Rain", Address: "Herzl st. 1\nTel Aviv", TaxId: "515151515", Email: "info@silverrain.com"},
+		Buyer:    Buyer{Name: "Test Test", Address: "Kreshatyk st.", PostalCode: "32012", Country: "UA", Email: "test@test.com"},
+		Items: []Item{
+			{Title: "Ring (silver)", Quantity: 2, Price: 10000},
+			{Title: "Кольцо", Quantity: 1, Price: 5000},
+		},
+		ShippingMethod: "Express",
+		ShippingCost:   1500,
+		Net:            22650,
+		Tax:            3850,
+		Total:          26500,
+		TaxRate:        17,
+		TaxInclusive:   true,
+	}
+
+	got := Generate(inv)
+
+	assert.True(t, bytes.HasPrefix(got, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(got, []byte("%%EOF\n")))
+
+	for _, text := range []string{"(No. SR-000042)", "(Date: 2021-03-01)", "(Ring \\(silver\\))", "(??????)", "(Tax ID: 515151515)",
+		"(Shipping \\(Express\\))", "(265.00)", "(VAT 17% included)", "(38.50)"} {
+		assert.Contains(t, string(got), text)
+	}
+
+	assertXref(t, got)
+}
+
+func TestGenerate_Pages(t *testing.T) {
+	items := make([]Item, 60)
+	for i := range items {
+		items[i] = Item{Title: fmt.Sprintf("Item %d", i), Quantity: 1, Price: 100}
+	}
+
+	got := Generate(Invoice{Number: "SR-000001", Items: items, Net: 6000, Total: 6000})
+
+	assert.Contains(t, string(got), "/Count 2")
+	assert.Contains(t, string(got), "(Item 59)")
+	assertXref(t, got)
+}
+
+func TestSummaryRows(t *testing.T) {
+	testTable := []struct {
+		name string
+		inv  Invoice
+		want []summaryRow
+	}{
+		{
+			name: "Inclusive Tax",
+			inv: Invoice{Items: []Item{{Quantity: 2, Price: 1000}}, PromoCode: "SALE10", Discount: 200,
+				Net: 1538, Tax: 262, Total: 1800, TaxRate: 17, TaxInclusive: true},
+			want: []summaryRow{
+				{label: "Subtotal", amount: 2000},
+				{label: "Discount (SALE10)", amount: -200},
+				{label: "Total", amount: 1800, bold: true},
+				{label: "Net amount", amount: 1538},
+				{label: "VAT 17% included", amount: 262},
+			},
+		},
+		{
+			name: "Exclusive Tax",
+			inv: Invoice{Items: []Item{{Quantity: 1, Price: 1000}}, ShippingMethod: "Standard", ShippingCost: 500,
+				Net: 1500, Tax: 113, Total: 1613, TaxRate: 7.5},
+			want: []summaryRow{
+				{label: "Subtotal", amount: 1000},
+				{label: "Shipping (Standard)", amount: 500},
+				{label: "Net amount", amount: 1500},
+				{label: "VAT 7.5%", amount: 113},
+				{label: "Total", amount: 1613, bold: true},
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, summaryRows(testCase.inv))
+		})
+	}
+}
+
+// assertXref checks that every object offset in the cross-reference table points to the object.
+func assertXref(t *testing.T, pdf []byte) {
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	if !assert.NotNil(t, match) {
+		return
+	}
+
+	xref, _ := strconv.Atoi(string(match[1]))
+	assert.True(t, bytes.HasPrefix(pdf[xref:], []byte("xref\n")))
+
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	assert.NotEmpty(t, offsets)
+
+	for i, offset := range offsets {
+		n, _ := strconv.Atoi(string(offset[1]))
+		assert.True(t, bytes.HasPrefix(pdf[n:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))))
+	}
+}
@@ -0,0 +1,146 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page size in points
+const (
+	pageWidth  = 595
+	pageHeight = 842
+)
+
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+// winAnsi maps characters outside of Latin-1 that WinAnsiEncoding still has, the rest are printed as '?'.
+var winAnsi = map[rune]byte{
+	'€': 0x80,
+	'‘': 0x91,
+	'’': 0x92,
+	'“': 0x93,
+	'”': 0x94,
+	'•': 0x95,
+	'–': 0x96,
+	'—': 0x97,
+}
+
+// page is a content stream of a single PDF page, coordinates start at the bottom left corner.
+type page struct {
+	content bytes.Buffer
+}
+
+func (p *page) text(x, y float64, font string, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, encodeText(s))
+}
+
+// textRight prints the text so that it ends at x.
+func (p *page) textRight(x, y float64, font string, size float64, s string) {
+	p.text(x-textWidth(s, size), y, font, size, s)
+}
+
+func (p *page) line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(&p.content, "%.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+// document is a minimal PDF 1.4 writer with standard Helvetica fonts, so no fonts have to be embedded.
+type document struct {
+	pages []*page
+}
+
+func (d *document) addPage() *page {
+	p := &page{}
+	fmt.Fprintf(&p.content, "0.5 w\n")
+	d.pages = append(d.pages, p)
+
+	return p
+}
+
+// bytes writes objects in order: catalog, pages, fonts and then page with its content stream for every page.
+func (d *document) bytes() []byte {
+	var (
+		buf     bytes.Buffer
+		offsets []int
+	)
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, p := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontRegular, fontBold, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", p.content.Len(), p.content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// encodeText converts the text to WinAnsiEncoding and escapes it for a PDF string literal.
+func encodeText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r < ' ':
+			b.WriteByte(' ')
+		case r < 0x7f || (r >= 0xa0 && r <= 0xff):
+			b.WriteByte(byte(r))
+		case winAnsi[r] != 0:
+			b.WriteByte(winAnsi[r])
+		default:
+			b.WriteByte('?')
+		}
+	}
+
+	return b.String()
+}
+
+// textWidth approximates Helvetica text width, it's exact for digits and separators,
+// which is enough to right-align amounts.
+func textWidth(s string, size float64) float64 {
+	var width float64
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			width += 556
+		case r == '.' || r == ',' || r == ' ':
+			width += 278
+		case r == '-':
+			width += 333
+		case r == '%':
+			width += 889
+		case r >= 'A' && r <= 'Z':
+			width += 667
+		default:
+			width += 500
+		}
+	}
+
+	return width * size / 1000
+}
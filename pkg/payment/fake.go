@@ -0,0 +1,289 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"html/template"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FakePagePath is where the api serves the fake payment page, the fake provider endpoint should point to it.
+const FakePagePath = "/payment/fake"
+
+const (
+	fakeResultSuccess = "success"
+	fakeCardMask      = "458045******4580"
+	fakeCardBrand     = "Visa"
+	fakeBuyerName     = "Test Buyer"
+
+	fakeStatusAuthorized = "authorized"
+	fakeStatusPaid       = "paid"
+	fakeStatusVoided     = "voided"
+	fakeStatusFailed     = "failed"
+)
+
+var fakeSaleNotifyTypes = map[string]string{
+	fakeStatusAuthorized: "sale-authorized",
+	fakeStatusPaid:       "sale-complete",
+	fakeStatusVoided:     "sale-void",
+	fakeStatusFailed:     "sale-failure",
+}
+
+var fakePage = template.Must(template.New("fake").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Test payment</title></head>
+<body style="font-family: sans-serif; max-width: 480px; margin: 60px auto;">
+<h2>Test payment</h2>
+<p>{{.ProductName}}</p>
+<p style="font-size: 24px;">{{.Price}} {{.Currency}}</p>
+{{if not .Payable}}
+<p>The sale is already {{.Status}}.</p>
+{{else}}
+{{if .Status}}<p>The payment failed, try again.</p>{{end}}
+<form method="post">
+    <button type="submit" name="result" value="success">Pay</button>
+    <button type="submit" name="result" value="failure">Decline</button>
+</form>
+{{end}}
+<p style="color: #9f9f9f;">No money is charged, this page is served by the local fake payment provider.</p>
+</body>
+</html>`))
+
+type fakeSale struct {
+	Id            string
+	TransactionID string
+	ProductName   string
+	Price         jewerly.Money
+	Currency      string
+	AuthorizeOnly bool
+	Status        string
+	Refunded      jewerly.Money
+}
+
+// Payable reports whether the buyer can pay the sale, failed payment can be retried as on the real payment page.
+func (s fakeSale) Payable() bool {
+	return s.Status == "" || s.Status == fakeStatusFailed
+}
+
+// FakeProvider emulates hosted payment page for local development, sales are kept in memory.
+// Callbacks are posted in the Isracard format, so they are processed the same way as real payments.
+type FakeProvider struct {
+	endpoint    string
+	returnURL   string
+	callbackURL string
+
+	client http.Client
+
+	mu    sync.Mutex
+	sales map[string]*fakeSale
+}
+
+func NewFakeProvider(endpoint, returnURL, callbackURL string) *FakeProvider {
+	return &FakeProvider{
+		endpoint:    endpoint,
+		returnURL:   returnURL,
+		callbackURL: callbackURL,
+		client: http.Client{
+			Timeout: time.Second * 5,
+		},
+		sales: make(map[string]*fakeSale),
+	}
+}
+
+func (p *FakeProvider) GenerateSale(inp GenerateSaleInput) (string, error) {
+	sale := &fakeSale{
+		Id:            uuid.New().String(),
+		TransactionID: inp.TransactionID,
+		ProductName:   inp.ProductName,
+		Price:         inp.Price,
+		Currency:      inp.Currency,
+		AuthorizeOnly: inp.AuthorizeOnly,
+	}
+
+	p.mu.Lock()
+	p.sales[sale.Id] = sale
+	p.mu.Unlock()
+
+	return fmt.Sprintf("%s/%s", p.endpoint, sale.Id), nil
+}
+
+func (p *FakeProvider) Refund(inp RefundInput) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sale, ok := p.sales[inp.SaleId]
+	if !ok || sale.Status != fakeStatusPaid {
+		return "", errors.New("fake sale isn't paid")
+	}
+
+	if sale.Refunded+inp.Amount > sale.Price {
+		return "", errors.New("refund amount exceeds the sale price")
+	}
+
+	sale.Refunded += inp.Amount
+
+	return uuid.New().String(), nil
+}
+
+func (p *FakeProvider) Capture(inp CaptureInput) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sale, ok := p.sales[inp.SaleId]
+	if !ok || sale.Status != fakeStatusAuthorized {
+		return "", errors.New("fake sale isn't authorized")
+	}
+
+	if inp.Amount > sale.Price {
+		return "", errors.New("capture amount exceeds the authorized price")
+	}
+
+	sale.Status = fakeStatusPaid
+	sale.Price = inp.Amount
+
+	return uuid.New().String(), nil
+}
+
+func (p *FakeProvider) Void(saleId string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sale, ok := p.sales[saleId]
+	if !ok || sale.Status != fakeStatusAuthorized {
+		return errors.New("fake sale isn't authorized")
+	}
+
+	sale.Status = fakeStatusVoided
+
+	return nil
+}
+
+func (p *FakeProvider) GetSale(transactionId string) (jewerly.TransactionCallbackInput, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, sale := range p.sales {
+		if sale.TransactionID != transactionId || sale.Status == "" {
+			continue
+		}
+
+		return jewerly.TransactionCallbackInput{
+			NotifyType:    fakeSaleNotifyTypes[sale.Status],
+			TransactionID: sale.TransactionID,
+			SaleId:        sale.Id,
+			SaleStatus:    sale.Status,
+			Price:         int(sale.Price.MinorUnits()),
+			Currency:      sale.Currency,
+			BuyerCardMask: fakeCardMask,
+			CardBrand:     fakeCardBrand,
+			BuyerName:     fakeBuyerName,
+		}, nil
+	}
+
+	return jewerly.TransactionCallbackInput{}, ErrSalePending
+}
+
+func (p *FakeProvider) ParseCallback(r *http.Request) (jewerly.TransactionCallbackInput, error) {
+	return parseFormCallback(r)
+}
+
+// ServeHTTP shows the payment page of the sale, the page form posts the result back to it.
+func (p *FakeProvider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	sale, ok := p.sales[path.Base(r.URL.Path)]
+	var view fakeSale
+	if ok {
+		view = *sale
+	}
+	p.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := fakePage.Execute(w, view); err != nil {
+			logrus.Errorf("failed to render fake payment page: %s", err.Error())
+		}
+	case http.MethodPost:
+		p.pay(w, r, view)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// pay sends the payment callback and redirects the customer to the return url, as the real payment page does.
+func (p *FakeProvider) pay(w http.ResponseWriter, r *http.Request, sale fakeSale) {
+	if !sale.Payable() {
+		http.Error(w, "sale is already "+sale.Status, http.StatusConflict)
+		return
+	}
+
+	success := r.PostFormValue("result") == fakeResultSuccess
+
+	status := fakeStatusPaid
+	if !success {
+		status = fakeStatusFailed
+	} else if sale.AuthorizeOnly {
+		status = fakeStatusAuthorized
+	}
+
+	// sale keeps the status when the callback is lost, as the real one does, so it can be reconciled later
+	p.mu.Lock()
+	p.sales[sale.Id].Status = status
+	p.mu.Unlock()
+
+	if err := p.sendCallback(sale, success); err != nil {
+		logrus.Errorf("failed to send fake payment callback: %s", err.Error())
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	http.Redirect(w, r, p.returnURL, http.StatusSeeOther)
+}
+
+func (p *FakeProvider) sendCallback(sale fakeSale, success bool) error {
+	notifyType, statusCode, saleStatus := "sale-complete", 0, "completed"
+	if sale.AuthorizeOnly {
+		notifyType, saleStatus = "sale-authorized", "authorized"
+	}
+	if !success {
+		notifyType, statusCode, saleStatus = "sale-failure", statusFail, "failed"
+	}
+
+	resp, err := p.client.PostForm(p.callbackURL, url.Values{
+		"status_code":                  {strconv.Itoa(statusCode)},
+		"notify_type":                  {notifyType},
+		"transaction_id":               {sale.TransactionID},
+		"payme_sale_id":                {sale.Id},
+		"payme_transaction_id":         {uuid.New().String()},
+		"sale_status":                  {saleStatus},
+		"sale_created":                 {time.Now().Format("2006-01-02 15:04:05")},
+		"price":                        {strconv.FormatInt(sale.Price.MinorUnits(), 10)},
+		"currency":                     {sale.Currency},
+		"buyer_card_mask":              {fakeCardMask},
+		"payme_transaction_card_brand": {fakeCardBrand},
+		"buyer_name":                   {fakeBuyerName},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("callback rejected with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
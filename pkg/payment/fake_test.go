@@ -0,0 +1,182 @@
+package payment
+
+import (
+	"github.com/stretchr/testify/assert"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestFakeProvider(t *testing.T) {
+	var callback jewerly.TransactionCallbackInput
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		callback, err = parseFormCallback(r)
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", r.URL.Query().Get("token"))
+	}))
+	defer callbackServer.Close()
+
+	p := NewFakeProvider("http://localhost:8000/payment/fake", "https://www.example.com/payment/success",
+		callbackServer.URL+"/payment/callback?token=secret")
+
+	saleURL, err := p.GenerateSale(GenerateSaleInput{Price: 26500, Currency: "ILS", ProductName: "Order #7",
+		TransactionID: "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11"})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(saleURL, "http://localhost:8000/payment/fake/"))
+
+	saleId := strings.TrimPrefix(saleURL, "http://localhost:8000/payment/fake/")
+
+	// not paid sale can't be refunded
+	_, err = p.Refund(RefundInput{SaleId: saleId, Amount: 100})
+	assert.Error(t, err)
+
+	_, err = p.GetSale("0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11")
+	assert.Equal(t, ErrSalePending, err)
+
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", FakePagePath+"/"+saleId, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "265.00 ILS")
+
+	// declined payment can be retried
+	req := httptest.NewRequest("POST", FakePagePath+"/"+saleId, strings.NewReader(url.Values{"result": {"failure"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	p.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Equal(t, "sale-failure", callback.NotifyType)
+
+	w = httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", FakePagePath+"/"+saleId, nil))
+	assert.Contains(t, w.Body.String(), "The payment failed, try again.")
+
+	req = httptest.NewRequest("POST", FakePagePath+"/"+saleId, strings.NewReader(url.Values{"result": {"success"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	p.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Equal(t, "https://www.example.com/payment/success", w.Header().Get("Location"))
+	assert.Equal(t, jewerly.TransactionCallbackInput{
+		NotifyType:    "sale-complete",
+		TransactionID: "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11",
+		SaleId:        saleId,
+		SaleStatus:    "completed",
+		SaleCreated:   callback.SaleCreated,
+		EventId:       callback.EventId,
+		Price:         26500,
+		Currency:      "ILS",
+		BuyerCardMask: fakeCardMask,
+		CardBrand:     fakeCardBrand,
+		BuyerName:     fakeBuyerName,
+	}, callback)
+	assert.NotEmpty(t, callback.EventId)
+
+	sale, err := p.GetSale("0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11")
+	assert.NoError(t, err)
+	assert.Equal(t, callback.NotifyType, sale.NotifyType)
+	assert.Equal(t, saleId, sale.SaleId)
+	assert.Equal(t, 26500, sale.Price)
+
+	refundId, err := p.Refund(RefundInput{SaleId: saleId, Amount: 20000})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, refundId)
+
+	_, err = p.Refund(RefundInput{SaleId: saleId, Amount: 6501})
+	assert.Error(t, err)
+
+	w = httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", FakePagePath+"/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestFakeProvider_Authorize(t *testing.T) {
+	var callback jewerly.TransactionCallbackInput
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		callback, err = parseFormCallback(r)
+		assert.NoError(t, err)
+	}))
+	defer callbackServer.Close()
+
+	p := NewFakeProvider("http://localhost:8000/payment/fake", "https://www.example.com/payment/success",
+		callbackServer.URL+"/payment/callback")
+
+	pay := func(saleId string) {
+		req := httptest.NewRequest("POST", FakePagePath+"/"+saleId, strings.NewReader(url.Values{"result": {"success"}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusSeeOther, w.Code)
+	}
+
+	saleURL, err := p.GenerateSale(GenerateSaleInput{Price: 26500, Currency: "ILS", ProductName: "Order #8",
+		TransactionID: "5d0e9f4c-1b7a-4a8e-b6f2-3c9d8e7a6b51", AuthorizeOnly: true})
+	assert.NoError(t, err)
+	saleId := strings.TrimPrefix(saleURL, "http://localhost:8000/payment/fake/")
+
+	// sale can't be captured before the authorization
+	_, err = p.Capture(CaptureInput{SaleId: saleId, Amount: 26500})
+	assert.Error(t, err)
+
+	pay(saleId)
+	assert.Equal(t, "sale-authorized", callback.NotifyType)
+	assert.Equal(t, "authorized", callback.SaleStatus)
+	assert.Equal(t, saleId, callback.SaleId)
+
+	// authorized sale isn't charged yet
+	_, err = p.Refund(RefundInput{SaleId: saleId, Amount: 100})
+	assert.Error(t, err)
+	_, err = p.Capture(CaptureInput{SaleId: saleId, Amount: 26501})
+	assert.Error(t, err)
+
+	captureId, err := p.Capture(CaptureInput{SaleId: saleId, Amount: 26500})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, captureId)
+
+	assert.Error(t, p.Void(saleId))
+	_, err = p.Refund(RefundInput{SaleId: saleId, Amount: 26500})
+	assert.NoError(t, err)
+
+	saleURL, err = p.GenerateSale(GenerateSaleInput{Price: 12000, Currency: "ILS", ProductName: "Order #9",
+		TransactionID: "8a1f2e3d-4c5b-4a69-8b7c-6d5e4f3a2b10", AuthorizeOnly: true})
+	assert.NoError(t, err)
+	saleId = strings.TrimPrefix(saleURL, "http://localhost:8000/payment/fake/")
+
+	pay(saleId)
+	assert.NoError(t, p.Void(saleId))
+
+	sale, err := p.GetSale("8a1f2e3d-4c5b-4a69-8b7c-6d5e4f3a2b10")
+	assert.NoError(t, err)
+	assert.Equal(t, "sale-void", sale.NotifyType)
+	_, err = p.Capture(CaptureInput{SaleId: saleId, Amount: 12000})
+	assert.Error(t, err)
+
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", FakePagePath+"/"+saleId, nil))
+	assert.Contains(t, w.Body.String(), "The sale is already voided.")
+}
+
+func TestNewProvider(t *testing.T) {
+	p, err := NewProvider(Config{Provider: "fake"})
+	assert.NoError(t, err)
+	assert.IsType(t, &FakeProvider{}, p)
+
+	_, err = NewProvider(Config{Provider: "isracard"})
+	assert.Equal(t, ErrCredentialsRequired, err)
+
+	_, err = NewProvider(Config{Provider: "stripe", APIKey: "sk_test"})
+	assert.Equal(t, ErrCredentialsRequired, err)
+
+	p, err = NewProvider(Config{Provider: "stripe", APIKey: "sk_test", WebhookSecret: "whsec_test"})
+	assert.NoError(t, err)
+	assert.IsType(t, &StripeProvider{}, p)
+
+	_, err = NewProvider(Config{Provider: "paypal"})
+	assert.Error(t, err)
+}
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
 	"net/http"
 	"time"
 )
@@ -12,7 +15,13 @@ import (
 const (
 	statusFail           = 1
 	generateSaleEndpoint = "generate-sale"
+	refundSaleEndpoint   = "refund-sale"
+	captureSaleEndpoint  = "capture-sale"
+	voidSaleEndpoint     = "void-sale"
+	getSalesEndpoint     = "get-sales"
 	defaultLanguage      = "en"
+
+	saleTypeAuthorize = "authorize"
 )
 
 type IsracardProvider struct {
@@ -38,13 +47,14 @@ func NewIsracardProvider(endpoint, apiKey, returnURL, callbackURL string) *Israc
 
 type generateSaleInput struct {
 	SellerPaymeID string `json:"seller_payme_id"`
-	Price         int    `json:"sale_price"`
+	Price         int64  `json:"sale_price"`
 	Currency      string `json:"currency"`
 	ProductName   string `json:"product_name"`
 	TransactionID string `json:"transaction_id"`
 	CallbackURL   string `json:"sale_callback_url"`
 	ReturnURL     string `json:"sale_return_url"`
 	Language      string `json:"language"`
+	SaleType      string `json:"sale_type,omitempty"`
 }
 
 type generateSaleResponse struct {
@@ -57,7 +67,7 @@ type generateSaleResponse struct {
 func (p *IsracardProvider) GenerateSale(inp GenerateSaleInput) (string, error) {
 	input := &generateSaleInput{
 		SellerPaymeID: p.apiKey,
-		Price:         inp.Price,
+		Price:         inp.Price.MinorUnits(),
 		ProductName:   inp.ProductName,
 		Currency:      inp.Currency,
 		TransactionID: inp.TransactionID,
@@ -67,6 +77,10 @@ func (p *IsracardProvider) GenerateSale(inp GenerateSaleInput) (string, error) {
 	}
 	out := new(generateSaleResponse)
 
+	if inp.AuthorizeOnly {
+		input.SaleType = saleTypeAuthorize
+	}
+
 	logrus.Debugf("generate sale input %+v", input)
 
 	err := p.do(http.MethodPost, generateSaleEndpoint, input, out)
@@ -83,6 +97,182 @@ func (p *IsracardProvider) GenerateSale(inp GenerateSaleInput) (string, error) {
 	return out.SaleURL, nil
 }
 
+type refundSaleInput struct {
+	SellerPaymeID string `json:"seller_payme_id"`
+	SaleID        string `json:"payme_sale_id"`
+	Amount        int64  `json:"sale_refund_amount"`
+	Language      string `json:"language"`
+}
+
+type captureSaleInput struct {
+	SellerPaymeID string `json:"seller_payme_id"`
+	SaleID        string `json:"payme_sale_id"`
+	Price         int64  `json:"sale_price"`
+	Language      string `json:"language"`
+}
+
+type voidSaleInput struct {
+	SellerPaymeID string `json:"seller_payme_id"`
+	SaleID        string `json:"payme_sale_id"`
+	Language      string `json:"language"`
+}
+
+// saleActionResponse is returned for refund, capture and void of the existing sale,
+// TransactionID identifies the action, its callback has the same payme_transaction_id.
+type saleActionResponse struct {
+	StatusCode         int    `json:"status_code"`
+	StatusErrorDetails string `json:"status_error_details"`
+	SaleStatus         string `json:"sale_status"`
+	TransactionID      string `json:"payme_transaction_id"`
+}
+
+// Refund refunds the sale fully or partially, the amount can't exceed the part of the sale price that isn't refunded yet.
+func (p *IsracardProvider) Refund(inp RefundInput) (string, error) {
+	input := &refundSaleInput{
+		SellerPaymeID: p.apiKey,
+		SaleID:        inp.SaleId,
+		Amount:        inp.Amount.MinorUnits(),
+		Language:      defaultLanguage,
+	}
+
+	logrus.Debugf("refund sale input %+v", input)
+
+	out, err := p.doSaleAction(refundSaleEndpoint, input)
+	if err != nil {
+		return "", err
+	}
+
+	return out.TransactionID, nil
+}
+
+// Capture charges the authorized sale.
+func (p *IsracardProvider) Capture(inp CaptureInput) (string, error) {
+	input := &captureSaleInput{
+		SellerPaymeID: p.apiKey,
+		SaleID:        inp.SaleId,
+		Price:         inp.Amount.MinorUnits(),
+		Language:      defaultLanguage,
+	}
+
+	logrus.Debugf("capture sale input %+v", input)
+
+	out, err := p.doSaleAction(captureSaleEndpoint, input)
+	if err != nil {
+		return "", err
+	}
+
+	return out.TransactionID, nil
+}
+
+// Void releases the amount held on the card by the authorized sale.
+func (p *IsracardProvider) Void(saleId string) error {
+	input := &voidSaleInput{
+		SellerPaymeID: p.apiKey,
+		SaleID:        saleId,
+		Language:      defaultLanguage,
+	}
+
+	logrus.Debugf("void sale input %+v", input)
+
+	_, err := p.doSaleAction(voidSaleEndpoint, input)
+	return err
+}
+
+func (p *IsracardProvider) doSaleAction(endpoint string, input interface{}) (*saleActionResponse, error) {
+	out := new(saleActionResponse)
+
+	err := p.do(http.MethodPost, endpoint, input, out)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Debugf("resp: %+v\n", out)
+
+	if out.StatusCode == statusFail {
+		return nil, fmt.Errorf("%s fail: %s", endpoint, out.StatusErrorDetails)
+	}
+
+	return out, nil
+}
+
+type getSalesInput struct {
+	SellerPaymeID string `json:"seller_payme_id"`
+	TransactionID string `json:"transaction_id"`
+}
+
+type isracardSale struct {
+	SaleID        string `json:"payme_sale_id"`
+	TransactionID string `json:"transaction_id"`
+	SaleStatus    string `json:"sale_status"`
+	SaleCreated   string `json:"sale_created"`
+	Price         int    `json:"sale_price"`
+	Currency      string `json:"currency"`
+	BuyerCardMask string `json:"buyer_card_mask"`
+	CardBrand     string `json:"payme_transaction_card_brand"`
+	BuyerName     string `json:"buyer_name"`
+	BuyerEmail    string `json:"buyer_email"`
+}
+
+type getSalesResponse struct {
+	StatusCode         int            `json:"status_code"`
+	StatusErrorDetails string         `json:"status_error_details"`
+	Items              []isracardSale `json:"items"`
+}
+
+// isracardSaleNotifyTypes maps final sale statuses to the callbacks which report them
+var isracardSaleNotifyTypes = map[string]string{
+	"completed":  "sale-complete",
+	"authorized": "sale-authorized",
+	"failed":     "sale-failure",
+	"voided":     "sale-void",
+}
+
+// GetSale returns the latest sale of the transaction, sales which weren't paid yet are in the initial status.
+func (p *IsracardProvider) GetSale(transactionId string) (jewerly.TransactionCallbackInput, error) {
+	out := new(getSalesResponse)
+
+	err := p.do(http.MethodPost, getSalesEndpoint, &getSalesInput{SellerPaymeID: p.apiKey, TransactionID: transactionId}, out)
+	if err != nil {
+		return jewerly.TransactionCallbackInput{}, err
+	}
+
+	if out.StatusCode == statusFail {
+		return jewerly.TransactionCallbackInput{}, fmt.Errorf("%s fail: %s", getSalesEndpoint, out.StatusErrorDetails)
+	}
+
+	if len(out.Items) == 0 || out.Items[len(out.Items)-1].SaleStatus == "initial" {
+		return jewerly.TransactionCallbackInput{}, ErrSalePending
+	}
+
+	sale := out.Items[len(out.Items)-1]
+
+	return jewerly.TransactionCallbackInput{
+		NotifyType:    isracardSaleNotifyTypes[sale.SaleStatus],
+		TransactionID: transactionId,
+		SaleId:        sale.SaleID,
+		SaleStatus:    sale.SaleStatus,
+		SaleCreated:   sale.SaleCreated,
+		Price:         sale.Price,
+		Currency:      sale.Currency,
+		BuyerCardMask: sale.BuyerCardMask,
+		CardBrand:     sale.CardBrand,
+		BuyerName:     sale.BuyerName,
+		BuyerEmail:    sale.BuyerEmail,
+	}, nil
+}
+
+// ParseCallback binds the form posted to the sale callback url.
+func (p *IsracardProvider) ParseCallback(r *http.Request) (jewerly.TransactionCallbackInput, error) {
+	return parseFormCallback(r)
+}
+
+func parseFormCallback(r *http.Request) (jewerly.TransactionCallbackInput, error) {
+	var inp jewerly.TransactionCallbackInput
+	err := binding.Form.Bind(r, &inp)
+
+	return inp, err
+}
+
 // http client
 func (p *IsracardProvider) do(method, endpoint string, input, out interface{}) error {
 	body, err := json.Marshal(input)
@@ -1,12 +1,98 @@
 package payment
 
+import (
+	"errors"
+	"fmt"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"net/http"
+)
+
 type GenerateSaleInput struct {
-	Price int
+	Price jewerly.Money
 	Currency string
 	ProductName string
 	TransactionID string
+
+	// AuthorizeOnly holds the amount on the card without charging it, sale is captured or voided later.
+	AuthorizeOnly bool
+}
+
+// CaptureInput amount can be lower than the authorized one, the rest of the hold is released.
+type CaptureInput struct {
+	SaleId string
+	Amount jewerly.Money
+}
+
+// RefundInput amount is in the sale currency, partial refunds pass less than the sale price.
+type RefundInput struct {
+	SaleId string
+	Amount jewerly.Money
 }
 
 type Provider interface {
 	GenerateSale(inp GenerateSaleInput) (string, error)
+
+	// Refund returns the provider refund id, the refund callback is matched with the saved refund by it.
+	Refund(inp RefundInput) (string, error)
+
+	// Capture returns the id of the capture event, the sale-complete callback the provider sends for it has the same one.
+	Capture(inp CaptureInput) (string, error)
+	Void(saleId string) error
+
+	// ParseCallback converts provider notification to the callback input,
+	// jewerly.ErrCallbackIgnored is returned for notifications that don't affect the payment.
+	ParseCallback(r *http.Request) (jewerly.TransactionCallbackInput, error)
+
+	// GetSale queries the current sale status, it's returned in the callback format so it's saved the same way.
+	// NotifyType is empty if the status doesn't map to any callback, SaleStatus has the provider status then.
+	GetSale(transactionId string) (jewerly.TransactionCallbackInput, error)
+}
+
+var (
+	ErrCredentialsRequired = errors.New("payment credentials are empty")
+	ErrSalePending         = errors.New("sale isn't paid or declined yet")
+)
+
+// Config is shared by all providers, each of them uses only the fields it needs.
+type Config struct {
+	Provider string
+
+	// Endpoint is the provider API url, the fake provider serves its payment page on it.
+	Endpoint      string
+	APIKey        string
+	WebhookSecret string
+
+	CallbackURL string
+	ReturnURL   string
+	CancelURL   string
+}
+
+var providers = map[string]func(cfg Config) (Provider, error){
+	"isracard": func(cfg Config) (Provider, error) {
+		if cfg.APIKey == "" {
+			return nil, ErrCredentialsRequired
+		}
+
+		return NewIsracardProvider(cfg.Endpoint, cfg.APIKey, cfg.ReturnURL, cfg.CallbackURL), nil
+	},
+	"stripe": func(cfg Config) (Provider, error) {
+		if cfg.APIKey == "" || cfg.WebhookSecret == "" {
+			return nil, ErrCredentialsRequired
+		}
+
+		return NewStripeProvider(cfg.Endpoint, cfg.APIKey, cfg.WebhookSecret, cfg.ReturnURL, cfg.CancelURL), nil
+	},
+	"fake": func(cfg Config) (Provider, error) {
+		return NewFakeProvider(cfg.Endpoint, cfg.ReturnURL, cfg.CallbackURL), nil
+	},
+}
+
+// NewProvider creates the provider selected in the config.
+func NewProvider(cfg Config) (Provider, error) {
+	newProvider, ok := providers[cfg.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Provider)
+	}
+
+	return newProvider(cfg)
 }
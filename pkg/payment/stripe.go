@@ -0,0 +1,356 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	checkoutSessionsEndpoint = "checkout/sessions"
+	refundsEndpoint          = "refunds"
+	paymentIntentsEndpoint   = "payment_intents"
+	searchIntentsEndpoint    = "payment_intents/search"
+
+	stripeSignatureHeader = "Stripe-Signature"
+	// webhooks signed earlier are rejected, so intercepted events can't be replayed
+	stripeSignatureTolerance = 5 * time.Minute
+	stripeMaxWebhookSize     = 64 << 10
+)
+
+var errInvalidStripeSignature = errors.New("invalid stripe webhook signature")
+
+// StripeProvider charges customers with Stripe Checkout, payment intent id is used as the sale id.
+type StripeProvider struct {
+	endpoint      string
+	secretKey     string
+	webhookSecret string
+
+	successURL string
+	cancelURL  string
+
+	client http.Client
+}
+
+func NewStripeProvider(endpoint, secretKey, webhookSecret, successURL, cancelURL string) *StripeProvider {
+	return &StripeProvider{
+		endpoint:      endpoint,
+		secretKey:     secretKey,
+		webhookSecret: webhookSecret,
+		successURL:    successURL,
+		cancelURL:     cancelURL,
+		client: http.Client{
+			Timeout: time.Second * 5,
+		}}
+}
+
+type stripeError struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type checkoutSession struct {
+	Id                string `json:"id"`
+	URL               string `json:"url"`
+	ClientReferenceId string `json:"client_reference_id"`
+	AmountTotal       int    `json:"amount_total"`
+	Currency          string `json:"currency"`
+	PaymentStatus     string `json:"payment_status"`
+	PaymentIntent     string `json:"payment_intent"`
+	CustomerDetails   struct {
+		Email string `json:"email"`
+		Name  string `json:"name"`
+	} `json:"customer_details"`
+}
+
+type paymentIntent struct {
+	Id               string `json:"id"`
+	Status           string `json:"status"`
+	Amount           int    `json:"amount"`
+	AmountCapturable int    `json:"amount_capturable"`
+	AmountReceived   int    `json:"amount_received"`
+	CaptureMethod    string `json:"capture_method"`
+	Currency         string `json:"currency"`
+	Metadata         struct {
+		TransactionId string `json:"transaction_id"`
+	} `json:"metadata"`
+}
+
+type paymentIntentList struct {
+	Data []paymentIntent `json:"data"`
+}
+
+type stripeRefund struct {
+	Id string `json:"id"`
+}
+
+type stripeEvent struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+func (p *StripeProvider) GenerateSale(inp GenerateSaleInput) (string, error) {
+	out := new(checkoutSession)
+
+	form := url.Values{
+		"mode":                                   {"payment"},
+		"client_reference_id":                    {inp.TransactionID},
+		"success_url":                            {p.successURL},
+		"cancel_url":                             {p.cancelURL},
+		"line_items[0][quantity]":                {"1"},
+		"line_items[0][price_data][currency]":    {strings.ToLower(inp.Currency)},
+		"line_items[0][price_data][unit_amount]": {strconv.FormatInt(inp.Price.MinorUnits(), 10)},
+		"line_items[0][price_data][product_data][name]": {inp.ProductName},
+		"payment_intent_data[metadata][transaction_id]": {inp.TransactionID},
+	}
+
+	// authorized payment intent waits for the capture, session stays unpaid until then
+	if inp.AuthorizeOnly {
+		form.Set("payment_intent_data[capture_method]", "manual")
+	}
+
+	err := p.do(http.MethodPost, checkoutSessionsEndpoint, form, out)
+	if err != nil {
+		return "", err
+	}
+
+	logrus.Debugf("checkout session: %s", out.Id)
+
+	return out.URL, nil
+}
+
+func (p *StripeProvider) Refund(inp RefundInput) (string, error) {
+	out := new(stripeRefund)
+
+	err := p.do(http.MethodPost, refundsEndpoint, url.Values{
+		"payment_intent": {inp.SaleId},
+		"amount":         {strconv.FormatInt(inp.Amount.MinorUnits(), 10)},
+	}, out)
+	if err != nil {
+		return "", err
+	}
+
+	return out.Id, nil
+}
+
+// Capture doesn't return the event id, webhook events of the captured intent aren't processed.
+func (p *StripeProvider) Capture(inp CaptureInput) (string, error) {
+	return "", p.do(http.MethodPost, fmt.Sprintf("%s/%s/capture", paymentIntentsEndpoint, inp.SaleId), url.Values{
+		"amount_to_capture": {strconv.FormatInt(inp.Amount.MinorUnits(), 10)},
+	}, nil)
+}
+
+func (p *StripeProvider) Void(saleId string) error {
+	return p.do(http.MethodPost, fmt.Sprintf("%s/%s/cancel", paymentIntentsEndpoint, saleId), url.Values{}, nil)
+}
+
+// GetSale finds the payment intent by the transaction id saved in its metadata.
+// Canceled intent of the manually captured sale is voided, otherwise its checkout session has expired.
+func (p *StripeProvider) GetSale(transactionId string) (jewerly.TransactionCallbackInput, error) {
+	out := new(paymentIntentList)
+
+	err := p.do(http.MethodGet, searchIntentsEndpoint, url.Values{
+		"query": {fmt.Sprintf("metadata['transaction_id']:'%s'", transactionId)},
+	}, out)
+	if err != nil {
+		return jewerly.TransactionCallbackInput{}, err
+	}
+
+	if len(out.Data) == 0 {
+		return jewerly.TransactionCallbackInput{}, ErrSalePending
+	}
+
+	intent := out.Data[0]
+	inp := jewerly.TransactionCallbackInput{
+		TransactionID: transactionId,
+		SaleId:        intent.Id,
+		SaleStatus:    intent.Status,
+		Price:         intent.Amount,
+		Currency:      strings.ToUpper(intent.Currency),
+	}
+
+	switch intent.Status {
+	case "succeeded":
+		inp.NotifyType = "sale-complete"
+		inp.Price = intent.AmountReceived
+	case "requires_capture":
+		inp.NotifyType = "sale-authorized"
+		inp.Price = intent.AmountCapturable
+	case "canceled":
+		inp.NotifyType = "sale-failure"
+		if intent.CaptureMethod == "manual" {
+			inp.NotifyType = "sale-void"
+		}
+	case "requires_payment_method", "requires_confirmation", "requires_action", "processing":
+		return jewerly.TransactionCallbackInput{}, ErrSalePending
+	}
+
+	return inp, nil
+}
+
+// ParseCallback verifies the webhook signature and converts checkout session events to the callback input,
+// session is paid either on completion or later for asynchronous payment methods.
+// Authorization of the manually captured sale is reported by the payment intent event.
+func (p *StripeProvider) ParseCallback(r *http.Request) (jewerly.TransactionCallbackInput, error) {
+	payload, err := ioutil.ReadAll(io.LimitReader(r.Body, stripeMaxWebhookSize))
+	if err != nil {
+		return jewerly.TransactionCallbackInput{}, err
+	}
+
+	if err := p.verifySignature(payload, r.Header.Get(stripeSignatureHeader), time.Now()); err != nil {
+		return jewerly.TransactionCallbackInput{}, err
+	}
+
+	var event stripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return jewerly.TransactionCallbackInput{}, err
+	}
+
+	var session checkoutSession
+	var notifyType string
+
+	switch event.Type {
+	case "payment_intent.amount_capturable_updated":
+		var intent paymentIntent
+		if err := json.Unmarshal(event.Data.Object, &intent); err != nil {
+			return jewerly.TransactionCallbackInput{}, err
+		}
+
+		return jewerly.TransactionCallbackInput{
+			NotifyType:    "sale-authorized",
+			TransactionID: intent.Metadata.TransactionId,
+			SaleStatus:    "requires_capture",
+			Price:         intent.AmountCapturable,
+			Currency:      strings.ToUpper(intent.Currency),
+			SaleId:        intent.Id,
+			EventId:       event.Id,
+		}, nil
+	case "checkout.session.completed", "checkout.session.async_payment_succeeded":
+		if err := json.Unmarshal(event.Data.Object, &session); err != nil {
+			return jewerly.TransactionCallbackInput{}, err
+		}
+
+		// asynchronous payment methods complete the session before the money is received
+		if session.PaymentStatus != "paid" {
+			return jewerly.TransactionCallbackInput{}, jewerly.ErrCallbackIgnored
+		}
+		notifyType = "sale-complete"
+	case "checkout.session.async_payment_failed", "checkout.session.expired":
+		if err := json.Unmarshal(event.Data.Object, &session); err != nil {
+			return jewerly.TransactionCallbackInput{}, err
+		}
+		notifyType = "sale-failure"
+	default:
+		return jewerly.TransactionCallbackInput{}, jewerly.ErrCallbackIgnored
+	}
+
+	return jewerly.TransactionCallbackInput{
+		NotifyType:    notifyType,
+		TransactionID: session.ClientReferenceId,
+		SaleStatus:    session.PaymentStatus,
+		Price:         session.AmountTotal,
+		Currency:      strings.ToUpper(session.Currency),
+		BuyerName:     session.CustomerDetails.Name,
+		BuyerEmail:    session.CustomerDetails.Email,
+		SaleId:        session.PaymentIntent,
+		EventId:       event.Id,
+	}, nil
+}
+
+// verifySignature checks the header in the "t=timestamp,v1=signature" format,
+// signature is HMAC-SHA256 of the timestamp and the payload joined with a dot.
+func (p *StripeProvider) verifySignature(payload []byte, header string, now time.Time) error {
+	var timestamp string
+	var signatures []string
+
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		switch kv[0] {
+		case "t":
+			timestamp = kv[1]
+		case "v1":
+			signatures = append(signatures, kv[1])
+		}
+	}
+
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || now.Sub(time.Unix(signedAt, 0)) > stripeSignatureTolerance {
+		return errInvalidStripeSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(p.webhookSecret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	for _, signature := range signatures {
+		sig, err := hex.DecodeString(signature)
+		if err == nil && hmac.Equal(sig, expected) {
+			return nil
+		}
+	}
+
+	return errInvalidStripeSignature
+}
+
+// do sends form encoded request, Stripe API doesn't accept JSON bodies. GET parameters are passed in the query.
+func (p *StripeProvider) do(method, endpoint string, form url.Values, out interface{}) error {
+	var body io.Reader
+	if method == http.MethodGet {
+		endpoint += "?" + form.Encode()
+	} else {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequest(method, p.endpoint+endpoint, body)
+	if err != nil {
+		logrus.Errorf("Error occurred while forming request: %s\n", err.Error())
+		return err
+	}
+
+	req.Header.Set("Content-type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+p.secretKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		logrus.Errorf("Error occurred while sending request: %s\n", err.Error())
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var stripeErr stripeError
+		if err := json.NewDecoder(resp.Body).Decode(&stripeErr); err != nil || stripeErr.Error.Message == "" {
+			return fmt.Errorf("stripe request unsuccessful, status code: %d", resp.StatusCode)
+		}
+
+		return fmt.Errorf("stripe request unsuccessful: %s", stripeErr.Error.Message)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,160 @@
+package payment
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func signStripePayload(secret string, timestamp int64, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.%s", timestamp, payload)))
+
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func TestStripeProvider_ParseCallback(t *testing.T) {
+	p := NewStripeProvider("", "sk_test", "whsec_test", "", "")
+
+	completed := `{"id":"evt_1","type":"checkout.session.completed","data":{"object":{"client_reference_id":"0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11",
+		"amount_total":26500,"currency":"ils","payment_status":"paid","payment_intent":"pi_123",
+		"customer_details":{"email":"test@test.com","name":"Test Test"}}}}`
+	authorized := `{"id":"evt_2","type":"payment_intent.amount_capturable_updated","data":{"object":{"id":"pi_456","amount_capturable":26500,
+		"currency":"ils","metadata":{"transaction_id":"0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11"}}}}`
+	now := time.Now().Unix()
+
+	testTable := []struct {
+		name      string
+		payload   string
+		signature string
+		want      jewerly.TransactionCallbackInput
+		wantErr   error
+	}{
+		{
+			name:      "Completed",
+			payload:   completed,
+			signature: signStripePayload("whsec_test", now, completed),
+			want: jewerly.TransactionCallbackInput{NotifyType: "sale-complete", TransactionID: "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11",
+				SaleStatus: "paid", Price: 26500, Currency: "ILS", BuyerName: "Test Test", BuyerEmail: "test@test.com", SaleId: "pi_123",
+				EventId: "evt_1"},
+		},
+		{
+			name:      "Authorized",
+			payload:   authorized,
+			signature: signStripePayload("whsec_test", now, authorized),
+			want: jewerly.TransactionCallbackInput{NotifyType: "sale-authorized", TransactionID: "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11",
+				SaleStatus: "requires_capture", Price: 26500, Currency: "ILS", SaleId: "pi_456", EventId: "evt_2"},
+		},
+		{
+			name:      "Expired",
+			payload:   `{"type":"checkout.session.expired","data":{"object":{"client_reference_id":"1","payment_status":"unpaid"}}}`,
+			signature: signStripePayload("whsec_test", now, `{"type":"checkout.session.expired","data":{"object":{"client_reference_id":"1","payment_status":"unpaid"}}}`),
+			want:      jewerly.TransactionCallbackInput{NotifyType: "sale-failure", TransactionID: "1", SaleStatus: "unpaid"},
+		},
+		{
+			name:      "Awaiting Async Payment",
+			payload:   `{"type":"checkout.session.completed","data":{"object":{"payment_status":"unpaid"}}}`,
+			signature: signStripePayload("whsec_test", now, `{"type":"checkout.session.completed","data":{"object":{"payment_status":"unpaid"}}}`),
+			wantErr:   jewerly.ErrCallbackIgnored,
+		},
+		{
+			name:      "Other Event",
+			payload:   `{"type":"charge.refunded","data":{"object":{}}}`,
+			signature: signStripePayload("whsec_test", now, `{"type":"charge.refunded","data":{"object":{}}}`),
+			wantErr:   jewerly.ErrCallbackIgnored,
+		},
+		{
+			name:      "Wrong Secret",
+			payload:   completed,
+			signature: signStripePayload("whsec_other", now, completed),
+			wantErr:   errInvalidStripeSignature,
+		},
+		{
+			name:      "Expired Signature",
+			payload:   completed,
+			signature: signStripePayload("whsec_test", now-3600, completed),
+			wantErr:   errInvalidStripeSignature,
+		},
+		{
+			name:      "Missing Signature",
+			payload:   completed,
+			signature: "v1=00",
+			wantErr:   errInvalidStripeSignature,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/payment/callback", bytes.NewBufferString(testCase.payload))
+			req.Header.Set(stripeSignatureHeader, testCase.signature)
+
+			got, err := p.ParseCallback(req)
+			if testCase.wantErr != nil {
+				assert.Equal(t, testCase.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestStripeProvider_GetSale(t *testing.T) {
+	testTable := []struct {
+		name     string
+		response string
+		want     jewerly.TransactionCallbackInput
+		wantErr  error
+	}{
+		{
+			name:     "Succeeded",
+			response: `{"data":[{"id":"pi_123","status":"succeeded","amount":26500,"amount_received":26500,"currency":"ils"}]}`,
+			want: jewerly.TransactionCallbackInput{NotifyType: "sale-complete", TransactionID: "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11",
+				SaleId: "pi_123", SaleStatus: "succeeded", Price: 26500, Currency: "ILS"},
+		},
+		{
+			name:     "Voided",
+			response: `{"data":[{"id":"pi_123","status":"canceled","amount":26500,"capture_method":"manual","currency":"ils"}]}`,
+			want: jewerly.TransactionCallbackInput{NotifyType: "sale-void", TransactionID: "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11",
+				SaleId: "pi_123", SaleStatus: "canceled", Price: 26500, Currency: "ILS"},
+		},
+		{
+			name:     "Processing",
+			response: `{"data":[{"id":"pi_123","status":"processing","amount":26500,"currency":"ils"}]}`,
+			wantErr:  ErrSalePending,
+		},
+		{
+			name:     "Not Found",
+			response: `{"data":[]}`,
+			wantErr:  ErrSalePending,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/payment_intents/search", r.URL.Path)
+				assert.Equal(t, "metadata['transaction_id']:'0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11'", r.URL.Query().Get("query"))
+				assert.Equal(t, "Bearer sk_test", r.Header.Get("Authorization"))
+
+				w.Write([]byte(testCase.response))
+			}))
+			defer server.Close()
+
+			p := NewStripeProvider(server.URL+"/", "sk_test", "whsec_test", "", "")
+
+			got, err := p.GetSale("0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11")
+			assert.Equal(t, testCase.wantErr, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
@@ -7,7 +7,9 @@ package mock_repository
 import (
 	gomock "github.com/golang/mock/gomock"
 	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	null_v3 "gopkg.in/guregu/null.v3"
 	reflect "reflect"
+	time "time"
 )
 
 // MockAdmin is a mock of Admin interface
@@ -47,6 +49,101 @@ func (mr *MockAdminMockRecorder) Authorize(login, passwordHash interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockAdmin)(nil).Authorize), login, passwordHash)
 }
 
+// MockUser is a mock of User interface
+type MockUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserMockRecorder
+}
+
+// MockUserMockRecorder is the mock recorder for MockUser
+type MockUserMockRecorder struct {
+	mock *MockUser
+}
+
+// NewMockUser creates a new mock instance
+func NewMockUser(ctrl *gomock.Controller) *MockUser {
+	mock := &MockUser{ctrl: ctrl}
+	mock.recorder = &MockUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockUser) EXPECT() *MockUserMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockUser) Create(user jewerly.User) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", user)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockUserMockRecorder) Create(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), user)
+}
+
+// GetByEmail mocks base method
+func (m *MockUser) GetByEmail(email string) (jewerly.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", email)
+	ret0, _ := ret[0].(jewerly.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail
+func (mr *MockUserMockRecorder) GetByEmail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUser)(nil).GetByEmail), email)
+}
+
+// CreateToken mocks base method
+func (m *MockUser) CreateToken(token jewerly.UserToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateToken indicates an expected call of CreateToken
+func (mr *MockUserMockRecorder) CreateToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockUser)(nil).CreateToken), token)
+}
+
+// VerifyEmail mocks base method
+func (m *MockUser) VerifyEmail(tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail
+func (mr *MockUserMockRecorder) VerifyEmail(tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUser)(nil).VerifyEmail), tokenHash)
+}
+
+// ResetPassword mocks base method
+func (m *MockUser) ResetPassword(tokenHash, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", tokenHash, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword
+func (mr *MockUserMockRecorder) ResetPassword(tokenHash, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUser)(nil).ResetPassword), tokenHash, passwordHash)
+}
+
 // MockProduct is a mock of Product interface
 type MockProduct struct {
 	ctrl     *gomock.Controller
@@ -99,6 +196,21 @@ func (mr *MockProductMockRecorder) GetAll(filters interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockProduct)(nil).GetAll), filters)
 }
 
+// Search mocks base method
+func (m *MockProduct) Search(filters jewerly.SearchProductsFilters) (jewerly.ProductsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", filters)
+	ret0, _ := ret[0].(jewerly.ProductsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search
+func (mr *MockProductMockRecorder) Search(filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockProduct)(nil).Search), filters)
+}
+
 // GetById mocks base method
 func (m *MockProduct) GetById(id int, language string) (jewerly.ProductResponse, error) {
 	m.ctrl.T.Helper()
@@ -172,6 +284,94 @@ func (mr *MockProductMockRecorder) GetProductImages(productId interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductImages", reflect.TypeOf((*MockProduct)(nil).GetProductImages), productId)
 }
 
+// GetProductsImages mocks base method
+func (m *MockProduct) GetProductsImages(productIds []int) (map[int][]jewerly.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductsImages", productIds)
+	ret0, _ := ret[0].(map[int][]jewerly.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductsImages indicates an expected call of GetProductsImages
+func (mr *MockProductMockRecorder) GetProductsImages(productIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsImages", reflect.TypeOf((*MockProduct)(nil).GetProductsImages), productIds)
+}
+
+// GetProductsVariants mocks base method
+func (m *MockProduct) GetProductsVariants(productIds []int) (map[int][]jewerly.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductsVariants", productIds)
+	ret0, _ := ret[0].(map[int][]jewerly.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductsVariants indicates an expected call of GetProductsVariants
+func (mr *MockProductMockRecorder) GetProductsVariants(productIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsVariants", reflect.TypeOf((*MockProduct)(nil).GetProductsVariants), productIds)
+}
+
+// CreateVariant mocks base method
+func (m *MockProduct) CreateVariant(productId int, inp jewerly.CreateVariantInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVariant", productId, inp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVariant indicates an expected call of CreateVariant
+func (mr *MockProductMockRecorder) CreateVariant(productId, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVariant", reflect.TypeOf((*MockProduct)(nil).CreateVariant), productId, inp)
+}
+
+// UpdateVariant mocks base method
+func (m *MockProduct) UpdateVariant(productId, variantId int, inp jewerly.UpdateVariantInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVariant", productId, variantId, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVariant indicates an expected call of UpdateVariant
+func (mr *MockProductMockRecorder) UpdateVariant(productId, variantId, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVariant", reflect.TypeOf((*MockProduct)(nil).UpdateVariant), productId, variantId, inp)
+}
+
+// DeleteVariant mocks base method
+func (m *MockProduct) DeleteVariant(productId, variantId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVariant", productId, variantId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVariant indicates an expected call of DeleteVariant
+func (mr *MockProductMockRecorder) DeleteVariant(productId, variantId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVariant", reflect.TypeOf((*MockProduct)(nil).DeleteVariant), productId, variantId)
+}
+
+// SetCategorySale mocks base method
+func (m *MockProduct) SetCategorySale(inp jewerly.BulkSaleInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCategorySale", inp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCategorySale indicates an expected call of SetCategorySale
+func (mr *MockProductMockRecorder) SetCategorySale(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCategorySale", reflect.TypeOf((*MockProduct)(nil).SetCategorySale), inp)
+}
+
 // MockOrder is a mock of Order interface
 type MockOrder struct {
 	ctrl     *gomock.Controller
@@ -226,17 +426,60 @@ func (mr *MockOrderMockRecorder) GetOrderProducts(items interface{}) *gomock.Cal
 }
 
 // CreateTransaction mocks base method
-func (m *MockOrder) CreateTransaction(transactionId, cardMask, status string) error {
+func (m *MockOrder) CreateTransaction(transactionId, eventId, cardMask, status string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTransaction", transactionId, cardMask, status)
+	ret := m.ctrl.Call(m, "CreateTransaction", transactionId, eventId, cardMask, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateTransaction indicates an expected call of CreateTransaction
-func (mr *MockOrderMockRecorder) CreateTransaction(transactionId, cardMask, status interface{}) *gomock.Call {
+func (mr *MockOrderMockRecorder) CreateTransaction(transactionId, eventId, cardMask, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockOrder)(nil).CreateTransaction), transactionId, eventId, cardMask, status)
+}
+
+// SetPaymentURL mocks base method
+func (m *MockOrder) SetPaymentURL(transactionId, url string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaymentURL", transactionId, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaymentURL indicates an expected call of SetPaymentURL
+func (mr *MockOrderMockRecorder) SetPaymentURL(transactionId, url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaymentURL", reflect.TypeOf((*MockOrder)(nil).SetPaymentURL), transactionId, url)
+}
+
+// SetSaleId mocks base method
+func (m *MockOrder) SetSaleId(transactionId, saleId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSaleId", transactionId, saleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSaleId indicates an expected call of SetSaleId
+func (mr *MockOrderMockRecorder) SetSaleId(transactionId, saleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSaleId", reflect.TypeOf((*MockOrder)(nil).SetSaleId), transactionId, saleId)
+}
+
+// GetPayment mocks base method
+func (m *MockOrder) GetPayment(orderId int) (jewerly.OrderPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayment", orderId)
+	ret0, _ := ret[0].(jewerly.OrderPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayment indicates an expected call of GetPayment
+func (mr *MockOrderMockRecorder) GetPayment(orderId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockOrder)(nil).CreateTransaction), transactionId, cardMask, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayment", reflect.TypeOf((*MockOrder)(nil).GetPayment), orderId)
 }
 
 // GetOrderId mocks base method
@@ -283,3 +526,1131 @@ func (mr *MockOrderMockRecorder) GetById(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockOrder)(nil).GetById), id)
 }
+
+// GetItemsDetails mocks base method
+func (m *MockOrder) GetItemsDetails(orderIds []int, language string) (map[int][]jewerly.CustomerOrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemsDetails", orderIds, language)
+	ret0, _ := ret[0].(map[int][]jewerly.CustomerOrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemsDetails indicates an expected call of GetItemsDetails
+func (mr *MockOrderMockRecorder) GetItemsDetails(orderIds, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsDetails", reflect.TypeOf((*MockOrder)(nil).GetItemsDetails), orderIds, language)
+}
+
+// UpdateStatus mocks base method
+func (m *MockOrder) UpdateStatus(orderId int, status, changedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", orderId, status, changedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus
+func (mr *MockOrderMockRecorder) UpdateStatus(orderId, status, changedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrder)(nil).UpdateStatus), orderId, status, changedBy)
+}
+
+// SetShipment mocks base method
+func (m *MockOrder) SetShipment(orderId int, inp jewerly.ShipOrderInput, changedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetShipment", orderId, inp, changedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetShipment indicates an expected call of SetShipment
+func (mr *MockOrderMockRecorder) SetShipment(orderId, inp, changedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetShipment", reflect.TypeOf((*MockOrder)(nil).SetShipment), orderId, inp, changedBy)
+}
+
+// CancelUnpaid mocks base method
+func (m *MockOrder) CancelUnpaid(orderId int, changedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUnpaid", orderId, changedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelUnpaid indicates an expected call of CancelUnpaid
+func (mr *MockOrderMockRecorder) CancelUnpaid(orderId, changedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUnpaid", reflect.TypeOf((*MockOrder)(nil).CancelUnpaid), orderId, changedBy)
+}
+
+// GetUnpaidOrderIds mocks base method
+func (m *MockOrder) GetUnpaidOrderIds(before time.Time, paidNotifyTypes []string) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnpaidOrderIds", before, paidNotifyTypes)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnpaidOrderIds indicates an expected call of GetUnpaidOrderIds
+func (mr *MockOrderMockRecorder) GetUnpaidOrderIds(before, paidNotifyTypes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnpaidOrderIds", reflect.TypeOf((*MockOrder)(nil).GetUnpaidOrderIds), before, paidNotifyTypes)
+}
+
+// ReserveRefund mocks base method
+func (m *MockOrder) ReserveRefund(refund jewerly.Refund, refunded jewerly.Money) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveRefund", refund, refunded)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveRefund indicates an expected call of ReserveRefund
+func (mr *MockOrderMockRecorder) ReserveRefund(refund, refunded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveRefund", reflect.TypeOf((*MockOrder)(nil).ReserveRefund), refund, refunded)
+}
+
+// CompleteRefund mocks base method
+func (m *MockOrder) CompleteRefund(refund jewerly.Refund, transactionId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRefund", refund, transactionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteRefund indicates an expected call of CompleteRefund
+func (mr *MockOrderMockRecorder) CompleteRefund(refund, transactionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRefund", reflect.TypeOf((*MockOrder)(nil).CompleteRefund), refund, transactionId)
+}
+
+// CancelRefund mocks base method
+func (m *MockOrder) CancelRefund(refundId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelRefund", refundId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelRefund indicates an expected call of CancelRefund
+func (mr *MockOrderMockRecorder) CancelRefund(refundId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelRefund", reflect.TypeOf((*MockOrder)(nil).CancelRefund), refundId)
+}
+
+// CompleteAuthorization mocks base method
+func (m *MockOrder) CompleteAuthorization(orderId int, transactionId, eventId, cardMask, notifyType, status, changedBy string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteAuthorization", orderId, transactionId, eventId, cardMask, notifyType, status, changedBy)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteAuthorization indicates an expected call of CompleteAuthorization
+func (mr *MockOrderMockRecorder) CompleteAuthorization(orderId, transactionId, eventId, cardMask, notifyType, status, changedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteAuthorization", reflect.TypeOf((*MockOrder)(nil).CompleteAuthorization), orderId, transactionId, eventId, cardMask, notifyType, status, changedBy)
+}
+
+// GetStaleAuthorizedOrderIds mocks base method
+func (m *MockOrder) GetStaleAuthorizedOrderIds(before time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStaleAuthorizedOrderIds", before)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStaleAuthorizedOrderIds indicates an expected call of GetStaleAuthorizedOrderIds
+func (mr *MockOrderMockRecorder) GetStaleAuthorizedOrderIds(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStaleAuthorizedOrderIds", reflect.TypeOf((*MockOrder)(nil).GetStaleAuthorizedOrderIds), before)
+}
+
+// MockCart is a mock of Cart interface
+type MockCart struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartMockRecorder
+}
+
+// MockCartMockRecorder is the mock recorder for MockCart
+type MockCartMockRecorder struct {
+	mock *MockCart
+}
+
+// NewMockCart creates a new mock instance
+func NewMockCart(ctrl *gomock.Controller) *MockCart {
+	mock := &MockCart{ctrl: ctrl}
+	mock.recorder = &MockCartMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCart) EXPECT() *MockCartMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockCart) Create(token string, userId null_v3.Int) (jewerly.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", token, userId)
+	ret0, _ := ret[0].(jewerly.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockCartMockRecorder) Create(token, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCart)(nil).Create), token, userId)
+}
+
+// GetByToken mocks base method
+func (m *MockCart) GetByToken(token string) (jewerly.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByToken", token)
+	ret0, _ := ret[0].(jewerly.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByToken indicates an expected call of GetByToken
+func (mr *MockCartMockRecorder) GetByToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByToken", reflect.TypeOf((*MockCart)(nil).GetByToken), token)
+}
+
+// GetByUserId mocks base method
+func (m *MockCart) GetByUserId(userId int64) (jewerly.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", userId)
+	ret0, _ := ret[0].(jewerly.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserId indicates an expected call of GetByUserId
+func (mr *MockCartMockRecorder) GetByUserId(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockCart)(nil).GetByUserId), userId)
+}
+
+// AssignUser mocks base method
+func (m *MockCart) AssignUser(cartId int, userId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignUser", cartId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignUser indicates an expected call of AssignUser
+func (mr *MockCartMockRecorder) AssignUser(cartId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignUser", reflect.TypeOf((*MockCart)(nil).AssignUser), cartId, userId)
+}
+
+// Merge mocks base method
+func (m *MockCart) Merge(fromCartId, toCartId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", fromCartId, toCartId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge
+func (mr *MockCartMockRecorder) Merge(fromCartId, toCartId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockCart)(nil).Merge), fromCartId, toCartId)
+}
+
+// GetItems mocks base method
+func (m *MockCart) GetItems(cartId int, language string) ([]jewerly.CartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", cartId, language)
+	ret0, _ := ret[0].([]jewerly.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems
+func (mr *MockCartMockRecorder) GetItems(cartId, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockCart)(nil).GetItems), cartId, language)
+}
+
+// AddItem mocks base method
+func (m *MockCart) AddItem(cartId, productId int, variantId null_v3.Int, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", cartId, productId, variantId, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddItem indicates an expected call of AddItem
+func (mr *MockCartMockRecorder) AddItem(cartId, productId, variantId, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockCart)(nil).AddItem), cartId, productId, variantId, quantity)
+}
+
+// SetItemQuantity mocks base method
+func (m *MockCart) SetItemQuantity(cartId, productId int, variantId null_v3.Int, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemQuantity", cartId, productId, variantId, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItemQuantity indicates an expected call of SetItemQuantity
+func (mr *MockCartMockRecorder) SetItemQuantity(cartId, productId, variantId, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemQuantity", reflect.TypeOf((*MockCart)(nil).SetItemQuantity), cartId, productId, variantId, quantity)
+}
+
+// DeleteItem mocks base method
+func (m *MockCart) DeleteItem(cartId, productId int, variantId null_v3.Int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", cartId, productId, variantId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItem indicates an expected call of DeleteItem
+func (mr *MockCartMockRecorder) DeleteItem(cartId, productId, variantId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockCart)(nil).DeleteItem), cartId, productId, variantId)
+}
+
+// Clear mocks base method
+func (m *MockCart) Clear(cartId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", cartId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear
+func (mr *MockCartMockRecorder) Clear(cartId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockCart)(nil).Clear), cartId)
+}
+
+// MockReminder is a mock of Reminder interface
+type MockReminder struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderMockRecorder
+}
+
+// MockReminderMockRecorder is the mock recorder for MockReminder
+type MockReminderMockRecorder struct {
+	mock *MockReminder
+}
+
+// NewMockReminder creates a new mock instance
+func NewMockReminder(ctrl *gomock.Controller) *MockReminder {
+	mock := &MockReminder{ctrl: ctrl}
+	mock.recorder = &MockReminderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockReminder) EXPECT() *MockReminderMockRecorder {
+	return m.recorder
+}
+
+// GetAbandonedCarts mocks base method
+func (m *MockReminder) GetAbandonedCarts(from, to time.Time) ([]jewerly.CartReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAbandonedCarts", from, to)
+	ret0, _ := ret[0].([]jewerly.CartReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAbandonedCarts indicates an expected call of GetAbandonedCarts
+func (mr *MockReminderMockRecorder) GetAbandonedCarts(from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAbandonedCarts", reflect.TypeOf((*MockReminder)(nil).GetAbandonedCarts), from, to)
+}
+
+// GetUnpaidOrders mocks base method
+func (m *MockReminder) GetUnpaidOrders(from, to time.Time, paidNotifyTypes []string) ([]jewerly.OrderReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnpaidOrders", from, to, paidNotifyTypes)
+	ret0, _ := ret[0].([]jewerly.OrderReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnpaidOrders indicates an expected call of GetUnpaidOrders
+func (mr *MockReminderMockRecorder) GetUnpaidOrders(from, to, paidNotifyTypes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnpaidOrders", reflect.TypeOf((*MockReminder)(nil).GetUnpaidOrders), from, to, paidNotifyTypes)
+}
+
+// MarkCartReminded mocks base method
+func (m *MockReminder) MarkCartReminded(cartId int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkCartReminded", cartId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkCartReminded indicates an expected call of MarkCartReminded
+func (mr *MockReminderMockRecorder) MarkCartReminded(cartId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCartReminded", reflect.TypeOf((*MockReminder)(nil).MarkCartReminded), cartId)
+}
+
+// MarkOrderReminded mocks base method
+func (m *MockReminder) MarkOrderReminded(orderId int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOrderReminded", orderId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOrderReminded indicates an expected call of MarkOrderReminded
+func (mr *MockReminderMockRecorder) MarkOrderReminded(orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOrderReminded", reflect.TypeOf((*MockReminder)(nil).MarkOrderReminded), orderId)
+}
+
+// OptOut mocks base method
+func (m *MockReminder) OptOut(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OptOut", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OptOut indicates an expected call of OptOut
+func (mr *MockReminderMockRecorder) OptOut(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OptOut", reflect.TypeOf((*MockReminder)(nil).OptOut), email)
+}
+
+// MockReconciliation is a mock of Reconciliation interface
+type MockReconciliation struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconciliationMockRecorder
+}
+
+// MockReconciliationMockRecorder is the mock recorder for MockReconciliation
+type MockReconciliationMockRecorder struct {
+	mock *MockReconciliation
+}
+
+// NewMockReconciliation creates a new mock instance
+func NewMockReconciliation(ctrl *gomock.Controller) *MockReconciliation {
+	mock := &MockReconciliation{ctrl: ctrl}
+	mock.recorder = &MockReconciliationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockReconciliation) EXPECT() *MockReconciliationMockRecorder {
+	return m.recorder
+}
+
+// GetPendingTransactions mocks base method
+func (m *MockReconciliation) GetPendingTransactions(from, to time.Time, finalNotifyTypes []string) ([]jewerly.PendingTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingTransactions", from, to, finalNotifyTypes)
+	ret0, _ := ret[0].([]jewerly.PendingTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingTransactions indicates an expected call of GetPendingTransactions
+func (mr *MockReconciliationMockRecorder) GetPendingTransactions(from, to, finalNotifyTypes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingTransactions", reflect.TypeOf((*MockReconciliation)(nil).GetPendingTransactions), from, to, finalNotifyTypes)
+}
+
+// CreateDiscrepancy mocks base method
+func (m *MockReconciliation) CreateDiscrepancy(discrepancy jewerly.PaymentDiscrepancy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDiscrepancy", discrepancy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDiscrepancy indicates an expected call of CreateDiscrepancy
+func (mr *MockReconciliationMockRecorder) CreateDiscrepancy(discrepancy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDiscrepancy", reflect.TypeOf((*MockReconciliation)(nil).CreateDiscrepancy), discrepancy)
+}
+
+// GetDiscrepancies mocks base method
+func (m *MockReconciliation) GetDiscrepancies(filters jewerly.PaymentDiscrepancyFilters) ([]jewerly.PaymentDiscrepancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscrepancies", filters)
+	ret0, _ := ret[0].([]jewerly.PaymentDiscrepancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiscrepancies indicates an expected call of GetDiscrepancies
+func (mr *MockReconciliationMockRecorder) GetDiscrepancies(filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscrepancies", reflect.TypeOf((*MockReconciliation)(nil).GetDiscrepancies), filters)
+}
+
+// MockPromo is a mock of Promo interface
+type MockPromo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromoMockRecorder
+}
+
+// MockPromoMockRecorder is the mock recorder for MockPromo
+type MockPromoMockRecorder struct {
+	mock *MockPromo
+}
+
+// NewMockPromo creates a new mock instance
+func NewMockPromo(ctrl *gomock.Controller) *MockPromo {
+	mock := &MockPromo{ctrl: ctrl}
+	mock.recorder = &MockPromoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPromo) EXPECT() *MockPromoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockPromo) Create(inp jewerly.CreatePromoCodeInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", inp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockPromoMockRecorder) Create(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromo)(nil).Create), inp)
+}
+
+// GetAll mocks base method
+func (m *MockPromo) GetAll() ([]jewerly.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]jewerly.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll
+func (mr *MockPromoMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPromo)(nil).GetAll))
+}
+
+// GetById mocks base method
+func (m *MockPromo) GetById(id int) (jewerly.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", id)
+	ret0, _ := ret[0].(jewerly.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById
+func (mr *MockPromoMockRecorder) GetById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPromo)(nil).GetById), id)
+}
+
+// GetByCode mocks base method
+func (m *MockPromo) GetByCode(code string) (jewerly.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", code)
+	ret0, _ := ret[0].(jewerly.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode
+func (mr *MockPromoMockRecorder) GetByCode(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockPromo)(nil).GetByCode), code)
+}
+
+// Update mocks base method
+func (m *MockPromo) Update(id int, inp jewerly.UpdatePromoCodeInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", id, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockPromoMockRecorder) Update(id, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPromo)(nil).Update), id, inp)
+}
+
+// Delete mocks base method
+func (m *MockPromo) Delete(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockPromoMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPromo)(nil).Delete), id)
+}
+
+// CountUsages mocks base method
+func (m *MockPromo) CountUsages(promoCodeId int, email string) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsages", promoCodeId, email)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountUsages indicates an expected call of CountUsages
+func (mr *MockPromoMockRecorder) CountUsages(promoCodeId, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsages", reflect.TypeOf((*MockPromo)(nil).CountUsages), promoCodeId, email)
+}
+
+// MockCurrency is a mock of Currency interface
+type MockCurrency struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyMockRecorder
+}
+
+// MockCurrencyMockRecorder is the mock recorder for MockCurrency
+type MockCurrencyMockRecorder struct {
+	mock *MockCurrency
+}
+
+// NewMockCurrency creates a new mock instance
+func NewMockCurrency(ctrl *gomock.Controller) *MockCurrency {
+	mock := &MockCurrency{ctrl: ctrl}
+	mock.recorder = &MockCurrencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCurrency) EXPECT() *MockCurrencyMockRecorder {
+	return m.recorder
+}
+
+// GetRates mocks base method
+func (m *MockCurrency) GetRates() ([]jewerly.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRates")
+	ret0, _ := ret[0].([]jewerly.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRates indicates an expected call of GetRates
+func (mr *MockCurrencyMockRecorder) GetRates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockCurrency)(nil).GetRates))
+}
+
+// GetRate mocks base method
+func (m *MockCurrency) GetRate(currency string) (jewerly.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRate", currency)
+	ret0, _ := ret[0].(jewerly.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRate indicates an expected call of GetRate
+func (mr *MockCurrencyMockRecorder) GetRate(currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRate", reflect.TypeOf((*MockCurrency)(nil).GetRate), currency)
+}
+
+// SetRates mocks base method
+func (m *MockCurrency) SetRates(rates []jewerly.ExchangeRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRates", rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRates indicates an expected call of SetRates
+func (mr *MockCurrencyMockRecorder) SetRates(rates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRates", reflect.TypeOf((*MockCurrency)(nil).SetRates), rates)
+}
+
+// MockShipping is a mock of Shipping interface
+type MockShipping struct {
+	ctrl     *gomock.Controller
+	recorder *MockShippingMockRecorder
+}
+
+// MockShippingMockRecorder is the mock recorder for MockShipping
+type MockShippingMockRecorder struct {
+	mock *MockShipping
+}
+
+// NewMockShipping creates a new mock instance
+func NewMockShipping(ctrl *gomock.Controller) *MockShipping {
+	mock := &MockShipping{ctrl: ctrl}
+	mock.recorder = &MockShippingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockShipping) EXPECT() *MockShippingMockRecorder {
+	return m.recorder
+}
+
+// CreateZone mocks base method
+func (m *MockShipping) CreateZone(inp jewerly.CreateShippingZoneInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateZone", inp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateZone indicates an expected call of CreateZone
+func (mr *MockShippingMockRecorder) CreateZone(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateZone", reflect.TypeOf((*MockShipping)(nil).CreateZone), inp)
+}
+
+// GetZones mocks base method
+func (m *MockShipping) GetZones() ([]jewerly.ShippingZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetZones")
+	ret0, _ := ret[0].([]jewerly.ShippingZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetZones indicates an expected call of GetZones
+func (mr *MockShippingMockRecorder) GetZones() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetZones", reflect.TypeOf((*MockShipping)(nil).GetZones))
+}
+
+// UpdateZone mocks base method
+func (m *MockShipping) UpdateZone(id int, inp jewerly.UpdateShippingZoneInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateZone", id, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateZone indicates an expected call of UpdateZone
+func (mr *MockShippingMockRecorder) UpdateZone(id, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateZone", reflect.TypeOf((*MockShipping)(nil).UpdateZone), id, inp)
+}
+
+// DeleteZone mocks base method
+func (m *MockShipping) DeleteZone(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteZone", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteZone indicates an expected call of DeleteZone
+func (mr *MockShippingMockRecorder) DeleteZone(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteZone", reflect.TypeOf((*MockShipping)(nil).DeleteZone), id)
+}
+
+// CreateMethod mocks base method
+func (m *MockShipping) CreateMethod(inp jewerly.CreateShippingMethodInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMethod", inp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMethod indicates an expected call of CreateMethod
+func (mr *MockShippingMockRecorder) CreateMethod(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMethod", reflect.TypeOf((*MockShipping)(nil).CreateMethod), inp)
+}
+
+// UpdateMethod mocks base method
+func (m *MockShipping) UpdateMethod(id int, inp jewerly.UpdateShippingMethodInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMethod", id, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMethod indicates an expected call of UpdateMethod
+func (mr *MockShippingMockRecorder) UpdateMethod(id, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMethod", reflect.TypeOf((*MockShipping)(nil).UpdateMethod), id, inp)
+}
+
+// DeleteMethod mocks base method
+func (m *MockShipping) DeleteMethod(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMethod", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMethod indicates an expected call of DeleteMethod
+func (mr *MockShippingMockRecorder) DeleteMethod(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMethod", reflect.TypeOf((*MockShipping)(nil).DeleteMethod), id)
+}
+
+// GetCountryMethods mocks base method
+func (m *MockShipping) GetCountryMethods(country string) ([]jewerly.ShippingMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountryMethods", country)
+	ret0, _ := ret[0].([]jewerly.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountryMethods indicates an expected call of GetCountryMethods
+func (mr *MockShippingMockRecorder) GetCountryMethods(country interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountryMethods", reflect.TypeOf((*MockShipping)(nil).GetCountryMethods), country)
+}
+
+// MockTax is a mock of Tax interface
+type MockTax struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxMockRecorder
+}
+
+// MockTaxMockRecorder is the mock recorder for MockTax
+type MockTaxMockRecorder struct {
+	mock *MockTax
+}
+
+// NewMockTax creates a new mock instance
+func NewMockTax(ctrl *gomock.Controller) *MockTax {
+	mock := &MockTax{ctrl: ctrl}
+	mock.recorder = &MockTaxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTax) EXPECT() *MockTaxMockRecorder {
+	return m.recorder
+}
+
+// GetRates mocks base method
+func (m *MockTax) GetRates() ([]jewerly.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRates")
+	ret0, _ := ret[0].([]jewerly.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRates indicates an expected call of GetRates
+func (mr *MockTaxMockRecorder) GetRates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockTax)(nil).GetRates))
+}
+
+// GetRate mocks base method
+func (m *MockTax) GetRate(country string) (jewerly.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRate", country)
+	ret0, _ := ret[0].(jewerly.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRate indicates an expected call of GetRate
+func (mr *MockTaxMockRecorder) GetRate(country interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRate", reflect.TypeOf((*MockTax)(nil).GetRate), country)
+}
+
+// SetRate mocks base method
+func (m *MockTax) SetRate(rate jewerly.TaxRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRate", rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRate indicates an expected call of SetRate
+func (mr *MockTaxMockRecorder) SetRate(rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRate", reflect.TypeOf((*MockTax)(nil).SetRate), rate)
+}
+
+// DeleteRate mocks base method
+func (m *MockTax) DeleteRate(country string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRate", country)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRate indicates an expected call of DeleteRate
+func (mr *MockTaxMockRecorder) DeleteRate(country interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRate", reflect.TypeOf((*MockTax)(nil).DeleteRate), country)
+}
+
+// MockInvoice is a mock of Invoice interface
+type MockInvoice struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceMockRecorder
+}
+
+// MockInvoiceMockRecorder is the mock recorder for MockInvoice
+type MockInvoiceMockRecorder struct {
+	mock *MockInvoice
+}
+
+// NewMockInvoice creates a new mock instance
+func NewMockInvoice(ctrl *gomock.Controller) *MockInvoice {
+	mock := &MockInvoice{ctrl: ctrl}
+	mock.recorder = &MockInvoiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockInvoice) EXPECT() *MockInvoiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockInvoice) Create(orderId int, saveFile func(int) (string, error)) (jewerly.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", orderId, saveFile)
+	ret0, _ := ret[0].(jewerly.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockInvoiceMockRecorder) Create(orderId, saveFile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInvoice)(nil).Create), orderId, saveFile)
+}
+
+// GetByOrderId mocks base method
+func (m *MockInvoice) GetByOrderId(orderId int) (jewerly.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrderId", orderId)
+	ret0, _ := ret[0].(jewerly.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrderId indicates an expected call of GetByOrderId
+func (mr *MockInvoiceMockRecorder) GetByOrderId(orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrderId", reflect.TypeOf((*MockInvoice)(nil).GetByOrderId), orderId)
+}
+
+// MockSettings is a mock of Settings interface
+type MockSettings struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettingsMockRecorder
+}
+
+// MockSettingsMockRecorder is the mock recorder for MockSettings
+type MockSettingsMockRecorder struct {
+	mock *MockSettings
+}
+
+// NewMockSettings creates a new mock instance
+func NewMockSettings(ctrl *gomock.Controller) *MockSettings {
+	mock := &MockSettings{ctrl: ctrl}
+	mock.recorder = &MockSettingsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSettings) EXPECT() *MockSettingsMockRecorder {
+	return m.recorder
+}
+
+// GetImages mocks base method
+func (m *MockSettings) GetImages() ([]jewerly.HomepageImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImages")
+	ret0, _ := ret[0].([]jewerly.HomepageImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImages indicates an expected call of GetImages
+func (mr *MockSettingsMockRecorder) GetImages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImages", reflect.TypeOf((*MockSettings)(nil).GetImages))
+}
+
+// CreateImage mocks base method
+func (m *MockSettings) CreateImage(imageID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImage", imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateImage indicates an expected call of CreateImage
+func (mr *MockSettingsMockRecorder) CreateImage(imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImage", reflect.TypeOf((*MockSettings)(nil).CreateImage), imageID)
+}
+
+// UpdateImage mocks base method
+func (m *MockSettings) UpdateImage(id, imageID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateImage", id, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateImage indicates an expected call of UpdateImage
+func (mr *MockSettingsMockRecorder) UpdateImage(id, imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImage", reflect.TypeOf((*MockSettings)(nil).UpdateImage), id, imageID)
+}
+
+// GetTextBlocks mocks base method
+func (m *MockSettings) GetTextBlocks() ([]jewerly.TextBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTextBlocks")
+	ret0, _ := ret[0].([]jewerly.TextBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTextBlocks indicates an expected call of GetTextBlocks
+func (mr *MockSettingsMockRecorder) GetTextBlocks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTextBlocks", reflect.TypeOf((*MockSettings)(nil).GetTextBlocks))
+}
+
+// GetTextBlockById mocks base method
+func (m *MockSettings) GetTextBlockById(id int) (jewerly.TextBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTextBlockById", id)
+	ret0, _ := ret[0].(jewerly.TextBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTextBlockById indicates an expected call of GetTextBlockById
+func (mr *MockSettingsMockRecorder) GetTextBlockById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTextBlockById", reflect.TypeOf((*MockSettings)(nil).GetTextBlockById), id)
+}
+
+// CreateTextBlock mocks base method
+func (m *MockSettings) CreateTextBlock(block jewerly.TextBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTextBlock", block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTextBlock indicates an expected call of CreateTextBlock
+func (mr *MockSettingsMockRecorder) CreateTextBlock(block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTextBlock", reflect.TypeOf((*MockSettings)(nil).CreateTextBlock), block)
+}
+
+// UpdateTextBlock mocks base method
+func (m *MockSettings) UpdateTextBlock(id int, block jewerly.UpdateTextBlockInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTextBlock", id, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTextBlock indicates an expected call of UpdateTextBlock
+func (mr *MockSettingsMockRecorder) UpdateTextBlock(id, block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTextBlock", reflect.TypeOf((*MockSettings)(nil).UpdateTextBlock), id, block)
+}
+
+// MockPageText is a mock of PageText interface
+type MockPageText struct {
+	ctrl     *gomock.Controller
+	recorder *MockPageTextMockRecorder
+}
+
+// MockPageTextMockRecorder is the mock recorder for MockPageText
+type MockPageTextMockRecorder struct {
+	mock *MockPageText
+}
+
+// NewMockPageText creates a new mock instance
+func NewMockPageText(ctrl *gomock.Controller) *MockPageText {
+	mock := &MockPageText{ctrl: ctrl}
+	mock.recorder = &MockPageTextMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPageText) EXPECT() *MockPageTextMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockPageText) Create(page string, input jewerly.MultiLanguageInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", page, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create
+func (mr *MockPageTextMockRecorder) Create(page, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPageText)(nil).Create), page, input)
+}
+
+// Update mocks base method
+func (m *MockPageText) Update() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Update")
+}
+
+// Update indicates an expected call of Update
+func (mr *MockPageTextMockRecorder) Update() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPageText)(nil).Update))
+}
+
+// Get mocks base method
+func (m *MockPageText) Get() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Get")
+}
+
+// Get indicates an expected call of Get
+func (mr *MockPageTextMockRecorder) Get() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPageText)(nil).Get))
+}
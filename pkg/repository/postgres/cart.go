@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"gopkg.in/guregu/null.v3"
+)
+
+const (
+	foreignKeyViolationCode = "23503"
+
+	cartItemsVariantConstraint = "cart_items_variant_fkey"
+)
+
+type CartRepository struct {
+	db *sqlx.DB
+}
+
+func NewCartRepository(db *sqlx.DB) *CartRepository {
+	return &CartRepository{db: db}
+}
+
+func (r *CartRepository) Create(token string, userId null.Int) (jewerly.Cart, error) {
+	var cart jewerly.Cart
+
+	err := r.db.Get(&cart, fmt.Sprintf("INSERT INTO %s (token, user_id) VALUES ($1, $2) RETURNING id, token, user_id, updated_at", cartsTable),
+		token, userId)
+
+	return cart, err
+}
+
+func (r *CartRepository) GetByToken(token string) (jewerly.Cart, error) {
+	return r.get("token", token)
+}
+
+func (r *CartRepository) GetByUserId(userId int64) (jewerly.Cart, error) {
+	return r.get("user_id", userId)
+}
+
+func (r *CartRepository) get(column string, value interface{}) (jewerly.Cart, error) {
+	var cart jewerly.Cart
+
+	err := r.db.Get(&cart, fmt.Sprintf("SELECT id, token, user_id, updated_at FROM %s WHERE %s=$1", cartsTable, column), value)
+	if err == sql.ErrNoRows {
+		return cart, jewerly.ErrCartNotFound
+	}
+
+	return cart, err
+}
+
+func (r *CartRepository) AssignUser(cartId int, userId int64) error {
+	_, err := r.db.Exec(fmt.Sprintf("UPDATE %s SET user_id=$1, updated_at=NOW() WHERE id=$2", cartsTable), userId, cartId)
+	return err
+}
+
+// Merge moves items of the cart into another one summing quantities of the same products and deletes the source cart.
+func (r *CartRepository) Merge(fromCartId, toCartId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`INSERT INTO %[1]s (cart_id, product_id, variant_id, quantity) SELECT $1, product_id, variant_id, quantity FROM %[1]s
+									WHERE cart_id = $2 ON CONFLICT (cart_id, product_id, COALESCE(variant_id, 0))
+									DO UPDATE SET quantity = %[1]s.quantity + EXCLUDED.quantity`, cartItemsTable),
+		toCartId, fromCartId)
+	if err != nil {
+		logrus.Errorf("failed to merge cart items: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id=$1", cartsTable), fromCartId)
+	if err != nil {
+		logrus.Errorf("failed to delete merged cart: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("UPDATE %s SET updated_at=NOW() WHERE id=$1", cartsTable), toCartId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetItems returns cart items with the actual product prices and availability.
+func (r *CartRepository) GetItems(cartId int, language string) ([]jewerly.CartItem, error) {
+	var items []jewerly.CartItem
+
+	// item is available only if there is enough stock of the product or selected variant for the quantity in the cart
+	query := fmt.Sprintf(`SELECT ci.product_id, ci.quantity, t.%[1]s as title, %[6]s + COALESCE(v.price_delta, 0) as price,
+							COALESCE(v.stock, p.stock) >= ci.quantity as in_stock, ci.variant_id, v.option_type, v.option_value FROM %[2]s ci
+							JOIN %[3]s p on p.id = ci.product_id
+							JOIN %[4]s t on t.id = p.title_id
+							LEFT JOIN %[5]s v on v.id = ci.variant_id WHERE ci.cart_id = $1 ORDER BY ci.id`,
+		language, cartItemsTable, productsTable, titlesTable, productVariantsTable, currentPriceColumn)
+	err := r.db.Select(&items, query, cartId)
+	if err != nil {
+		logrus.Errorf("failed to get cart items: %s", err.Error())
+		return nil, err
+	}
+
+	ids := make([]int, len(items))
+	for i := range items {
+		ids[i] = items[i].ProductId
+	}
+
+	images, err := selectProductsImages(r.db, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		items[i].Images = images[items[i].ProductId]
+	}
+
+	return items, nil
+}
+
+// AddItem adds product to the cart or increases its quantity if it's already there.
+func (r *CartRepository) AddItem(cartId, productId int, variantId null.Int, quantity int) error {
+	return r.upsertItem(fmt.Sprintf(`INSERT INTO %[1]s (cart_id, product_id, variant_id, quantity) VALUES ($1, $2, $3, $4)
+									ON CONFLICT (cart_id, product_id, COALESCE(variant_id, 0))
+									DO UPDATE SET quantity = %[1]s.quantity + EXCLUDED.quantity`, cartItemsTable),
+		cartId, productId, variantId, quantity)
+}
+
+func (r *CartRepository) SetItemQuantity(cartId, productId int, variantId null.Int, quantity int) error {
+	return r.upsertItem(fmt.Sprintf(`INSERT INTO %s (cart_id, product_id, variant_id, quantity) VALUES ($1, $2, $3, $4)
+									ON CONFLICT (cart_id, product_id, COALESCE(variant_id, 0)) DO UPDATE SET quantity = EXCLUDED.quantity`, cartItemsTable),
+		cartId, productId, variantId, quantity)
+}
+
+func (r *CartRepository) upsertItem(query string, cartId, productId int, variantId null.Int, quantity int) error {
+	_, err := r.db.Exec(query, cartId, productId, variantId, quantity)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == foreignKeyViolationCode {
+		if pqErr.Constraint == cartItemsVariantConstraint {
+			return jewerly.ErrVariantNotFound
+		}
+
+		return jewerly.ErrProductNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return r.touch(cartId)
+}
+
+func (r *CartRepository) DeleteItem(cartId, productId int, variantId null.Int) error {
+	_, err := r.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE cart_id=$1 AND product_id=$2 AND variant_id IS NOT DISTINCT FROM $3", cartItemsTable),
+		cartId, productId, variantId)
+	if err != nil {
+		return err
+	}
+
+	return r.touch(cartId)
+}
+
+func (r *CartRepository) Clear(cartId int) error {
+	_, err := r.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE cart_id=$1", cartItemsTable), cartId)
+	if err != nil {
+		return err
+	}
+
+	return r.touch(cartId)
+}
+
+// touch also resets the abandoned cart reminder, so the customer gets a new one if the cart is left again.
+func (r *CartRepository) touch(cartId int) error {
+	_, err := r.db.Exec(fmt.Sprintf("UPDATE %s SET updated_at=NOW(), reminder_sent_at=NULL WHERE id=$1", cartsTable), cartId)
+	return err
+}
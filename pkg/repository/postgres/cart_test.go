@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"gopkg.in/guregu/null.v3"
+	"testing"
+)
+
+func TestCartRepository_GetItems(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewCartRepository(db)
+
+	mock.ExpectQuery("SELECT ci.product_id, ci.quantity, t.english as title, CASE WHEN (.+) THEN p.sale_price ELSE p.price END \\+ COALESCE\\(v.price_delta, 0\\) as price, " +
+		"COALESCE\\(v.stock, p.stock\\) >= ci.quantity as in_stock, ci.variant_id, v.option_type, v.option_value FROM cart_items ci (.+) WHERE ci.cart_id = \\$1").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "quantity", "title", "price", "in_stock", "variant_id", "option_type", "option_value"}).
+			AddRow(1, 2, "Ring", 110, true, 5, "size", "17").AddRow(2, 1, "Earrings", 50, false, nil, nil, nil))
+	mock.ExpectQuery("SELECT pi.product_id, i.id, i.url, i.alt_text FROM images i (.+) WHERE pi.product_id = ANY\\(\\$1\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "id", "url", "alt_text"}).
+			AddRow(2, 10, "https://images/2.png", nil))
+
+	got, err := r.GetItems(1, jewerly.English)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, []jewerly.CartItem{
+		{ProductId: 1, Title: "Ring", Price: 11000, Quantity: 2, InStock: true, VariantId: null.IntFrom(5),
+			VariantOption: null.StringFrom("size"), VariantValue: null.StringFrom("17")},
+		{ProductId: 2, Title: "Earrings", Price: 5000, Quantity: 1, Images: []jewerly.Image{{Id: 10, URL: "https://images/2.png"}}},
+	}, got)
+}
+
+func TestCartRepository_AddItem(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewCartRepository(db)
+
+	type args struct {
+		cartId    int
+		productId int
+		variantId null.Int
+		quantity  int
+	}
+
+	type mockBehavior func(args args)
+
+	testTable := []struct {
+		name         string
+		args         args
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			args: args{cartId: 1, productId: 2, quantity: 3},
+			mockBehavior: func(args args) {
+				mock.ExpectExec("INSERT INTO cart_items (.+) ON CONFLICT \\(cart_id, product_id, COALESCE\\(variant_id, 0\\)\\) DO UPDATE SET quantity = cart_items.quantity \\+ EXCLUDED.quantity").
+					WithArgs(args.cartId, args.productId, args.variantId, args.quantity).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE carts SET updated_at=NOW\\(\\)").
+					WithArgs(args.cartId).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Product Not Found",
+			args: args{cartId: 1, productId: 100, quantity: 1},
+			mockBehavior: func(args args) {
+				mock.ExpectExec("INSERT INTO cart_items").
+					WithArgs(args.cartId, args.productId, args.variantId, args.quantity).WillReturnError(&pq.Error{Code: "23503"})
+			},
+			wantErr: jewerly.ErrProductNotFound,
+		},
+		{
+			name: "Variant Not Found",
+			args: args{cartId: 1, productId: 2, variantId: null.IntFrom(100), quantity: 1},
+			mockBehavior: func(args args) {
+				mock.ExpectExec("INSERT INTO cart_items").
+					WithArgs(args.cartId, args.productId, args.variantId, args.quantity).
+					WillReturnError(&pq.Error{Code: "23503", Constraint: "cart_items_variant_fkey"})
+			},
+			wantErr: jewerly.ErrVariantNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.args)
+
+			err := r.AddItem(testCase.args.cartId, testCase.args.productId, testCase.args.variantId, testCase.args.quantity)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCartRepository_Merge(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewCartRepository(db)
+
+	type mockBehavior func(from, to int)
+
+	testTable := []struct {
+		name         string
+		from, to     int
+		mockBehavior mockBehavior
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			from: 1,
+			to:   2,
+			mockBehavior: func(from, to int) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO cart_items (.+) SELECT \\$1, product_id, variant_id, quantity FROM cart_items WHERE cart_id = \\$2").
+					WithArgs(to, from).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM carts").WithArgs(from).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE carts SET updated_at=NOW\\(\\)").WithArgs(to).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Failed To Move Items",
+			from: 1,
+			to:   2,
+			mockBehavior: func(from, to int) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO cart_items").
+					WithArgs(to, from).WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.from, testCase.to)
+
+			err := r.Merge(testCase.from, testCase.to)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+)
+
+type CurrencyRepository struct {
+	db *sqlx.DB
+}
+
+func NewCurrencyRepository(db *sqlx.DB) *CurrencyRepository {
+	return &CurrencyRepository{db: db}
+}
+
+func (r *CurrencyRepository) GetRates() ([]jewerly.ExchangeRate, error) {
+	var rates []jewerly.ExchangeRate
+	err := r.db.Select(&rates, fmt.Sprintf("SELECT currency, rate, updated_at FROM %s ORDER BY currency", exchangeRatesTable))
+
+	return rates, err
+}
+
+func (r *CurrencyRepository) GetRate(currency string) (jewerly.ExchangeRate, error) {
+	var rate jewerly.ExchangeRate
+	err := r.db.Get(&rate, fmt.Sprintf("SELECT currency, rate, updated_at FROM %s WHERE currency = $1", exchangeRatesTable), currency)
+	if err == sql.ErrNoRows {
+		return rate, jewerly.ErrExchangeRateNotFound
+	}
+
+	return rate, err
+}
+
+// SetRates creates or updates all rates at once, so imported file is either applied completely or not applied at all.
+func (r *CurrencyRepository) SetRates(rates []jewerly.ExchangeRate) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (currency, rate) VALUES ($1, $2)
+							ON CONFLICT (currency) DO UPDATE SET rate = excluded.rate, updated_at = NOW()`, exchangeRatesTable)
+	for _, rate := range rates {
+		if _, err := tx.Exec(query, rate.Currency, rate.Rate); err != nil {
+			logrus.Errorf("failed to set %s exchange rate: %s", rate.Currency, err.Error())
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"testing"
+	"time"
+)
+
+func TestCurrencyRepository_GetRate(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewCurrencyRepository(db)
+
+	updatedAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name         string
+		currency     string
+		mockBehavior func(currency string)
+		want         jewerly.ExchangeRate
+		wantErr      error
+	}{
+		{
+			name:     "Ok",
+			currency: jewerly.CurrencyUAH,
+			mockBehavior: func(currency string) {
+				mock.ExpectQuery("SELECT currency, rate, updated_at FROM exchange_rates WHERE currency = \\$1").WithArgs(currency).
+					WillReturnRows(sqlmock.NewRows([]string{"currency", "rate", "updated_at"}).AddRow(currency, 27.85, updatedAt))
+			},
+			want: jewerly.ExchangeRate{Currency: jewerly.CurrencyUAH, Rate: 27.85, UpdatedAt: updatedAt},
+		},
+		{
+			name:     "Not Found",
+			currency: jewerly.CurrencyILS,
+			mockBehavior: func(currency string) {
+				mock.ExpectQuery("SELECT currency, rate, updated_at FROM exchange_rates WHERE currency = \\$1").WithArgs(currency).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: jewerly.ErrExchangeRateNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.currency)
+
+			got, err := r.GetRate(testCase.currency)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.wantErr == nil {
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCurrencyRepository_SetRates(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewCurrencyRepository(db)
+
+	rates := []jewerly.ExchangeRate{
+		{Currency: jewerly.CurrencyEUR, Rate: 0.84},
+		{Currency: jewerly.CurrencyUAH, Rate: 27.85},
+	}
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				for _, rate := range rates {
+					mock.ExpectExec("INSERT INTO exchange_rates \\(currency, rate\\) VALUES \\(\\$1, \\$2\\) ON CONFLICT \\(currency\\) DO UPDATE").
+						WithArgs(rate.Currency, rate.Rate).WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Failed To Set Rate",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO exchange_rates").
+					WithArgs(rates[0].Currency, rates[0].Rate).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO exchange_rates").
+					WithArgs(rates[1].Currency, rates[1].Rate).WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			err := r.SetRates(rates)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
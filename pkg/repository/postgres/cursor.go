@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+)
+
+// cursor points to the last row of the previous page: the value of the sort column and row id as a tie-breaker.
+// Sort keeps the order the cursor was made for, the value can't be compared with another sort column.
+// Clients receive it base64 encoded and should treat it as an opaque string.
+type cursor struct {
+	Sort  string `json:"s,omitempty"`
+	Value string `json:"v,omitempty"`
+	Id    int    `json:"id"`
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, jewerly.ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, &c); err != nil || c.Id <= 0 {
+		return c, jewerly.ErrInvalidCursor
+	}
+
+	return c, nil
+}
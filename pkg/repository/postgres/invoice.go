@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+)
+
+const invoiceColumns = "id, order_id, number, file_name, created_at"
+
+type InvoiceRepository struct {
+	db *sqlx.DB
+}
+
+func NewInvoiceRepository(db *sqlx.DB) *InvoiceRepository {
+	return &InvoiceRepository{db: db}
+}
+
+// Create issues the next invoice number to the order, saveFile stores the invoice file before the invoice is inserted.
+// Invoices table is locked until the transaction ends, so numbers are sequential without gaps
+// even when concurrent callbacks are processed or saving the file fails.
+// Invoice is created only once per order, the existing one is returned without saving the file again.
+func (r *InvoiceRepository) Create(orderId int, saveFile func(number int) (string, error)) (jewerly.Invoice, error) {
+	var invoice jewerly.Invoice
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return invoice, err
+	}
+
+	if _, err := tx.Exec(fmt.Sprintf("LOCK TABLE %s IN SHARE ROW EXCLUSIVE MODE", invoicesTable)); err != nil {
+		logrus.Errorf("failed to lock invoices table: %s", err.Error())
+		tx.Rollback()
+		return invoice, err
+	}
+
+	err = tx.QueryRow(fmt.Sprintf("SELECT %s FROM %s WHERE order_id = $1", invoiceColumns, invoicesTable), orderId).
+		Scan(&invoice.Id, &invoice.OrderId, &invoice.Number, &invoice.FileName, &invoice.CreatedAt)
+	if err == nil {
+		return invoice, tx.Commit()
+	}
+
+	if err != sql.ErrNoRows {
+		logrus.Errorf("failed to get order invoice: %s", err.Error())
+		tx.Rollback()
+		return invoice, err
+	}
+
+	var number int
+	if err := tx.QueryRow(fmt.Sprintf("SELECT COALESCE(MAX(number), 0) + 1 FROM %s", invoicesTable)).Scan(&number); err != nil {
+		logrus.Errorf("failed to get next invoice number: %s", err.Error())
+		tx.Rollback()
+		return invoice, err
+	}
+
+	fileName, err := saveFile(number)
+	if err != nil {
+		logrus.Errorf("failed to save invoice file: %s", err.Error())
+		tx.Rollback()
+		return invoice, err
+	}
+
+	invoice = jewerly.Invoice{OrderId: orderId, Number: number, FileName: fileName}
+	err = tx.QueryRow(fmt.Sprintf("INSERT INTO %s (order_id, number, file_name) VALUES ($1, $2, $3) RETURNING id, created_at", invoicesTable),
+		orderId, number, fileName).Scan(&invoice.Id, &invoice.CreatedAt)
+	if err != nil {
+		logrus.Errorf("failed to create invoice: %s", err.Error())
+		tx.Rollback()
+		return invoice, err
+	}
+
+	return invoice, tx.Commit()
+}
+
+func (r *InvoiceRepository) GetByOrderId(orderId int) (jewerly.Invoice, error) {
+	var invoice jewerly.Invoice
+	err := r.db.Get(&invoice, fmt.Sprintf("SELECT %s FROM %s WHERE order_id = $1", invoiceColumns, invoicesTable), orderId)
+	if err == sql.ErrNoRows {
+		return invoice, jewerly.ErrInvoiceNotFound
+	}
+
+	return invoice, err
+}
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"testing"
+	"time"
+)
+
+func TestInvoiceRepository_Create(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewInvoiceRepository(db)
+
+	createdAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name         string
+		orderId      int
+		saveErr      error
+		mockBehavior func(orderId int)
+		want         jewerly.Invoice
+		wantSaved    int
+		wantErr      bool
+	}{
+		{
+			name:    "Ok",
+			orderId: 7,
+			mockBehavior: func(orderId int) {
+				mock.ExpectBegin()
+				mock.ExpectExec("LOCK TABLE invoices IN SHARE ROW EXCLUSIVE MODE").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FROM invoices WHERE order_id = \\$1").WithArgs(orderId).WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery("SELECT COALESCE\\(MAX\\(number\\), 0\\) \\+ 1 FROM invoices").
+					WillReturnRows(sqlmock.NewRows([]string{"number"}).AddRow(42))
+				mock.ExpectQuery("INSERT INTO invoices \\(order_id, number, file_name\\) VALUES \\(\\$1, \\$2, \\$3\\) RETURNING id, created_at").
+					WithArgs(orderId, 42, "invoices/42.pdf").WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(3, createdAt))
+				mock.ExpectCommit()
+			},
+			want:      jewerly.Invoice{Id: 3, OrderId: 7, Number: 42, FileName: "invoices/42.pdf", CreatedAt: createdAt},
+			wantSaved: 42,
+		},
+		{
+			name:    "Already Created",
+			orderId: 7,
+			mockBehavior: func(orderId int) {
+				mock.ExpectBegin()
+				mock.ExpectExec("LOCK TABLE invoices").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FROM invoices WHERE order_id = \\$1").WithArgs(orderId).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "number", "file_name", "created_at"}).
+						AddRow(3, orderId, 41, "invoices/41.pdf", createdAt))
+				mock.ExpectCommit()
+			},
+			want: jewerly.Invoice{Id: 3, OrderId: 7, Number: 41, FileName: "invoices/41.pdf", CreatedAt: createdAt},
+		},
+		{
+			name:    "Save File Error",
+			orderId: 7,
+			saveErr: errors.New("upload failed"),
+			mockBehavior: func(orderId int) {
+				mock.ExpectBegin()
+				mock.ExpectExec("LOCK TABLE invoices").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FROM invoices WHERE order_id = \\$1").WithArgs(orderId).WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery("SELECT COALESCE\\(MAX\\(number\\), 0\\) \\+ 1 FROM invoices").
+					WillReturnRows(sqlmock.NewRows([]string{"number"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			wantSaved: 1,
+			wantErr:   true,
+		},
+		{
+			name:    "Insert Error",
+			orderId: 7,
+			mockBehavior: func(orderId int) {
+				mock.ExpectBegin()
+				mock.ExpectExec("LOCK TABLE invoices").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FROM invoices WHERE order_id = \\$1").WithArgs(orderId).WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery("SELECT COALESCE\\(MAX\\(number\\), 0\\) \\+ 1 FROM invoices").
+					WillReturnRows(sqlmock.NewRows([]string{"number"}).AddRow(1))
+				mock.ExpectQuery("INSERT INTO invoices").WithArgs(orderId, 1, "invoices/1.pdf").WillReturnError(errors.New("fail"))
+				mock.ExpectRollback()
+			},
+			wantSaved: 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.orderId)
+
+			var saved int
+			got, err := r.Create(testCase.orderId, func(number int) (string, error) {
+				saved = number
+				return fmt.Sprintf("invoices/%d.pdf", number), testCase.saveErr
+			})
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.Equal(t, testCase.wantSaved, saved)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestInvoiceRepository_GetByOrderId(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewInvoiceRepository(db)
+
+	mock.ExpectQuery("SELECT id, order_id, number, file_name, created_at FROM invoices WHERE order_id = \\$1").WithArgs(8).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = r.GetByOrderId(8)
+	assert.Equal(t, jewerly.ErrInvoiceNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
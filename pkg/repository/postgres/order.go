@@ -4,11 +4,19 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"gopkg.in/guregu/null.v3"
+	"sort"
 	"strings"
+	"time"
 )
 
+const orderColumns = `id, user_id, ordered_at, first_name, last_name, additional_name, country, address, email, postal_code, total_cost,
+						promo_code, discount, free_shipping, currency, exchange_rate, status, status_updated_at, status_updated_by, carrier, tracking_number, tracking_url, shipped_at,
+						shipping_method_id, shipping_method, shipping_cost, net_amount, tax_amount, tax_rate, tax_inclusive`
+
 type OrderRepository struct {
 	db *sqlx.DB
 }
@@ -23,6 +31,10 @@ func (r *OrderRepository) Create(input jewerly.CreateOrderInput) (int, error) {
 		return 0, err
 	}
 
+	if err := r.reserveStock(tx, input.Items); err != nil {
+		return 0, err
+	}
+
 	orderId, err := r.createOrder(tx, input)
 	if err != nil {
 		return 0, err
@@ -33,6 +45,12 @@ func (r *OrderRepository) Create(input jewerly.CreateOrderInput) (int, error) {
 		return 0, err
 	}
 
+	if input.Discount.PromoCodeId != 0 {
+		if err := r.usePromoCode(tx, orderId, input); err != nil {
+			return 0, err
+		}
+	}
+
 	err = r.createOrderTransactionRecords(tx, orderId, input.TransactionID)
 	if err != nil {
 		return 0, err
@@ -47,9 +65,25 @@ func (r *OrderRepository) GetOrderProducts(items []jewerly.OrderItem) ([]jewerly
 		return nil, err
 	}
 
-	err = r.getProductsImages(products)
+	if err := r.getProductsImages(products); err != nil {
+		return nil, err
+	}
 
-	return products, err
+	ids := make([]int, len(products))
+	for i := range products {
+		ids[i] = products[i].Id
+	}
+
+	variants, err := selectProductsVariants(r.db, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range products {
+		products[i].Variants = variants[products[i].Id]
+	}
+
+	return products, nil
 }
 
 func (r *OrderRepository) getOrderProducts(items []jewerly.OrderItem) ([]jewerly.ProductResponse, error) {
@@ -63,90 +97,209 @@ func (r *OrderRepository) getOrderProducts(items []jewerly.OrderItem) ([]jewerly
 		ids[i] = fmt.Sprintf("$%d", i+1)
 	}
 
-	err := r.db.Select(&products, fmt.Sprintf("SELECT p.id, p.price FROM %s p INNER JOIN %s t ON t.id = p.title_id WHERE p.id IN (%s) and p.in_stock=true",
-		productsTable, titlesTable, strings.Join(ids, ",")), values...)
+	err := r.db.Select(&products, fmt.Sprintf(`SELECT p.id, p.price, p.category_id, p.weight, p.made_to_order, %s FROM %s p INNER JOIN %s t ON t.id = p.title_id
+									WHERE p.id IN (%s)`, productSaleColumns, productsTable, titlesTable, strings.Join(ids, ",")), values...)
 
 	return products, err
 }
 
 func (r *OrderRepository) getProductsImages(products []jewerly.ProductResponse) error {
+	ids := make([]int, len(products))
 	for i := range products {
-		err := r.db.Select(&products[i].Images,
-			fmt.Sprintf("SELECT i.id, i.url, i.alt_text FROM %s i INNER JOIN %s pi on pi.image_id = i.id WHERE pi.product_id=$1", imagesTable, productImagesTable),
-			products[i].Id)
-		if err != nil {
-			return err
-		}
+		ids[i] = products[i].Id
+	}
+
+	images, err := selectProductsImages(r.db, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range products {
+		products[i].Images = images[products[i].Id]
+	}
+
+	return nil
+}
+
+// CreateTransaction saves transaction status from the payment callback, ErrDuplicateCallback is returned when the event
+// was already received or the transaction already has the status, so repeated deliveries are processed once.
+// Partial refund can repeat for the transaction, only the event id tells its deliveries apart. The event id is unique
+// with the status, as PayMe sends the same one with every notify type of the sale.
+func (r *OrderRepository) CreateTransaction(transactionId, eventId, cardMask, status string) error {
+	res, err := r.db.Exec(fmt.Sprintf("INSERT INTO %s (uuid, event_id, card_mask, status) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING",
+		transactionsHistoryTable), transactionId, null.NewString(eventId, eventId != ""), cardMask, status)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return jewerly.ErrDuplicateCallback
 	}
 
 	return nil
 }
 
-func (r *OrderRepository) CreateTransaction(transactionId, cardMask, status string) error {
-	_, err := r.db.Exec(fmt.Sprintf("INSERT INTO %s (uuid, card_mask, status) VALUES ($1, $2, $3)", transactionsHistoryTable),
-		transactionId, cardMask, status)
+func (r *OrderRepository) SetPaymentURL(transactionId, url string) error {
+	_, err := r.db.Exec(fmt.Sprintf("UPDATE %s SET payment_url=$1 WHERE uuid=$2", transactionsTable), url, transactionId)
 	return err
 }
 
+func (r *OrderRepository) SetSaleId(transactionId, saleId string) error {
+	_, err := r.db.Exec(fmt.Sprintf("UPDATE %s SET sale_id=$1 WHERE uuid=$2", transactionsTable), saleId, transactionId)
+	return err
+}
+
+func (r *OrderRepository) GetPayment(orderId int) (jewerly.OrderPayment, error) {
+	var payment jewerly.OrderPayment
+	err := r.db.Get(&payment, fmt.Sprintf("SELECT uuid, sale_id FROM %s WHERE order_id=$1", transactionsTable), orderId)
+	if err == sql.ErrNoRows {
+		return payment, jewerly.ErrTransactionNotFound
+	}
+
+	return payment, err
+}
+
 func (r *OrderRepository) GetOrderId(transactionId string) (int, error) {
 	var id int
 	err := r.db.Get(&id, fmt.Sprintf("SELECT order_id FROM %s WHERE uuid=$1", transactionsTable), transactionId)
+	if err == sql.ErrNoRows {
+		return id, jewerly.ErrTransactionNotFound
+	}
+
 	return id, err
 }
 
 func (r *OrderRepository) GetAll(input jewerly.GetAllOrdersFilters) (jewerly.OrderList, error) {
 	var orders jewerly.OrderList
 
-	selectOrdersQuery := fmt.Sprintf(`SELECT id, ordered_at, first_name, last_name, additional_name, country,
-										address, email, postal_code, total_cost FROM %s OFFSET $1 LIMIT $2`, ordersTable)
-	err := r.db.Select(&orders.Data, selectOrdersQuery, input.Offset, input.Limit)
-	if err != nil {
-		logrus.Errorf("failed to get orders: %s", err.Error())
-		return orders, err
-	}
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
 
-	selectCountQuery := fmt.Sprintf(`SELECT count(*) FROM %s`, ordersTable)
-	err = r.db.Get(&orders.Total, selectCountQuery)
-	if err != nil {
-		logrus.Errorf("failed to get orders count: %s", err.Error())
-		return orders, err
+	if input.UserId.Valid {
+		args = append(args, input.UserId.Int64)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
 	}
 
-	selectOrderItemsQuery := fmt.Sprintf("SELECT product_id, quantity FROM %s WHERE order_id = $1", orderItemsTable)
+	selectCountQuery := fmt.Sprintf(`SELECT count(*) FROM %s %s`, ordersTable, buildWhereQuery(conditions))
+	countArgs := args
 
-	for i := range orders.Data {
-		err = r.db.Select(&orders.Data[i].Items, selectOrderItemsQuery, orders.Data[i].Id)
+	// newest orders go first, keyset pagination replaces offset when cursor is passed
+	offset := input.Offset
+	if input.Cursor != "" {
+		c, err := decodeCursor(input.Cursor)
 		if err != nil {
-			logrus.Errorf("failed to get order items for order id %d, error: %s", orders.Data[i].Id, err.Error())
 			return orders, err
 		}
+
+		args = append(args, c.Id)
+		conditions = append(conditions, fmt.Sprintf("id < $%d", len(args)))
+		offset = 0
 	}
 
-	selectTransactionsQuery := fmt.Sprintf(`SELECT th.uuid, th.created_at, th.status, th.card_mask FROM %s th 
-											INNER JOIN %s t on t.uuid = th.uuid WHERE t.order_id = $1`, transactionsHistoryTable, transactionsTable)
-	for i := range orders.Data {
-		err = r.db.Select(&orders.Data[i].Transactions, selectTransactionsQuery, orders.Data[i].Id)
+	args = append(args, offset, input.Limit+1)
+	selectOrdersQuery := fmt.Sprintf(`SELECT %s FROM %s %s ORDER BY id DESC OFFSET $%d LIMIT $%d`, orderColumns, ordersTable,
+		buildWhereQuery(conditions), len(args)-1, len(args))
+	err := r.db.Select(&orders.Data, selectOrdersQuery, args...)
+	if err != nil {
+		logrus.Errorf("failed to get orders: %s", err.Error())
+		return orders, err
+	}
+
+	// one extra row shows that the next page exists
+	if len(orders.Data) > input.Limit {
+		orders.Data = orders.Data[:input.Limit]
+		orders.NextCursor = encodeCursor(cursor{Id: orders.Data[len(orders.Data)-1].Id})
+	}
+
+	if input.Cursor == "" {
+		err = r.db.Get(&orders.Total, selectCountQuery, countArgs...)
 		if err != nil {
-			logrus.Errorf("failed to get transactions for order id %d, error: %s", orders.Data[i].Id, err.Error())
+			logrus.Errorf("failed to get orders count: %s", err.Error())
 			return orders, err
 		}
 	}
 
-	return orders, nil
+	if len(orders.Data) == 0 {
+		return orders, nil
+	}
+
+	err = r.setOrdersDetails(orders.Data)
+
+	return orders, err
+}
+
+type orderItemRow struct {
+	OrderId int `db:"order_id"`
+	jewerly.OrderItem
+}
+
+type transactionRow struct {
+	OrderId int `db:"order_id"`
+	jewerly.Transaction
+}
+
+// setOrdersDetails loads items and transactions of all orders with one query per relation.
+func (r *OrderRepository) setOrdersDetails(orders []jewerly.Order) error {
+	ids := make([]int, len(orders))
+	for i := range orders {
+		ids[i] = orders[i].Id
+	}
+
+	var items []orderItemRow
+	selectOrderItemsQuery := fmt.Sprintf("SELECT order_id, product_id, quantity, variant_id FROM %s WHERE order_id = ANY($1) ORDER BY id", orderItemsTable)
+	err := r.db.Select(&items, selectOrderItemsQuery, pq.Array(ids))
+	if err != nil {
+		logrus.Errorf("failed to get order items: %s", err.Error())
+		return err
+	}
+
+	var transactions []transactionRow
+	selectTransactionsQuery := fmt.Sprintf(`SELECT t.order_id, th.uuid, th.created_at, th.status, th.card_mask FROM %s th 
+											INNER JOIN %s t on t.uuid = th.uuid WHERE t.order_id = ANY($1) ORDER BY th.id`,
+		transactionsHistoryTable, transactionsTable)
+	err = r.db.Select(&transactions, selectTransactionsQuery, pq.Array(ids))
+	if err != nil {
+		logrus.Errorf("failed to get transactions: %s", err.Error())
+		return err
+	}
+
+	positions := make(map[int]int, len(orders))
+	for i := range orders {
+		positions[orders[i].Id] = i
+	}
+
+	for _, item := range items {
+		i := positions[item.OrderId]
+		orders[i].Items = append(orders[i].Items, item.OrderItem)
+	}
+
+	for _, transaction := range transactions {
+		i := positions[transaction.OrderId]
+		orders[i].Transactions = append(orders[i].Transactions, transaction.Transaction)
+	}
+
+	return nil
 }
 
 func (r *OrderRepository) GetById(id int) (jewerly.Order, error) {
 	var order jewerly.Order
 
-	selectOrdersQuery := fmt.Sprintf(`SELECT id, ordered_at, first_name, last_name, additional_name, country,
-										address, email, postal_code, total_cost FROM %s WHERE id=$1`, ordersTable)
+	selectOrdersQuery := fmt.Sprintf(`SELECT %s FROM %s WHERE id=$1`, orderColumns, ordersTable)
 	err := r.db.Get(&order, selectOrdersQuery, id)
+	if err == sql.ErrNoRows {
+		return order, jewerly.ErrOrderNotFound
+	}
 	if err != nil {
 		logrus.Errorf("failed to get orders: %s", err.Error())
 		return order, err
 	}
 
-	selectOrderItemsQuery := fmt.Sprintf("SELECT product_id, quantity FROM %s WHERE order_id = $1", orderItemsTable)
+	selectOrderItemsQuery := fmt.Sprintf("SELECT product_id, quantity, variant_id FROM %s WHERE order_id = $1 ORDER BY id", orderItemsTable)
 	err = r.db.Select(&order.Items, selectOrderItemsQuery, id)
 	if err != nil {
 		logrus.Errorf("failed to get order items for order id %d, error: %s", id, err.Error())
@@ -154,22 +307,607 @@ func (r *OrderRepository) GetById(id int) (jewerly.Order, error) {
 	}
 
 	selectTransactionsQuery := fmt.Sprintf(`SELECT th.uuid, th.created_at, th.status, th.card_mask FROM %s th 
-											INNER JOIN %s t on t.uuid = th.uuid WHERE t.order_id = $1`, transactionsHistoryTable, transactionsTable)
+											INNER JOIN %s t on t.uuid = th.uuid WHERE t.order_id = $1 ORDER BY th.id`, transactionsHistoryTable, transactionsTable)
 	err = r.db.Select(&order.Transactions, selectTransactionsQuery, id)
 	if err != nil {
 		logrus.Errorf("failed to get transactions for order id %d, error: %s", id, err.Error())
 		return order, err
 	}
 
-	return order, nil
+	selectStatusHistoryQuery := fmt.Sprintf("SELECT status, changed_by, created_at FROM %s WHERE order_id = $1 ORDER BY id", orderStatusHistoryTable)
+	err = r.db.Select(&order.StatusHistory, selectStatusHistoryQuery, id)
+	if err != nil {
+		logrus.Errorf("failed to get status history for order id %d, error: %s", id, err.Error())
+		return order, err
+	}
+
+	order.Refunds, err = r.getRefunds(id)
+
+	return order, err
+}
+
+type refundItemRow struct {
+	RefundId int `db:"refund_id"`
+	jewerly.OrderItem
+}
+
+func (r *OrderRepository) getRefunds(orderId int) ([]jewerly.Refund, error) {
+	var refunds []jewerly.Refund
+	err := r.db.Select(&refunds, fmt.Sprintf(`SELECT id, order_id, amount, reason, full_refund, status, provider_refund_id, created_by, created_at FROM %s
+												WHERE order_id = $1 ORDER BY id`, refundsTable), orderId)
+	if err != nil {
+		logrus.Errorf("failed to get refunds for order id %d, error: %s", orderId, err.Error())
+		return nil, err
+	}
+
+	if len(refunds) == 0 {
+		return refunds, nil
+	}
+
+	var items []refundItemRow
+	err = r.db.Select(&items, fmt.Sprintf(`SELECT ri.refund_id, ri.product_id, ri.quantity, ri.variant_id FROM %s ri
+											INNER JOIN %s rf ON rf.id = ri.refund_id WHERE rf.order_id = $1 ORDER BY ri.id`,
+		refundItemsTable, refundsTable), orderId)
+	if err != nil {
+		logrus.Errorf("failed to get refund items for order id %d, error: %s", orderId, err.Error())
+		return nil, err
+	}
+
+	positions := make(map[int]int, len(refunds))
+	for i := range refunds {
+		positions[refunds[i].Id] = i
+	}
+
+	for _, item := range items {
+		i := positions[item.RefundId]
+		refunds[i].Items = append(refunds[i].Items, item.OrderItem)
+	}
+
+	return refunds, nil
+}
+
+// ReserveRefund saves the pending refund with its items before the payment provider is called. Refunded is the sum
+// of the order refunds the refund is calculated from, ErrRefundConflict is returned when another refund changed it.
+func (r *OrderRepository) ReserveRefund(refund jewerly.Refund, refunded jewerly.Money) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := r.lockOrderStatus(tx, refund.OrderId); err != nil {
+		return 0, err
+	}
+
+	var currentRefunded jewerly.Money
+	err = tx.QueryRow(fmt.Sprintf("SELECT COALESCE(sum(amount), 0) FROM %s WHERE order_id = $1", refundsTable), refund.OrderId).
+		Scan(&currentRefunded)
+	if err != nil {
+		logrus.Errorf("failed to get refunded sum: %s", err.Error())
+		tx.Rollback()
+		return 0, err
+	}
+
+	if currentRefunded != refunded {
+		tx.Rollback()
+		return 0, jewerly.ErrRefundConflict
+	}
+
+	var refundId int
+	err = tx.QueryRow(fmt.Sprintf(`INSERT INTO %s (order_id, amount, reason, full_refund, created_by, status) VALUES ($1, $2, $3, $4, $5, $6)
+									RETURNING id`, refundsTable),
+		refund.OrderId, refund.Amount, refund.Reason, refund.Full, refund.CreatedBy, jewerly.RefundStatusPending).Scan(&refundId)
+	if err != nil {
+		logrus.Errorf("failed to create refund: %s", err.Error())
+		tx.Rollback()
+		return 0, err
+	}
+
+	if len(refund.Items) > 0 {
+		if err := r.createRefundItems(tx, refundId, refund.Items); err != nil {
+			return 0, err
+		}
+	}
+
+	return refundId, tx.Commit()
+}
+
+// CompleteRefund marks the pending refund made in the payment provider completed, returns refunded items to stock
+// and records the refund in the transaction history with the provider refund id as the event id.
+// Full refund moves the order to refunded status.
+func (r *OrderRepository) CompleteRefund(refund jewerly.Refund, transactionId string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	currentStatus, err := r.lockOrderStatus(tx, refund.OrderId)
+	if err != nil {
+		return err
+	}
+
+	// refund is completed once, even if the admin retries the completion concurrently
+	res, err := tx.Exec(fmt.Sprintf("UPDATE %s SET status = $1, provider_refund_id = $2 WHERE id = $3 AND status = $4", refundsTable),
+		jewerly.RefundStatusCompleted, refund.ProviderRefundId, refund.Id, jewerly.RefundStatusPending)
+	if err != nil {
+		logrus.Errorf("failed to complete refund: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		tx.Rollback()
+		return jewerly.ErrRefundNotFound
+	}
+
+	if len(refund.Items) > 0 {
+		if err := r.restock(tx, refund.Items); err != nil {
+			return err
+		}
+	}
+
+	status := "partial-refund"
+	if refund.Full {
+		status = "refund"
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("INSERT INTO %s (uuid, event_id, status, refund_id) VALUES ($1, $2, $3, $4)", transactionsHistoryTable),
+		transactionId, refund.ProviderRefundId, status, refund.Id)
+	if err != nil {
+		logrus.Errorf("failed to insert refund transaction history record: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	if refund.Full {
+		if err := r.changeStatus(tx, refund.OrderId, currentStatus, jewerly.OrderStatusRefunded, refund.CreatedBy); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CancelRefund removes the pending refund, that wasn't made by the payment provider, with its items.
+func (r *OrderRepository) CancelRefund(refundId int) error {
+	_, err := r.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND status = $2", refundsTable), refundId, jewerly.RefundStatusPending)
+	return err
+}
+
+func (r *OrderRepository) createRefundItems(tx *sql.Tx, refundId int, refundItems []jewerly.OrderItem) error {
+	items := []string{}
+	values := []interface{}{}
+	values = append(values, refundId)
+	argId := 2
+
+	for _, item := range refundItems {
+		values = append(values, item.ProductId, item.Quantity, item.VariantId)
+		items = append(items, fmt.Sprintf("($1, $%d, $%d, $%d)", argId, argId+1, argId+2))
+
+		argId += 3
+	}
+
+	_, err := tx.Exec(fmt.Sprintf("INSERT INTO %s (refund_id, product_id, quantity, variant_id) VALUES %s", refundItemsTable,
+		strings.Join(items, ",")), values...)
+	if err != nil {
+		logrus.Errorf("failed to create refund items: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// restock returns refunded items to the product and variant stock, rows are locked in the same order as on reservation.
+func (r *OrderRepository) restock(tx *sql.Tx, refundItems []jewerly.OrderItem) error {
+	items := make([]jewerly.OrderItem, len(refundItems))
+	copy(items, refundItems)
+	sort.Slice(items, func(i, j int) bool { return items[i].ProductId < items[j].ProductId })
+
+	for _, item := range items {
+		_, err := tx.Exec(fmt.Sprintf("UPDATE %s SET stock = stock + $1 WHERE id = $2", productsTable), item.Quantity, item.ProductId)
+		if err != nil {
+			logrus.Errorf("failed to restock refunded product: %s", err.Error())
+			tx.Rollback()
+			return err
+		}
+
+		if !item.VariantId.Valid {
+			continue
+		}
+
+		_, err = tx.Exec(fmt.Sprintf("UPDATE %s SET stock = stock + $1 WHERE id = $2", productVariantsTable), item.Quantity, item.VariantId)
+		if err != nil {
+			logrus.Errorf("failed to restock refunded variant: %s", err.Error())
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return nil
+}
+
+type customerOrderItemRow struct {
+	OrderId int `db:"order_id"`
+	jewerly.CustomerOrderItem
+}
+
+// GetItemsDetails returns items of the orders with product titles in the given language, prices and images.
+// Items of orders created before prices were saved with them have current product prices.
+func (r *OrderRepository) GetItemsDetails(orderIds []int, language string) (map[int][]jewerly.CustomerOrderItem, error) {
+	var rows []customerOrderItemRow
+
+	query := fmt.Sprintf(`SELECT oi.order_id, oi.product_id, oi.quantity, t.%[1]s as title,
+							COALESCE(oi.price, p.price + COALESCE(v.price_delta, 0)) as price,
+							oi.variant_id, v.option_type, v.option_value FROM %[2]s oi
+							JOIN %[3]s p on p.id = oi.product_id
+							JOIN %[4]s t on t.id = p.title_id
+							LEFT JOIN %[5]s v on v.id = oi.variant_id WHERE oi.order_id = ANY($1) ORDER BY oi.id`,
+		language, orderItemsTable, productsTable, titlesTable, productVariantsTable)
+	err := r.db.Select(&rows, query, pq.Array(orderIds))
+	if err != nil {
+		logrus.Errorf("failed to get order items details: %s", err.Error())
+		return nil, err
+	}
+
+	ids := make([]int, len(rows))
+	for i := range rows {
+		ids[i] = rows[i].ProductId
+	}
+
+	images, err := selectProductsImages(r.db, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(map[int][]jewerly.CustomerOrderItem, len(orderIds))
+	for _, row := range rows {
+		row.Images = images[row.ProductId]
+		items[row.OrderId] = append(items[row.OrderId], row.CustomerOrderItem)
+	}
+
+	return items, nil
+}
+
+func (r *OrderRepository) UpdateStatus(orderId int, status, changedBy string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	currentStatus, err := r.lockOrderStatus(tx, orderId)
+	if err != nil {
+		return err
+	}
+
+	if err := r.changeStatus(tx, orderId, currentStatus, status, changedBy); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *OrderRepository) SetShipment(orderId int, inp jewerly.ShipOrderInput, changedBy string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	currentStatus, err := r.lockOrderStatus(tx, orderId)
+	if err != nil {
+		return err
+	}
+
+	// tracking info of an already shipped order can be corrected without changing its status
+	if currentStatus != jewerly.OrderStatusShipped {
+		if err := r.changeStatus(tx, orderId, currentStatus, jewerly.OrderStatusShipped, changedBy); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`UPDATE %s SET carrier=$1, tracking_number=$2, tracking_url=$3, shipped_at=COALESCE(shipped_at, NOW()) 
+									WHERE id=$4`, ordersTable),
+		inp.Carrier, inp.TrackingNumber, null.NewString(inp.TrackingURL, inp.TrackingURL != ""), orderId)
+	if err != nil {
+		logrus.Errorf("failed to update order shipment: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// lockOrderStatus locks the order row, so concurrent updates validate transition against the actual status.
+func (r *OrderRepository) lockOrderStatus(tx *sql.Tx, orderId int) (string, error) {
+	var status string
+	err := tx.QueryRow(fmt.Sprintf("SELECT status FROM %s WHERE id=$1 FOR UPDATE", ordersTable), orderId).Scan(&status)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return "", jewerly.ErrOrderNotFound
+		}
+		return "", err
+	}
+
+	return status, nil
+}
+
+func (r *OrderRepository) changeStatus(tx *sql.Tx, orderId int, currentStatus, status, changedBy string) error {
+	if err := jewerly.ValidateOrderStatusTransition(currentStatus, status); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err := tx.Exec(fmt.Sprintf("UPDATE %s SET status=$1, status_updated_at=NOW(), status_updated_by=$2 WHERE id=$3", ordersTable),
+		status, changedBy, orderId)
+	if err != nil {
+		logrus.Errorf("failed to update order status: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("INSERT INTO %s (order_id, status, changed_by) VALUES ($1, $2, $3)", orderStatusHistoryTable),
+		orderId, status, changedBy)
+	if err != nil {
+		logrus.Errorf("failed to insert order status history record: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	if status == jewerly.OrderStatusCancelled {
+		if err := r.releaseStock(tx, orderId); err != nil {
+			return err
+		}
+
+		return r.releasePromoCode(tx, orderId)
+	}
+
+	return nil
+}
+
+// CancelUnpaid cancels the order and releases reserved stock only if the order is still waiting for payment,
+// authorized payment isn't charged yet, so such order is cancelled as well.
+func (r *OrderRepository) CancelUnpaid(orderId int, changedBy string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	currentStatus, err := r.lockOrderStatus(tx, orderId)
+	if err != nil {
+		return err
+	}
+
+	if currentStatus != jewerly.OrderStatusNew && currentStatus != jewerly.OrderStatusAuthorized {
+		return tx.Rollback()
+	}
+
+	if err := r.changeStatus(tx, orderId, currentStatus, jewerly.OrderStatusCancelled, changedBy); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetUnpaidOrderIds returns new orders placed before the time, that didn't receive any of paidNotifyTypes callbacks.
+func (r *OrderRepository) GetUnpaidOrderIds(before time.Time, paidNotifyTypes []string) ([]int, error) {
+	var ids []int
+
+	query := fmt.Sprintf(`SELECT o.id FROM %[1]s o WHERE o.ordered_at < $1 AND o.status = $2
+							AND NOT EXISTS (SELECT 1 FROM %[2]s t JOIN %[3]s th ON th.uuid = t.uuid WHERE t.order_id = o.id AND th.status = ANY($3))
+							ORDER BY o.id`,
+		ordersTable, transactionsTable, transactionsHistoryTable)
+	err := r.db.Select(&ids, query, before, jewerly.OrderStatusNew, pq.Array(paidNotifyTypes))
+
+	return ids, err
+}
+
+// CompleteAuthorization records captured or voided payment of the authorized order and moves it to the status.
+// Provider callback can record the transaction status first, false is returned then, so the customer is notified once.
+// Event id of the provider action is saved, so its callback received later is skipped as a duplicate.
+// ErrOrderNotAuthorized is returned when the order is neither authorized nor already in the status.
+func (r *OrderRepository) CompleteAuthorization(orderId int, transactionId, eventId, cardMask, notifyType, status, changedBy string) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	currentStatus, err := r.lockOrderStatus(tx, orderId)
+	if err != nil {
+		return false, err
+	}
+
+	if currentStatus != jewerly.OrderStatusAuthorized && currentStatus != status {
+		tx.Rollback()
+		return false, jewerly.ErrOrderNotAuthorized
+	}
+
+	res, err := tx.Exec(fmt.Sprintf(`INSERT INTO %s (uuid, event_id, card_mask, status) VALUES ($1, $2, $3, $4)
+									ON CONFLICT DO NOTHING`, transactionsHistoryTable),
+		transactionId, null.NewString(eventId, eventId != ""), cardMask, notifyType)
+	if err != nil {
+		logrus.Errorf("failed to insert transaction history record: %s", err.Error())
+		tx.Rollback()
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if currentStatus != status {
+		if err := r.changeStatus(tx, orderId, currentStatus, status, changedBy); err != nil {
+			return false, err
+		}
+	}
+
+	return affected > 0, tx.Commit()
+}
+
+// GetStaleAuthorizedOrderIds returns orders which payment was authorized before the time and is neither captured nor voided.
+func (r *OrderRepository) GetStaleAuthorizedOrderIds(before time.Time) ([]int, error) {
+	var ids []int
+
+	err := r.db.Select(&ids, fmt.Sprintf("SELECT id FROM %s WHERE status = $1 AND status_updated_at < $2 ORDER BY id", ordersTable),
+		jewerly.OrderStatusAuthorized, before)
+
+	return ids, err
+}
+
+// reserveStock decreases stock of the ordered products and variants, the order is rejected if any of them doesn't have enough items.
+func (r *OrderRepository) reserveStock(tx *sql.Tx, orderItems []jewerly.OrderItem) error {
+	productQuantities, productIds := make(map[int]int), make([]int, 0, len(orderItems))
+	variantQuantities, variantIds := make(map[int]int), make([]int, 0)
+	variantProducts := make(map[int]int)
+
+	for _, item := range orderItems {
+		if item.MadeToOrder {
+			continue
+		}
+
+		if _, ex := productQuantities[item.ProductId]; !ex {
+			productIds = append(productIds, item.ProductId)
+		}
+		productQuantities[item.ProductId] += item.Quantity
+
+		if !item.VariantId.Valid {
+			continue
+		}
+
+		variantId := int(item.VariantId.Int64)
+		if _, ex := variantQuantities[variantId]; !ex {
+			variantIds = append(variantIds, variantId)
+		}
+		variantQuantities[variantId] += item.Quantity
+		variantProducts[variantId] = item.ProductId
+	}
+
+	// concurrent orders lock rows in the same order to avoid deadlocks
+	sort.Ints(productIds)
+	sort.Ints(variantIds)
+
+	for _, id := range productIds {
+		err := decreaseStock(tx, fmt.Sprintf("UPDATE %s SET stock = stock - $1 WHERE id = $2 AND stock >= $1", productsTable),
+			productQuantities[id], id)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, id := range variantIds {
+		err := decreaseStock(tx, fmt.Sprintf("UPDATE %s SET stock = stock - $1 WHERE id = $2 AND product_id = $3 AND stock >= $1", productVariantsTable),
+			variantQuantities[id], id, variantProducts[id])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decreaseStock(tx *sql.Tx, query string, args ...interface{}) error {
+	res, err := tx.Exec(query, args...)
+	if err != nil {
+		logrus.Errorf("failed to reserve stock: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affected == 0 {
+		tx.Rollback()
+		return jewerly.ErrInsufficientStock
+	}
+
+	return nil
+}
+
+func (r *OrderRepository) releaseStock(tx *sql.Tx, orderId int) error {
+	_, err := tx.Exec(fmt.Sprintf(`UPDATE %s p SET stock = p.stock + oi.quantity
+									FROM (SELECT product_id, sum(quantity) as quantity FROM %s WHERE order_id = $1 AND NOT made_to_order GROUP BY product_id) oi
+									WHERE p.id = oi.product_id`, productsTable, orderItemsTable), orderId)
+	if err != nil {
+		logrus.Errorf("failed to release order stock: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`UPDATE %s v SET stock = v.stock + oi.quantity
+									FROM (SELECT variant_id, sum(quantity) as quantity FROM %s WHERE order_id = $1 AND variant_id IS NOT NULL AND NOT made_to_order
+											GROUP BY variant_id) oi
+									WHERE v.id = oi.variant_id`, productVariantsTable, orderItemsTable), orderId)
+	if err != nil {
+		logrus.Errorf("failed to release order variants stock: %s", err.Error())
+		tx.Rollback()
+	}
+
+	return err
+}
+
+// usePromoCode records the promo code usage, limits are checked again under the promo code row lock
+// so concurrent orders can't exceed them.
+func (r *OrderRepository) usePromoCode(tx *sql.Tx, orderId int, input jewerly.CreateOrderInput) error {
+	_, err := tx.Exec(fmt.Sprintf("SELECT id FROM %s WHERE id=$1 FOR UPDATE", promoCodesTable), input.Discount.PromoCodeId)
+	if err != nil {
+		logrus.Errorf("failed to lock promo code: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	res, err := tx.Exec(fmt.Sprintf(`INSERT INTO %[1]s (promo_code_id, order_id, email) SELECT pc.id, $2, $3 FROM %[2]s pc WHERE pc.id = $1
+							AND (pc.usage_limit IS NULL OR (SELECT count(*) FROM %[1]s WHERE promo_code_id = pc.id) < pc.usage_limit)
+							AND (pc.per_customer_limit IS NULL OR
+								(SELECT count(*) FROM %[1]s WHERE promo_code_id = pc.id AND email = $3) < pc.per_customer_limit)`,
+		promoCodeUsagesTable, promoCodesTable), input.Discount.PromoCodeId, orderId, strings.ToLower(input.Email))
+	if err != nil {
+		logrus.Errorf("failed to create promo code usage: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affected == 0 {
+		tx.Rollback()
+		return jewerly.ErrPromoCodeUsageLimit
+	}
+
+	return nil
+}
+
+// releasePromoCode frees the usage of cancelled order, so it doesn't count towards promo code limits.
+func (r *OrderRepository) releasePromoCode(tx *sql.Tx, orderId int) error {
+	_, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE order_id=$1", promoCodeUsagesTable), orderId)
+	if err != nil {
+		logrus.Errorf("failed to release promo code usage: %s", err.Error())
+		tx.Rollback()
+	}
+
+	return err
 }
 
 func (r *OrderRepository) createOrder(tx *sql.Tx, input jewerly.CreateOrderInput) (int, error) {
 	var orderId int
-	createOrderQuery := fmt.Sprintf(`INSERT INTO %s (first_name, last_name, additional_name, country, address, postal_code, email, total_cost)
-									VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`, ordersTable)
+	createOrderQuery := fmt.Sprintf(`INSERT INTO %s (first_name, last_name, additional_name, country, address, postal_code, email, total_cost, user_id,
+									promo_code, discount, free_shipping, currency, exchange_rate, shipping_method_id, shipping_method, shipping_cost,
+									net_amount, tax_amount, tax_rate, tax_inclusive)
+									VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21) RETURNING id`, ordersTable)
 	row := tx.QueryRow(createOrderQuery, input.FirstName, input.LastName, input.AdditionalName, input.Country, input.Address,
-		input.PostalCode, input.Email, input.TotalCost)
+		input.PostalCode, input.Email, input.TotalCost, input.UserId, null.NewString(input.Discount.Code, input.Discount.Code != ""),
+		input.Discount.Amount, input.Discount.FreeShipping, input.Currency, input.ExchangeRate,
+		null.NewInt(int64(input.Shipping.MethodId), input.Shipping.MethodId != 0),
+		null.NewString(input.Shipping.Method, input.Shipping.Method != ""), input.Shipping.Cost,
+		input.Tax.Net, input.Tax.Tax, input.Tax.Rate, input.Tax.Inclusive)
 	err := row.Scan(&orderId)
 	if err != nil {
 		logrus.Errorf("failed to create new order: %s", err.Error())
@@ -187,13 +925,14 @@ func (r *OrderRepository) createOrderItems(tx *sql.Tx, orderId int, orderItems [
 	argId := 2
 
 	for _, item := range orderItems {
-		values = append(values, item.ProductId, item.Quantity)
-		items = append(items, fmt.Sprintf("($1, $%d, $%d)", argId, argId+1))
+		values = append(values, item.ProductId, item.Quantity, item.VariantId, item.Price, item.MadeToOrder)
+		items = append(items, fmt.Sprintf("($1, $%d, $%d, $%d, $%d, $%d)", argId, argId+1, argId+2, argId+3, argId+4))
 
-		argId += 2
+		argId += 5
 	}
 
-	createOrderItemsQuery := fmt.Sprintf("INSERT INTO %s (order_id, product_id, quantity) VALUES %s", orderItemsTable, strings.Join(items, ","))
+	createOrderItemsQuery := fmt.Sprintf("INSERT INTO %s (order_id, product_id, quantity, variant_id, price, made_to_order) VALUES %s",
+		orderItemsTable, strings.Join(items, ","))
 
 	_, err := tx.Exec(createOrderItemsQuery, values...)
 	if err != nil {
@@ -1,12 +1,15 @@
 package postgres
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	sqlmock "github.com/zhashkevych/go-sqlxmock"
 	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"gopkg.in/guregu/null.v3"
 	"testing"
+	"time"
 )
 
 func TestOrderRepository_Create(t *testing.T) {
@@ -18,6 +21,18 @@ func TestOrderRepository_Create(t *testing.T) {
 
 	type mockBehavior func(input jewerly.CreateOrderInput, orderId int)
 
+	// items of the test orders have different products sorted by id
+	expectReserveStock := func(items []jewerly.OrderItem) {
+		for _, item := range items {
+			if item.MadeToOrder {
+				continue
+			}
+
+			mock.ExpectExec("UPDATE products SET stock = stock - \\$1 WHERE id = \\$2 AND stock >= \\$1").
+				WithArgs(item.Quantity, item.ProductId).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+	}
+
 	testTable := []struct {
 		name         string
 		input        jewerly.CreateOrderInput
@@ -29,8 +44,8 @@ func TestOrderRepository_Create(t *testing.T) {
 			name: "OK",
 			input: jewerly.CreateOrderInput{
 				Items: []jewerly.OrderItem{
-					{1, 3},
-					{18, 5},
+					{ProductId: 1, Quantity: 3},
+					{ProductId: 18, Quantity: 5},
 				},
 				FirstName:      "Test",
 				LastName:       "Test",
@@ -41,18 +56,23 @@ func TestOrderRepository_Create(t *testing.T) {
 				Address:        "Kreshatyk st.",
 				PostalCode:     "32012",
 				TransactionID:  "1111-2222-3333-4444-asdas",
+				Shipping:       jewerly.OrderShipping{MethodId: 2, Method: "Express", Cost: 1500},
+				Tax:            jewerly.OrderTax{Rate: 17, Inclusive: true, Net: 1282, Tax: 218, Gross: 1500},
 			},
 			orderId: 42,
 			mockBehavior: func(input jewerly.CreateOrderInput, orderId int) {
 				mock.ExpectBegin()
+				expectReserveStock(input.Items)
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(orderId)
 				mock.ExpectQuery("INSERT INTO orders").WithArgs(input.FirstName, input.LastName, input.AdditionalName, input.Country, input.Address,
-					input.PostalCode, input.Email, input.TotalCost).WillReturnRows(rows)
+					input.PostalCode, input.Email, input.TotalCost, input.UserId, nil, input.Discount.Amount, input.Discount.FreeShipping, input.Currency, input.ExchangeRate,
+					input.Shipping.MethodId, input.Shipping.Method, input.Shipping.Cost,
+					input.Tax.Net, input.Tax.Tax, input.Tax.Rate, input.Tax.Inclusive).WillReturnRows(rows)
 
 				args := []driver.Value{orderId}
 				for _, item := range input.Items {
-					args = append(args, item.ProductId, item.Quantity)
+					args = append(args, item.ProductId, item.Quantity, item.VariantId, item.Price, item.MadeToOrder)
 				}
 				mock.ExpectExec("INSERT INTO order_items").WithArgs(args...).WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -65,12 +85,51 @@ func TestOrderRepository_Create(t *testing.T) {
 				mock.ExpectCommit()
 			},
 		},
+		{
+			name: "OK - Made To Order",
+			input: jewerly.CreateOrderInput{
+				Items: []jewerly.OrderItem{
+					{ProductId: 1, Quantity: 3},
+					{ProductId: 18, Quantity: 2, MadeToOrder: true},
+				},
+				FirstName:     "Test",
+				LastName:      "Test",
+				Email:         "test@test.com",
+				Country:       "UA",
+				Address:       "Kreshatyk st.",
+				PostalCode:    "32012",
+				TransactionID: "1111-2222-3333-4444-asdas",
+			},
+			orderId: 42,
+			mockBehavior: func(input jewerly.CreateOrderInput, orderId int) {
+				mock.ExpectBegin()
+
+				// made to order product is produced after the order, its stock isn't reserved
+				mock.ExpectExec("UPDATE products SET stock = stock - \\$1 WHERE id = \\$2 AND stock >= \\$1").
+					WithArgs(3, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(orderId)
+				mock.ExpectQuery("INSERT INTO orders").WillReturnRows(rows)
+
+				mock.ExpectExec("INSERT INTO order_items \\(order_id, product_id, quantity, variant_id, price, made_to_order\\)").
+					WithArgs(orderId, 1, 3, nil, input.Items[0].Price, false, 18, 2, nil, input.Items[1].Price, true).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec("INSERT INTO transactions").WithArgs(orderId, input.TransactionID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec("INSERT INTO transactions_history").WithArgs(input.TransactionID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectCommit()
+			},
+		},
 		{
 			name: "Insert Order Error",
 			input: jewerly.CreateOrderInput{
 				Items: []jewerly.OrderItem{
-					{1, 3},
-					{18, 5},
+					{ProductId: 1, Quantity: 3},
+					{ProductId: 18, Quantity: 5},
 				},
 				FirstName:      "Test",
 				LastName:       "Test",
@@ -85,10 +144,13 @@ func TestOrderRepository_Create(t *testing.T) {
 			orderId: 42,
 			mockBehavior: func(input jewerly.CreateOrderInput, orderId int) {
 				mock.ExpectBegin()
+				expectReserveStock(input.Items)
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(orderId).CloseError(errors.New("fail"))
 				mock.ExpectQuery("INSERT INTO orders").WithArgs(input.FirstName, input.LastName, input.AdditionalName, input.Country, input.Address,
-					input.PostalCode, input.Email, input.TotalCost).WillReturnRows(rows)
+					input.PostalCode, input.Email, input.TotalCost, input.UserId, nil, input.Discount.Amount, input.Discount.FreeShipping, input.Currency, input.ExchangeRate,
+					nil, nil, input.Shipping.Cost,
+					input.Tax.Net, input.Tax.Tax, input.Tax.Rate, input.Tax.Inclusive).WillReturnRows(rows)
 
 				mock.ExpectRollback()
 			},
@@ -98,8 +160,8 @@ func TestOrderRepository_Create(t *testing.T) {
 			name: "Insert Order Items Fail",
 			input: jewerly.CreateOrderInput{
 				Items: []jewerly.OrderItem{
-					{1, 3},
-					{18, 5},
+					{ProductId: 1, Quantity: 3},
+					{ProductId: 18, Quantity: 5},
 				},
 				FirstName:      "Test",
 				LastName:       "Test",
@@ -114,14 +176,17 @@ func TestOrderRepository_Create(t *testing.T) {
 			orderId: 42,
 			mockBehavior: func(input jewerly.CreateOrderInput, orderId int) {
 				mock.ExpectBegin()
+				expectReserveStock(input.Items)
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(orderId)
 				mock.ExpectQuery("INSERT INTO orders").WithArgs(input.FirstName, input.LastName, input.AdditionalName, input.Country, input.Address,
-					input.PostalCode, input.Email, input.TotalCost).WillReturnRows(rows)
+					input.PostalCode, input.Email, input.TotalCost, input.UserId, nil, input.Discount.Amount, input.Discount.FreeShipping, input.Currency, input.ExchangeRate,
+					nil, nil, input.Shipping.Cost,
+					input.Tax.Net, input.Tax.Tax, input.Tax.Rate, input.Tax.Inclusive).WillReturnRows(rows)
 
 				args := []driver.Value{orderId}
 				for _, item := range input.Items {
-					args = append(args, item.ProductId, item.Quantity)
+					args = append(args, item.ProductId, item.Quantity, item.VariantId, item.Price, item.MadeToOrder)
 				}
 				mock.ExpectExec("INSERT INTO order_items").WithArgs(args...).WillReturnError(errors.New("fail"))
 
@@ -133,8 +198,8 @@ func TestOrderRepository_Create(t *testing.T) {
 			name: "Insert Tranasction Fail",
 			input: jewerly.CreateOrderInput{
 				Items: []jewerly.OrderItem{
-					{1, 3},
-					{18, 5},
+					{ProductId: 1, Quantity: 3},
+					{ProductId: 18, Quantity: 5},
 				},
 				FirstName:      "Test",
 				LastName:       "Test",
@@ -149,14 +214,17 @@ func TestOrderRepository_Create(t *testing.T) {
 			orderId: 42,
 			mockBehavior: func(input jewerly.CreateOrderInput, orderId int) {
 				mock.ExpectBegin()
+				expectReserveStock(input.Items)
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(orderId)
 				mock.ExpectQuery("INSERT INTO orders").WithArgs(input.FirstName, input.LastName, input.AdditionalName, input.Country, input.Address,
-					input.PostalCode, input.Email, input.TotalCost).WillReturnRows(rows)
+					input.PostalCode, input.Email, input.TotalCost, input.UserId, nil, input.Discount.Amount, input.Discount.FreeShipping, input.Currency, input.ExchangeRate,
+					nil, nil, input.Shipping.Cost,
+					input.Tax.Net, input.Tax.Tax, input.Tax.Rate, input.Tax.Inclusive).WillReturnRows(rows)
 
 				args := []driver.Value{orderId}
 				for _, item := range input.Items {
-					args = append(args, item.ProductId, item.Quantity)
+					args = append(args, item.ProductId, item.Quantity, item.VariantId, item.Price, item.MadeToOrder)
 				}
 				mock.ExpectExec("INSERT INTO order_items").WithArgs(args...).WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -171,8 +239,8 @@ func TestOrderRepository_Create(t *testing.T) {
 			name: "Insert Transaction History Fail",
 			input: jewerly.CreateOrderInput{
 				Items: []jewerly.OrderItem{
-					{1, 3},
-					{18, 5},
+					{ProductId: 1, Quantity: 3},
+					{ProductId: 18, Quantity: 5},
 				},
 				FirstName:      "Test",
 				LastName:       "Test",
@@ -187,14 +255,17 @@ func TestOrderRepository_Create(t *testing.T) {
 			orderId: 42,
 			mockBehavior: func(input jewerly.CreateOrderInput, orderId int) {
 				mock.ExpectBegin()
+				expectReserveStock(input.Items)
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(orderId)
 				mock.ExpectQuery("INSERT INTO orders").WithArgs(input.FirstName, input.LastName, input.AdditionalName, input.Country, input.Address,
-					input.PostalCode, input.Email, input.TotalCost).WillReturnRows(rows)
+					input.PostalCode, input.Email, input.TotalCost, input.UserId, nil, input.Discount.Amount, input.Discount.FreeShipping, input.Currency, input.ExchangeRate,
+					nil, nil, input.Shipping.Cost,
+					input.Tax.Net, input.Tax.Tax, input.Tax.Rate, input.Tax.Inclusive).WillReturnRows(rows)
 
 				args := []driver.Value{orderId}
 				for _, item := range input.Items {
-					args = append(args, item.ProductId, item.Quantity)
+					args = append(args, item.ProductId, item.Quantity, item.VariantId, item.Price, item.MadeToOrder)
 				}
 				mock.ExpectExec("INSERT INTO order_items").WithArgs(args...).WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -208,6 +279,117 @@ func TestOrderRepository_Create(t *testing.T) {
 			},
 			shouldFail: true,
 		},
+		{
+			name: "OK With Promo Code",
+			input: jewerly.CreateOrderInput{
+				Items: []jewerly.OrderItem{
+					{ProductId: 1, Quantity: 3},
+				},
+				FirstName:     "Test",
+				LastName:      "Test",
+				Email:         "Test@test.com",
+				Country:       "UA",
+				Address:       "Kreshatyk st.",
+				PostalCode:    "32012",
+				TransactionID: "1111-2222-3333-4444-asdas",
+				TotalCost:     742500,
+				Discount:      jewerly.OrderDiscount{PromoCodeId: 7, Code: "SALE10", Amount: 82500},
+				Currency:      jewerly.CurrencyUAH,
+				ExchangeRate:  27.5,
+			},
+			orderId: 42,
+			mockBehavior: func(input jewerly.CreateOrderInput, orderId int) {
+				mock.ExpectBegin()
+				expectReserveStock(input.Items)
+
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(orderId)
+				mock.ExpectQuery("INSERT INTO orders").WithArgs(input.FirstName, input.LastName, input.AdditionalName, input.Country, input.Address,
+					input.PostalCode, input.Email, input.TotalCost, input.UserId, "SALE10", input.Discount.Amount, false, input.Currency, input.ExchangeRate,
+					nil, nil, input.Shipping.Cost,
+					input.Tax.Net, input.Tax.Tax, input.Tax.Rate, input.Tax.Inclusive).WillReturnRows(rows)
+
+				mock.ExpectExec("INSERT INTO order_items").WithArgs(orderId, 1, 3, input.Items[0].VariantId, input.Items[0].Price, false).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec("SELECT id FROM promo_codes WHERE id=\\$1 FOR UPDATE").WithArgs(7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO promo_code_usages (.+) SELECT pc.id, \\$2, \\$3 FROM promo_codes pc WHERE pc.id = \\$1").
+					WithArgs(7, orderId, "test@test.com").WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec("INSERT INTO transactions").WithArgs(orderId, input.TransactionID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec("INSERT INTO transactions_history").WithArgs(input.TransactionID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Promo Code Usage Limit Reached",
+			input: jewerly.CreateOrderInput{
+				Items: []jewerly.OrderItem{
+					{ProductId: 1, Quantity: 3},
+				},
+				FirstName:     "Test",
+				LastName:      "Test",
+				Email:         "test@test.com",
+				Country:       "UA",
+				Address:       "Kreshatyk st.",
+				PostalCode:    "32012",
+				TransactionID: "1111-2222-3333-4444-asdas",
+				TotalCost:     27000,
+				Discount:      jewerly.OrderDiscount{PromoCodeId: 7, Code: "SALE10", Amount: 3000},
+			},
+			orderId: 42,
+			mockBehavior: func(input jewerly.CreateOrderInput, orderId int) {
+				mock.ExpectBegin()
+				expectReserveStock(input.Items)
+
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(orderId)
+				mock.ExpectQuery("INSERT INTO orders").WithArgs(input.FirstName, input.LastName, input.AdditionalName, input.Country, input.Address,
+					input.PostalCode, input.Email, input.TotalCost, input.UserId, "SALE10", input.Discount.Amount, false, input.Currency, input.ExchangeRate,
+					nil, nil, input.Shipping.Cost,
+					input.Tax.Net, input.Tax.Tax, input.Tax.Rate, input.Tax.Inclusive).WillReturnRows(rows)
+
+				mock.ExpectExec("INSERT INTO order_items").WithArgs(orderId, 1, 3, input.Items[0].VariantId, input.Items[0].Price, false).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec("SELECT id FROM promo_codes WHERE id=\\$1 FOR UPDATE").WithArgs(7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO promo_code_usages").
+					WithArgs(7, orderId, input.Email).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectRollback()
+			},
+			shouldFail: true,
+		},
+		{
+			name: "Insufficient Stock",
+			input: jewerly.CreateOrderInput{
+				Items: []jewerly.OrderItem{
+					{ProductId: 18, Quantity: 5},
+					{ProductId: 1, Quantity: 1},
+					{ProductId: 1, Quantity: 2},
+				},
+				FirstName:     "Test",
+				LastName:      "Test",
+				Email:         "test@test.com",
+				Country:       "UA",
+				Address:       "Kreshatyk st.",
+				PostalCode:    "32012",
+				TransactionID: "1111-2222-3333-4444-asdas",
+			},
+			mockBehavior: func(input jewerly.CreateOrderInput, orderId int) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE products SET stock = stock - \\$1 WHERE id = \\$2 AND stock >= \\$1").
+					WithArgs(3, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE products SET stock = stock - \\$1 WHERE id = \\$2 AND stock >= \\$1").
+					WithArgs(5, 18).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			shouldFail: true,
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -225,4 +407,727 @@ func TestOrderRepository_Create(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+var orderRowColumns = []string{"id", "ordered_at", "first_name", "last_name", "additional_name", "country", "address", "email", "postal_code", "total_cost",
+	"status", "status_updated_at", "status_updated_by", "carrier", "tracking_number", "tracking_url", "shipped_at"}
+
+// expectGetAllOrders sets up the queries OrderRepository.GetAll is expected to run for a page of n orders.
+func expectGetAllOrders(mock sqlmock.Sqlmock, n int) {
+	orders := sqlmock.NewRows(orderRowColumns)
+	items := sqlmock.NewRows([]string{"order_id", "product_id", "quantity"})
+	transactions := sqlmock.NewRows([]string{"order_id", "uuid", "created_at", "status", "card_mask"})
+
+	for i := n; i > 0; i-- {
+		orders.AddRow(i, time.Time{}, "Test", "Test", "", "UA", "Kreshatyk st.", "test@test.com", "32012", 100,
+			"new", time.Time{}, nil, nil, nil, nil, nil)
+		items.AddRow(i, 1, 1).AddRow(i, 2, 3)
+		transactions.AddRow(i, "1111-2222-3333-4444", time.Time{}, "created", nil)
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM orders ORDER BY id DESC OFFSET \\$1 LIMIT \\$2").
+		WithArgs(0, n+1).WillReturnRows(orders)
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM orders").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(n))
+	mock.ExpectQuery("SELECT (.+) FROM order_items WHERE order_id = ANY\\(\\$1\\)").WillReturnRows(items)
+	mock.ExpectQuery("SELECT (.+) FROM transactions_history th (.+) WHERE t.order_id = ANY\\(\\$1\\)").WillReturnRows(transactions)
+}
+
+func TestOrderRepository_CreateTransaction(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewOrderRepository(db)
+
+	transactionId := "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11"
+
+	testTable := []struct {
+		name         string
+		status       string
+		mockBehavior func(status string)
+		wantErr      error
+	}{
+		{
+			name:   "Ok",
+			status: "sale-authorized",
+			mockBehavior: func(status string) {
+				mock.ExpectExec("INSERT INTO transactions_history \\(uuid, event_id, card_mask, status\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\) ON CONFLICT DO NOTHING").
+					WithArgs(transactionId, "evt_1", "4580****1234", status).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			// PayMe sends the same event id with every notify type of the sale
+			name:   "Ok - Same Event Id With Another Status",
+			status: "sale-complete",
+			mockBehavior: func(status string) {
+				mock.ExpectExec("INSERT INTO transactions_history (.+) ON CONFLICT DO NOTHING").
+					WithArgs(transactionId, "evt_1", "4580****1234", status).WillReturnResult(sqlmock.NewResult(2, 1))
+			},
+		},
+		{
+			name:   "Duplicate",
+			status: "sale-complete",
+			mockBehavior: func(status string) {
+				mock.ExpectExec("INSERT INTO transactions_history (.+) ON CONFLICT DO NOTHING").
+					WithArgs(transactionId, "evt_1", "4580****1234", status).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: jewerly.ErrDuplicateCallback,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.status)
+
+			err := r.CreateTransaction(transactionId, "evt_1", "4580****1234", testCase.status)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrderRepository_GetOrderId(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewOrderRepository(db)
+
+	mock.ExpectQuery("SELECT order_id FROM transactions WHERE uuid=\\$1").WithArgs("unknown").WillReturnError(sql.ErrNoRows)
+
+	_, err = r.GetOrderId("unknown")
+	assert.Equal(t, jewerly.ErrTransactionNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrderRepository_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	expectGetAllOrders(mock, 2)
+
+	r := NewOrderRepository(db)
+
+	got, err := r.GetAll(jewerly.GetAllOrdersFilters{Limit: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, 2, got.Total)
+	assert.Len(t, got.Data, 2)
+	for _, order := range got.Data {
+		assert.Equal(t, []jewerly.OrderItem{{ProductId: 1, Quantity: 1}, {ProductId: 2, Quantity: 3}}, order.Items)
+		assert.Len(t, order.Transactions, 1)
+	}
+}
+
+func TestOrderRepository_GetAll_User(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM orders WHERE user_id = \\$1 ORDER BY id DESC OFFSET \\$2 LIMIT \\$3").
+		WithArgs(7, 0, 3).WillReturnRows(sqlmock.NewRows(orderRowColumns))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM orders WHERE user_id = \\$1").
+		WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	r := NewOrderRepository(db)
+
+	got, err := r.GetAll(jewerly.GetAllOrdersFilters{Limit: 2, UserId: null.IntFrom(7)})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Empty(t, got.Data)
+}
+
+// BenchmarkOrderRepository_GetAll fails if a page of 100 orders takes more round trips than the expected 4 queries,
+// sqlmock rejects every query that isn't expected, so the count doesn't depend on the page size.
+func BenchmarkOrderRepository_GetAll(b *testing.B) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewOrderRepository(db)
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		expectGetAllOrders(mock, 100)
+		b.StartTimer()
+
+		if _, err := r.GetAll(jewerly.GetAllOrdersFilters{Limit: 100}); err != nil {
+			b.Fatal(err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestOrderRepository_UpdateStatus(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewOrderRepository(db)
+
+	type args struct {
+		orderId   int
+		status    string
+		changedBy string
+	}
+	type mockBehavior func(args args)
+
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		args         args
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			args: args{orderId: 1, status: jewerly.OrderStatusPacked, changedBy: "admin"},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders WHERE id=\\$1 FOR UPDATE").
+					WithArgs(args.orderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusPaid))
+				mock.ExpectExec("UPDATE orders SET status=\\$1, status_updated_at=NOW\\(\\), status_updated_by=\\$2 WHERE id=\\$3").
+					WithArgs(args.status, args.changedBy, args.orderId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO order_status_history").
+					WithArgs(args.orderId, args.status, args.changedBy).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Cancel Releases Stock",
+			args: args{orderId: 1, status: jewerly.OrderStatusCancelled, changedBy: "admin"},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders WHERE id=\\$1 FOR UPDATE").
+					WithArgs(args.orderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusPaid))
+				mock.ExpectExec("UPDATE orders SET status=\\$1").
+					WithArgs(args.status, args.changedBy, args.orderId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO order_status_history").
+					WithArgs(args.orderId, args.status, args.changedBy).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE products p SET stock = p.stock \\+ oi.quantity FROM \\(SELECT product_id, sum\\(quantity\\) (.+) FROM order_items WHERE order_id = \\$1 AND NOT made_to_order").
+					WithArgs(args.orderId).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE product_variants v SET stock = v.stock \\+ oi.quantity FROM \\(SELECT variant_id, sum\\(quantity\\) (.+) FROM order_items WHERE order_id = \\$1 AND variant_id IS NOT NULL AND NOT made_to_order").
+					WithArgs(args.orderId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM promo_code_usages WHERE order_id=\\$1").
+					WithArgs(args.orderId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Not Found",
+			args: args{orderId: 1, status: jewerly.OrderStatusPacked, changedBy: "admin"},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders").
+					WithArgs(args.orderId).WillReturnRows(sqlmock.NewRows([]string{"status"}))
+				mock.ExpectRollback()
+			},
+			wantErr: jewerly.ErrOrderNotFound,
+		},
+		{
+			name: "Invalid Transition",
+			args: args{orderId: 1, status: jewerly.OrderStatusShipped, changedBy: "admin"},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders").
+					WithArgs(args.orderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusNew))
+				mock.ExpectRollback()
+			},
+			wantErr: jewerly.ErrOrderStatusTransition,
+		},
+		{
+			name: "Failed Insert History",
+			args: args{orderId: 1, status: jewerly.OrderStatusPaid, changedBy: jewerly.StatusChangedByPayment},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders").
+					WithArgs(args.orderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusNew))
+				mock.ExpectExec("UPDATE orders").
+					WithArgs(args.status, args.changedBy, args.orderId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO order_status_history").
+					WithArgs(args.orderId, args.status, args.changedBy).WillReturnError(errors.New("insert error"))
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("insert error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.args)
+
+			err := r.UpdateStatus(testCase.args.orderId, testCase.args.status, testCase.args.changedBy)
+			if testCase.wantErr != nil {
+				assert.Equal(t, testCase.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrderRepository_CancelUnpaid(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewOrderRepository(db)
+
+	type mockBehavior func(orderId int)
+
+	testTable := []struct {
+		name         string
+		orderId      int
+		mockBehavior mockBehavior
+	}{
+		{
+			name:    "Ok",
+			orderId: 1,
+			mockBehavior: func(orderId int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders WHERE id=\\$1 FOR UPDATE").
+					WithArgs(orderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusNew))
+				mock.ExpectExec("UPDATE orders SET status=\\$1").
+					WithArgs(jewerly.OrderStatusCancelled, jewerly.StatusChangedByTimeout, orderId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO order_status_history").
+					WithArgs(orderId, jewerly.OrderStatusCancelled, jewerly.StatusChangedByTimeout).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE products p SET stock = p.stock \\+ oi.quantity").
+					WithArgs(orderId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE product_variants v SET stock = v.stock \\+ oi.quantity").
+					WithArgs(orderId).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM promo_code_usages").
+					WithArgs(orderId).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "Already Paid",
+			orderId: 1,
+			mockBehavior: func(orderId int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders WHERE id=\\$1 FOR UPDATE").
+					WithArgs(orderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusPaid))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.orderId)
+
+			err := r.CancelUnpaid(testCase.orderId, jewerly.StatusChangedByTimeout)
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrderRepository_SetShipment(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewOrderRepository(db)
+
+	type args struct {
+		orderId   int
+		input     jewerly.ShipOrderInput
+		changedBy string
+	}
+	type mockBehavior func(args args)
+
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		args         args
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			args: args{orderId: 1, input: jewerly.ShipOrderInput{Carrier: "DHL", TrackingNumber: "123"}, changedBy: "admin"},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders WHERE id=\\$1 FOR UPDATE").
+					WithArgs(args.orderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusPacked))
+				mock.ExpectExec("UPDATE orders SET status=\\$1").
+					WithArgs(jewerly.OrderStatusShipped, args.changedBy, args.orderId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO order_status_history").
+					WithArgs(args.orderId, jewerly.OrderStatusShipped, args.changedBy).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE orders SET carrier=\\$1, tracking_number=\\$2, tracking_url=\\$3").
+					WithArgs("DHL", "123", nil, args.orderId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Already Shipped",
+			args: args{orderId: 1, input: jewerly.ShipOrderInput{Carrier: "DHL", TrackingNumber: "123", TrackingURL: "https://dhl.com/123"},
+				changedBy: "admin"},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders").
+					WithArgs(args.orderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusShipped))
+				mock.ExpectExec("UPDATE orders SET carrier=\\$1").
+					WithArgs("DHL", "123", "https://dhl.com/123", args.orderId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Not Packed",
+			args: args{orderId: 1, input: jewerly.ShipOrderInput{Carrier: "DHL", TrackingNumber: "123"}, changedBy: "admin"},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders").
+					WithArgs(args.orderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusNew))
+				mock.ExpectRollback()
+			},
+			wantErr: jewerly.ErrOrderStatusTransition,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.args)
+
+			err := r.SetShipment(testCase.args.orderId, testCase.args.input, testCase.args.changedBy)
+			if testCase.wantErr != nil {
+				assert.Equal(t, testCase.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrderRepository_ReserveRefund(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewOrderRepository(db)
+
+	testTable := []struct {
+		name         string
+		refund       jewerly.Refund
+		refunded     jewerly.Money
+		mockBehavior func(refund jewerly.Refund)
+		want         int
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			refund: jewerly.Refund{OrderId: 1, Amount: 1050, Reason: "damaged", CreatedBy: "admin",
+				Items: []jewerly.OrderItem{{ProductId: 3, Quantity: 1, VariantId: null.IntFrom(7)}}},
+			refunded: 2000,
+			mockBehavior: func(refund jewerly.Refund) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders WHERE id=\\$1 FOR UPDATE").
+					WithArgs(refund.OrderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusDelivered))
+				mock.ExpectQuery("SELECT COALESCE\\(sum\\(amount\\), 0\\) FROM refunds WHERE order_id = \\$1").
+					WithArgs(refund.OrderId).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow([]byte("20.00")))
+				mock.ExpectQuery("INSERT INTO refunds \\(order_id, amount, reason, full_refund, created_by, status\\)").
+					WithArgs(refund.OrderId, refund.Amount, refund.Reason, false, refund.CreatedBy, jewerly.RefundStatusPending).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectExec("INSERT INTO refund_items \\(refund_id, product_id, quantity, variant_id\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\)").
+					WithArgs(5, 3, 1, null.IntFrom(7)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			want: 5,
+		},
+		{
+			name:     "Concurrent Refund",
+			refund:   jewerly.Refund{OrderId: 1, Amount: 26500, Reason: "cancelled", Full: true, CreatedBy: "admin"},
+			refunded: 0,
+			mockBehavior: func(refund jewerly.Refund) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders").
+					WithArgs(refund.OrderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusShipped))
+				mock.ExpectQuery("SELECT COALESCE\\(sum\\(amount\\), 0\\) FROM refunds").
+					WithArgs(refund.OrderId).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow([]byte("100.00")))
+				mock.ExpectRollback()
+			},
+			wantErr: jewerly.ErrRefundConflict,
+		},
+		{
+			name:   "Order Not Found",
+			refund: jewerly.Refund{OrderId: 1, Amount: 100, Reason: "damaged", CreatedBy: "admin"},
+			mockBehavior: func(refund jewerly.Refund) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders").WithArgs(refund.OrderId).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: jewerly.ErrOrderNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.refund)
+
+			got, err := r.ReserveRefund(testCase.refund, testCase.refunded)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.Equal(t, testCase.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrderRepository_CompleteRefund(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewOrderRepository(db)
+
+	transactionId := "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11"
+
+	testTable := []struct {
+		name         string
+		refund       jewerly.Refund
+		mockBehavior func(refund jewerly.Refund)
+		wantErr      error
+	}{
+		{
+			name: "Partial",
+			refund: jewerly.Refund{Id: 5, OrderId: 1, Amount: 1050, Reason: "damaged", CreatedBy: "admin", ProviderRefundId: null.StringFrom("rf_5"),
+				Items: []jewerly.OrderItem{{ProductId: 3, Quantity: 1, VariantId: null.IntFrom(7)}}},
+			mockBehavior: func(refund jewerly.Refund) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders WHERE id=\\$1 FOR UPDATE").
+					WithArgs(refund.OrderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusDelivered))
+				mock.ExpectExec("UPDATE refunds SET status = \\$1, provider_refund_id = \\$2 WHERE id = \\$3 AND status = \\$4").
+					WithArgs(jewerly.RefundStatusCompleted, refund.ProviderRefundId, refund.Id, jewerly.RefundStatusPending).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE products SET stock = stock \\+ \\$1 WHERE id = \\$2").
+					WithArgs(1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE product_variants SET stock = stock \\+ \\$1 WHERE id = \\$2").
+					WithArgs(1, null.IntFrom(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO transactions_history \\(uuid, event_id, status, refund_id\\)").
+					WithArgs(transactionId, refund.ProviderRefundId, "partial-refund", refund.Id).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Full",
+			refund: jewerly.Refund{Id: 6, OrderId: 1, Amount: 26500, Reason: "cancelled", Full: true, CreatedBy: "admin", ProviderRefundId: null.StringFrom("rf_6")},
+			mockBehavior: func(refund jewerly.Refund) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders").
+					WithArgs(refund.OrderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusShipped))
+				mock.ExpectExec("UPDATE refunds SET status").
+					WithArgs(jewerly.RefundStatusCompleted, refund.ProviderRefundId, refund.Id, jewerly.RefundStatusPending).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO transactions_history").
+					WithArgs(transactionId, refund.ProviderRefundId, "refund", refund.Id).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE orders SET status=\\$1").
+					WithArgs(jewerly.OrderStatusRefunded, refund.CreatedBy, refund.OrderId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO order_status_history").
+					WithArgs(refund.OrderId, jewerly.OrderStatusRefunded, refund.CreatedBy).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Restock Error",
+			refund: jewerly.Refund{Id: 7, OrderId: 1, Amount: 100, Reason: "damaged", CreatedBy: "admin", Items: []jewerly.OrderItem{{ProductId: 3, Quantity: 2}}},
+			mockBehavior: func(refund jewerly.Refund) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders").
+					WithArgs(refund.OrderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusPaid))
+				mock.ExpectExec("UPDATE refunds SET status").
+					WithArgs(jewerly.RefundStatusCompleted, refund.ProviderRefundId, refund.Id, jewerly.RefundStatusPending).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE products SET stock").WithArgs(2, 3).WillReturnError(errors.New("fail"))
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("fail"),
+		},
+		{
+			name:   "Already Completed",
+			refund: jewerly.Refund{Id: 8, OrderId: 1, Amount: 100, Reason: "damaged", CreatedBy: "admin", Items: []jewerly.OrderItem{{ProductId: 3, Quantity: 2}}},
+			mockBehavior: func(refund jewerly.Refund) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders").
+					WithArgs(refund.OrderId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusPaid))
+				mock.ExpectExec("UPDATE refunds SET status").
+					WithArgs(jewerly.RefundStatusCompleted, refund.ProviderRefundId, refund.Id, jewerly.RefundStatusPending).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: jewerly.ErrRefundNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.refund)
+
+			err := r.CompleteRefund(testCase.refund, transactionId)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrderRepository_CompleteAuthorization(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewOrderRepository(db)
+
+	transactionId := "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11"
+
+	type args struct {
+		eventId    null.String
+		notifyType string
+		status     string
+	}
+
+	testTable := []struct {
+		name         string
+		args         args
+		mockBehavior func(args args)
+		want         bool
+		wantErr      error
+	}{
+		{
+			name: "Captured",
+			args: args{eventId: null.StringFrom("evt_1"), notifyType: "sale-complete", status: jewerly.OrderStatusPaid},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders WHERE id=\\$1 FOR UPDATE").
+					WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusAuthorized))
+				mock.ExpectExec("INSERT INTO transactions_history \\(uuid, event_id, card_mask, status\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\)\\s+ON CONFLICT DO NOTHING").
+					WithArgs(transactionId, args.eventId, "458045******4580", args.notifyType).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE orders SET status=\\$1").
+					WithArgs(args.status, "admin", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO order_status_history").
+					WithArgs(1, args.status, "admin").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			want: true,
+		},
+		{
+			name: "Callback Processed First",
+			args: args{eventId: null.StringFrom("evt_1"), notifyType: "sale-complete", status: jewerly.OrderStatusPaid},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders").
+					WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusPaid))
+				mock.ExpectExec("INSERT INTO transactions_history").
+					WithArgs(transactionId, args.eventId, "458045******4580", args.notifyType).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			want: false,
+		},
+		{
+			name: "Voided",
+			args: args{notifyType: "sale-void", status: jewerly.OrderStatusCancelled},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders").
+					WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusAuthorized))
+				mock.ExpectExec("INSERT INTO transactions_history").
+					WithArgs(transactionId, args.eventId, "458045******4580", args.notifyType).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE orders SET status=\\$1").
+					WithArgs(args.status, "admin", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO order_status_history").
+					WithArgs(1, args.status, "admin").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE products p SET stock = p.stock \\+ oi.quantity").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE product_variants v SET stock = v.stock \\+ oi.quantity").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM promo_code_usages").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			want: true,
+		},
+		{
+			name: "Not Authorized",
+			args: args{notifyType: "sale-complete", status: jewerly.OrderStatusPaid},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status FROM orders").
+					WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(jewerly.OrderStatusCancelled))
+				mock.ExpectRollback()
+			},
+			wantErr: jewerly.ErrOrderNotAuthorized,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.args)
+
+			got, err := r.CompleteAuthorization(1, transactionId, testCase.args.eventId.String, "458045******4580", testCase.args.notifyType,
+				testCase.args.status, "admin")
+			if testCase.wantErr != nil {
+				assert.Equal(t, testCase.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrderRepository_GetItemsDetails(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewOrderRepository(db)
+
+	mock.ExpectQuery("SELECT oi.order_id, oi.product_id, oi.quantity, t.russian as title,\\s+COALESCE\\(oi.price, p.price \\+ COALESCE\\(v.price_delta, 0\\)\\) as price,\\s+" +
+		"oi.variant_id, v.option_type, v.option_value FROM order_items oi (.+) WHERE oi.order_id = ANY\\(\\$1\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"order_id", "product_id", "quantity", "title", "price", "variant_id", "option_type", "option_value"}).
+			AddRow(1, 1, 2, "Кольцо", 100, nil, nil, nil).AddRow(1, 2, 1, "Серьги", 50, nil, nil, nil).
+			AddRow(2, 1, 1, "Кольцо", 120, 3, "size", "18"))
+	mock.ExpectQuery("SELECT pi.product_id, i.id, i.url, i.alt_text FROM images i (.+) WHERE pi.product_id = ANY\\(\\$1\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "id", "url", "alt_text"}).
+			AddRow(1, 10, "https://images/1.png", nil))
+
+	got, err := r.GetItemsDetails([]int{1, 2}, jewerly.Russian)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	images := []jewerly.Image{{Id: 10, URL: "https://images/1.png"}}
+	assert.Equal(t, map[int][]jewerly.CustomerOrderItem{
+		1: {
+			{ProductId: 1, Title: "Кольцо", Price: 10000, Quantity: 2, Images: images},
+			{ProductId: 2, Title: "Серьги", Price: 5000, Quantity: 1},
+		},
+		2: {
+			{ProductId: 1, Title: "Кольцо", Price: 12000, Quantity: 1, VariantId: null.IntFrom(3),
+				VariantOption: null.StringFrom("size"), VariantValue: null.StringFrom("18"), Images: images},
+		},
+	}, got)
+}
@@ -6,20 +6,38 @@ import (
 )
 
 const (
-	titlesTable              = "titles"
-	descriptionsTable        = "descriptions"
-	materialsTable           = "materials"
-	imagesTable              = "images"
-	productsTable            = "products"
-	productImagesTable       = "product_images"
-	ordersTable              = "orders"
-	orderItemsTable          = "order_items"
-	transactionsTable        = "transactions"
-	transactionsHistoryTable = "transactions_history"
-	adminUsersTable          = "admin_users"
-	homepageImagesTable      = "homepage_images"
-	textBlocksTable          = "text_blocks"
-	multiLanguageTextTable   = "multilanguage_text"
+	titlesTable               = "titles"
+	descriptionsTable         = "descriptions"
+	materialsTable            = "materials"
+	imagesTable               = "images"
+	productsTable             = "products"
+	productImagesTable        = "product_images"
+	ordersTable               = "orders"
+	orderItemsTable           = "order_items"
+	orderStatusHistoryTable   = "order_status_history"
+	transactionsTable         = "transactions"
+	transactionsHistoryTable  = "transactions_history"
+	adminUsersTable           = "admin_users"
+	usersTable                = "users"
+	userTokensTable           = "user_tokens"
+	cartsTable                = "carts"
+	cartItemsTable            = "cart_items"
+	emailOptOutsTable         = "email_opt_outs"
+	productVariantsTable      = "product_variants"
+	promoCodesTable           = "promo_codes"
+	promoCodeUsagesTable      = "promo_code_usages"
+	exchangeRatesTable        = "exchange_rates"
+	shippingZonesTable        = "shipping_zones"
+	shippingMethodsTable      = "shipping_methods"
+	shippingRatesTable        = "shipping_rates"
+	taxRatesTable             = "tax_rates"
+	invoicesTable             = "invoices"
+	refundsTable              = "refunds"
+	refundItemsTable          = "refund_items"
+	paymentDiscrepanciesTable = "payment_discrepancies"
+	homepageImagesTable       = "homepage_images"
+	textBlocksTable           = "text_blocks"
+	multiLanguageTextTable    = "multilanguage_text"
 )
 
 type Config struct {
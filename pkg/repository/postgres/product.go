@@ -3,11 +3,20 @@ package postgres
 import (
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 	jewerly "github.com/zhashkevych/jewelry-shop-backend"
 	"strings"
 )
 
+// Postgres text search configurations per product language.
+// There is no built-in ukrainian configuration, so it falls back to 'simple'.
+var searchConfigs = map[string]string{
+	jewerly.English:    "english",
+	jewerly.Russian:    "russian",
+	jewerly.Ukraininan: "simple",
+}
+
 type ProductRepository struct {
 	db *sqlx.DB
 }
@@ -60,9 +69,10 @@ func (r *ProductRepository) Create(product jewerly.CreateProductInput) error {
 	//insert product
 	var productId int
 	row = tx.QueryRow(fmt.Sprintf(`INSERT INTO %s
-								(code, category_id, title_id, description_id, material_id, price)
-								VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, productsTable),
-		product.Code, product.CategoryId, titleId, descriptionId, materialId, product.Price)
+								(code, category_id, title_id, description_id, material_id, price, stock, sale_price, sale_starts_at, sale_ends_at, weight, made_to_order)
+								VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`, productsTable),
+		product.Code, product.CategoryId, titleId, descriptionId, materialId, product.Price, product.Stock.ValueOrZero(),
+		product.SalePrice, product.SaleStartsAt, product.SaleEndsAt, product.Weight, product.MadeToOrder)
 	err = row.Scan(&productId)
 	if err != nil {
 		logrus.Errorf("[Create Product] create product error: %s", err.Error())
@@ -100,50 +110,217 @@ func multiLanguageInsertQuery(table string, input jewerly.MultiLanguageInput) (s
 func (r *ProductRepository) GetAll(filters jewerly.GetAllProductsFilters) (jewerly.ProductsList, error) {
 	var products jewerly.ProductsList
 
+	sort, ok := productsSorts[filters.Sort]
+	if !ok {
+		sort = productsSorts[""]
+	}
+
 	selectQuery := fmt.Sprintf(`SELECT p.id, t.%[1]s as title, d.%[1]s as description, m.%[1]s as material, p.price,
-							p.code, p.category_id, p.in_stock`, filters.Language)
+							p.code, p.category_id, p.in_stock, p.stock, p.made_to_order, %[2]s, %[3]s::text as sort_value`, filters.Language, productSaleColumns, sort.valueColumn())
 	fromQuery := fmt.Sprintf(` FROM %[1]s p
 							JOIN %[2]s t on t.id = p.title_id
 							JOIN %[3]s d on d.id = p.description_id
 							JOIN %[4]s m on m.id = p.material_id`,
 		productsTable, titlesTable, descriptionsTable, materialsTable)
 
-	// build where query
-	var whereQuery string
+	// popularity is the total quantity of the product across all orders
+	if filters.Sort == jewerly.SortPopular {
+		fromQuery += fmt.Sprintf(` LEFT JOIN (SELECT product_id, sum(quantity) as sold FROM %s GROUP BY product_id) s
+							on s.product_id = p.id`, orderItemsTable)
+	}
+
+	conditions, args := productsWhereConditions(filters)
+	whereQuery := buildWhereQuery(conditions)
+
+	// keyset pagination replaces offset when cursor is passed
+	pageConditions := conditions
+	pageArgs := args
+	offset := filters.Offset
+
+	if filters.Cursor != "" {
+		c, err := decodeCursor(filters.Cursor)
+		if err != nil {
+			return products, err
+		}
+
+		if c.Sort != filters.Sort {
+			return products, jewerly.ErrInvalidCursor
+		}
+
+		condition, cursorArgs := sort.afterCursor(c, len(args)+1)
+		pageConditions = append(conditions, condition)
+		pageArgs = append(args, cursorArgs...)
+		offset = 0
+	}
+
+	argId := len(pageArgs) + 1
+	limitQuery := fmt.Sprintf(" ORDER BY %s OFFSET $%d LIMIT $%d", sort.orderBy(), argId, argId+1)
+
+	// select products, one extra row shows that the next page exists
+	var rows []productRow
+
+	query := fmt.Sprintf("%s %s %s %s", selectQuery, fromQuery, buildWhereQuery(pageConditions), limitQuery)
+	err := r.db.Select(&rows, query, append(pageArgs, offset, filters.Limit+1)...)
+	if err != nil {
+		return products, err
+	}
+
+	if len(rows) > filters.Limit {
+		rows = rows[:filters.Limit]
+		last := rows[len(rows)-1]
+		products.NextCursor = encodeCursor(cursor{Sort: filters.Sort, Value: last.SortValue, Id: last.Id})
+	}
+
+	for i := range rows {
+		products.Products = append(products.Products, rows[i].ProductResponse)
+	}
+
+	if filters.Cursor != "" {
+		return products, nil
+	}
+
+	// total count
+	countQuery := fmt.Sprintf("SELECT count(*) %s %s", fromQuery, whereQuery)
+	err = r.db.Get(&products.Total, countQuery, args...)
+
+	return products, err
+}
+
+type productRow struct {
+	jewerly.ProductResponse
+	SortValue string `db:"sort_value"`
+}
+
+type productsSort struct {
+	column string
+	desc   bool
+}
+
+// products are sorted and filtered by the price they are sold for, the same that is shown to the customer
+var productsSorts = map[string]productsSort{
+	"":                    {},
+	jewerly.SortPriceAsc:  {column: currentPriceColumn},
+	jewerly.SortPriceDesc: {column: currentPriceColumn, desc: true},
+	jewerly.SortNewest:    {column: "p.created_at", desc: true},
+	jewerly.SortPopular:   {column: "COALESCE(s.sold, 0)", desc: true},
+}
+
+func (s productsSort) valueColumn() string {
+	if s.column == "" {
+		return "p.id"
+	}
 
+	return s.column
+}
+
+func (s productsSort) orderBy() string {
+	switch {
+	case s.column == "":
+		return "p.id"
+	case s.desc:
+		return s.column + " DESC, p.id"
+	default:
+		return s.column + ", p.id"
+	}
+}
+
+func (s productsSort) afterCursor(c cursor, argId int) (string, []interface{}) {
+	if s.column == "" {
+		return fmt.Sprintf("p.id > $%d", argId), []interface{}{c.Id}
+	}
+
+	operator := ">"
+	if s.desc {
+		operator = "<"
+	}
+
+	return fmt.Sprintf("(%[1]s %[2]s $%[3]d OR (%[1]s = $%[3]d AND p.id > $%[4]d))", s.column, operator, argId, argId+1),
+		[]interface{}{c.Value, c.Id}
+}
+
+func buildWhereQuery(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+func productsWhereConditions(filters jewerly.GetAllProductsFilters) ([]string, []interface{}) {
 	argId := 1
 	args := make([]interface{}, 0)
-	if filters.CategoryId.Valid {
-		whereQuery = fmt.Sprintf("WHERE p.category_id=$%d", argId)
-		args = append(args, filters.CategoryId.Int64)
+	conditions := make([]string, 0)
+
+	if len(filters.CategoryIds) > 0 {
+		conditions = append(conditions, fmt.Sprintf("p.category_id = ANY($%d)", argId))
+		args = append(args, pq.Array(filters.CategoryIds))
 		argId++
 	}
 
-	args = append(args, filters.Offset, filters.Limit)
-	limitQuery := fmt.Sprintf(" OFFSET $%d LIMIT $%d", argId, argId+1)
+	if filters.MinPrice.Valid {
+		conditions = append(conditions, fmt.Sprintf("%s >= $%d", currentPriceColumn, argId))
+		args = append(args, filters.MinPrice.Float64)
+		argId++
+	}
 
-	// BUILD FINAL QUERY
-	var query string
-	if whereQuery == "" {
-		query = fmt.Sprintf("%s %s %s", selectQuery, fromQuery, limitQuery)
-	} else {
-		query = fmt.Sprintf("%s %s %s %s", selectQuery, fromQuery, whereQuery, limitQuery)
+	if filters.MaxPrice.Valid {
+		conditions = append(conditions, fmt.Sprintf("%s <= $%d", currentPriceColumn, argId))
+		args = append(args, filters.MaxPrice.Float64)
+		argId++
 	}
 
-	// select products
-	err := r.db.Select(&products.Products, query, args...)
+	if filters.InStock.Valid {
+		conditions = append(conditions, fmt.Sprintf("p.in_stock = $%d", argId))
+		args = append(args, filters.InStock.Bool)
+		argId++
+	}
 
-	// total count
-	var countQuery string
-	args = make([]interface{}, 0)
-	if whereQuery == "" {
-		countQuery = fmt.Sprintf("SELECT count(*) %s", fromQuery)
-	} else {
-		countQuery = fmt.Sprintf("SELECT count(*) %s %s", fromQuery, whereQuery)
-		args = append(args, filters.CategoryId.Int64)
+	if filters.Material.Valid {
+		conditions = append(conditions, fmt.Sprintf("m.%s ILIKE $%d", filters.Language, argId))
+		args = append(args, "%"+likeEscaper.Replace(filters.Material.String)+"%")
+		argId++
 	}
 
-	err = r.db.Get(&products.Total, countQuery, args...)
+	return conditions, args
+}
+
+// likeEscaper makes wildcards of the user input match literally in LIKE patterns.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *ProductRepository) Search(filters jewerly.SearchProductsFilters) (jewerly.ProductsList, error) {
+	var products jewerly.ProductsList
+
+	config, ok := searchConfigs[filters.Language]
+	if !ok {
+		config = searchConfigs[jewerly.English]
+	}
+
+	fromQuery := fmt.Sprintf(` FROM %[1]s p
+							JOIN %[2]s t on t.id = p.title_id
+							JOIN %[3]s d on d.id = p.description_id
+							JOIN %[4]s m on m.id = p.material_id
+							CROSS JOIN plainto_tsquery('%[5]s', $1) q`,
+		productsTable, titlesTable, descriptionsTable, materialsTable, config)
+
+	// every column is matched separately, so the per-language GIN indexes are used
+	whereQuery := fmt.Sprintf(`WHERE to_tsvector('%[1]s', t.%[2]s) @@ q
+							OR to_tsvector('%[1]s', d.%[2]s) @@ q
+							OR to_tsvector('%[1]s', m.%[2]s) @@ q`, config, filters.Language)
+
+	rankQuery := fmt.Sprintf(`ts_rank(setweight(to_tsvector('%[1]s', t.%[2]s), 'A') ||
+							setweight(to_tsvector('%[1]s', d.%[2]s), 'B') ||
+							setweight(to_tsvector('%[1]s', m.%[2]s), 'C'), q)`, config, filters.Language)
+
+	query := fmt.Sprintf(`SELECT p.id, t.%[1]s as title, d.%[1]s as description, m.%[1]s as material, p.price,
+							p.code, p.category_id, p.in_stock, p.stock, p.made_to_order, %[5]s %[2]s %[3]s ORDER BY %[4]s DESC, p.id OFFSET $2 LIMIT $3`,
+		filters.Language, fromQuery, whereQuery, rankQuery, productSaleColumns)
+
+	err := r.db.Select(&products.Products, query, filters.Query, filters.Offset, filters.Limit)
+	if err != nil {
+		return products, err
+	}
+
+	err = r.db.Get(&products.Total, fmt.Sprintf("SELECT count(*) %s %s", fromQuery, whereQuery), filters.Query)
 
 	return products, err
 }
@@ -175,11 +352,11 @@ func (r *ProductRepository) GetById(id int, language string) (jewerly.ProductRes
 	var product jewerly.ProductResponse
 
 	query := fmt.Sprintf(`SELECT p.id, t.%[1]s as title, d.%[1]s as description, m.%[1]s as material, 
-							p.price, p.code, p.category_id, p.in_stock FROM %[2]s p
+							p.price, p.code, p.category_id, p.in_stock, p.stock, p.weight, p.made_to_order, %[6]s FROM %[2]s p
 							JOIN %[3]s t on t.id = p.title_id
 							JOIN %[4]s d on d.id = p.description_id
 							JOIN %[5]s m on m.id = p.material_id WHERE p.id = $1`,
-		language, productsTable, titlesTable, descriptionsTable, materialsTable)
+		language, productsTable, titlesTable, descriptionsTable, materialsTable, productSaleColumns)
 	err := r.db.Get(&product, query, id)
 
 	return product, err
@@ -202,6 +379,36 @@ func (r *ProductRepository) GetProductImages(productId int) ([]jewerly.Image, er
 	return images, err
 }
 
+func (r *ProductRepository) GetProductsImages(productIds []int) (map[int][]jewerly.Image, error) {
+	return selectProductsImages(r.db, productIds)
+}
+
+type productImageRow struct {
+	ProductId int `db:"product_id"`
+	jewerly.Image
+}
+
+// selectProductsImages loads images for all products in one query, grouped by product id.
+func selectProductsImages(db *sqlx.DB, productIds []int) (map[int][]jewerly.Image, error) {
+	images := make(map[int][]jewerly.Image, len(productIds))
+	if len(productIds) == 0 {
+		return images, nil
+	}
+
+	var rows []productImageRow
+	err := db.Select(&rows, fmt.Sprintf(`SELECT pi.product_id, i.id, i.url, i.alt_text FROM %s i JOIN %s pi ON pi.image_id = i.id
+									WHERE pi.product_id = ANY($1) ORDER BY pi.id`, imagesTable, productImagesTable), pq.Array(productIds))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		images[row.ProductId] = append(images[row.ProductId], row.Image)
+	}
+
+	return images, nil
+}
+
 func (r *ProductRepository) Update(id int, inp jewerly.UpdateProductInput) error {
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -249,9 +456,26 @@ func (r *ProductRepository) Update(id int, inp jewerly.UpdateProductInput) error
 		argId++
 	}
 
-	if inp.InStock.Valid {
-		updateValues = append(updateValues, fmt.Sprintf("in_stock=$%d", argId))
-		args = append(args, inp.InStock.Bool)
+	// in_stock is derived from stock
+	if inp.Stock.Valid {
+		updateValues = append(updateValues, fmt.Sprintf("stock=$%d", argId))
+		args = append(args, inp.Stock.Int64)
+		argId++
+	} else if inp.InStock.Valid && inp.InStock.Bool {
+		updateValues = append(updateValues, "stock=GREATEST(stock, 1)")
+	} else if inp.InStock.Valid {
+		updateValues = append(updateValues, "stock=0")
+	}
+
+	if inp.Weight.Valid {
+		updateValues = append(updateValues, fmt.Sprintf("weight=$%d", argId))
+		args = append(args, inp.Weight.Int64)
+		argId++
+	}
+
+	if inp.MadeToOrder.Valid {
+		updateValues = append(updateValues, fmt.Sprintf("made_to_order=$%d", argId))
+		args = append(args, inp.MadeToOrder.Bool)
 		argId++
 	}
 
@@ -263,10 +487,32 @@ func (r *ProductRepository) Update(id int, inp jewerly.UpdateProductInput) error
 
 	if inp.Price.Valid {
 		updateValues = append(updateValues, fmt.Sprintf("price=$%d", argId))
-		args = append(args, inp.Price.Float64)
+		args = append(args, inp.Price.Money)
 		argId++
 	}
 
+	if inp.RemoveSale {
+		updateValues = append(updateValues, "sale_price=NULL", "sale_starts_at=NULL", "sale_ends_at=NULL")
+	} else {
+		if inp.SalePrice.Valid {
+			updateValues = append(updateValues, fmt.Sprintf("sale_price=$%d", argId))
+			args = append(args, inp.SalePrice.Money)
+			argId++
+		}
+
+		if inp.SaleStartsAt.Valid {
+			updateValues = append(updateValues, fmt.Sprintf("sale_starts_at=$%d", argId))
+			args = append(args, inp.SaleStartsAt.Time)
+			argId++
+		}
+
+		if inp.SaleEndsAt.Valid {
+			updateValues = append(updateValues, fmt.Sprintf("sale_ends_at=$%d", argId))
+			args = append(args, inp.SaleEndsAt.Time)
+			argId++
+		}
+	}
+
 	updateProductQuery := fmt.Sprintf("UPDATE %s SET %s WHERE id = $%d", productsTable, strings.Join(updateValues, ", "), argId)
 	args = append(args, id)
 	argId++
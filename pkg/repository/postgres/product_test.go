@@ -0,0 +1,317 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"gopkg.in/guregu/null.v3"
+	"testing"
+)
+
+func TestProductRepository_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	type mockBehavior func(filters jewerly.GetAllProductsFilters)
+
+	productColumns := []string{"id", "title", "description", "material", "price", "code", "category_id", "in_stock", "sort_value"}
+
+	testTable := []struct {
+		name         string
+		filters      jewerly.GetAllProductsFilters
+		mockBehavior mockBehavior
+		expected     jewerly.ProductsList
+		shouldFail   bool
+	}{
+		{
+			name: "OK - No Filters",
+			filters: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Limit:    20,
+			},
+			mockBehavior: func(filters jewerly.GetAllProductsFilters) {
+				rows := sqlmock.NewRows(productColumns).AddRow(1, "Ring", "Silver ring", "Silver", 100, "R1", 1, true, "1")
+				mock.ExpectQuery("SELECT (.+) FROM products p (.+) ORDER BY p.id OFFSET \\$1 LIMIT \\$2").
+					WithArgs(filters.Offset, filters.Limit+1).WillReturnRows(rows)
+
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM products p").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			expected: jewerly.ProductsList{
+				Products: []jewerly.ProductResponse{
+					{Id: 1, Title: "Ring", Description: "Silver ring", Material: "Silver", Price: 10000, Code: null.StringFrom("R1"), CategoryId: 1, InStock: true},
+				},
+				Total: 1,
+			},
+		},
+		{
+			name: "OK - All Filters",
+			filters: jewerly.GetAllProductsFilters{
+				Language:    jewerly.Russian,
+				Offset:      20,
+				Limit:       10,
+				CategoryIds: []int{1, 3},
+				MinPrice:    null.FloatFrom(100),
+				MaxPrice:    null.FloatFrom(500),
+				InStock:     null.BoolFrom(true),
+				Material:    null.StringFrom("серебро"),
+				Sort:        jewerly.SortPriceDesc,
+			},
+			mockBehavior: func(filters jewerly.GetAllProductsFilters) {
+				args := []driver.Value{"{1,3}", filters.MinPrice.Float64, filters.MaxPrice.Float64, true, "%серебро%"}
+
+				rows := sqlmock.NewRows(productColumns)
+				mock.ExpectQuery("SELECT (.+) FROM products p (.+) WHERE p.category_id = ANY\\(\\$1\\) " +
+					"AND CASE WHEN (.+) THEN p.sale_price ELSE p.price END >= \\$2 AND CASE WHEN (.+) THEN p.sale_price ELSE p.price END <= \\$3 " +
+					"AND p.in_stock = \\$4 AND m.russian ILIKE \\$5 ORDER BY CASE WHEN (.+) THEN p.sale_price ELSE p.price END DESC, p.id OFFSET \\$6 LIMIT \\$7").
+					WithArgs(append(args, filters.Offset, filters.Limit+1)...).WillReturnRows(rows)
+
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM products p (.+) WHERE (.+)").
+					WithArgs(args...).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			expected: jewerly.ProductsList{},
+		},
+		{
+			name: "OK - Material Wildcards",
+			filters: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Limit:    20,
+				Material: null.StringFrom(`50%_gold\`),
+			},
+			mockBehavior: func(filters jewerly.GetAllProductsFilters) {
+				mock.ExpectQuery("SELECT (.+) FROM products p (.+) WHERE m.english ILIKE \\$1 ORDER BY p.id OFFSET \\$2 LIMIT \\$3").
+					WithArgs(`%50\%\_gold\\%`, filters.Offset, filters.Limit+1).WillReturnRows(sqlmock.NewRows(productColumns))
+
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM products p").
+					WithArgs(`%50\%\_gold\\%`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			expected: jewerly.ProductsList{},
+		},
+		{
+			name: "OK - Popular",
+			filters: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Limit:    20,
+				Sort:     jewerly.SortPopular,
+			},
+			mockBehavior: func(filters jewerly.GetAllProductsFilters) {
+				rows := sqlmock.NewRows(productColumns)
+				mock.ExpectQuery("SELECT (.+) FROM products p (.+) LEFT JOIN \\(SELECT product_id, sum\\(quantity\\) as sold FROM order_items (.+) "+
+					"ORDER BY COALESCE\\(s.sold, 0\\) DESC, p.id OFFSET \\$1 LIMIT \\$2").
+					WithArgs(filters.Offset, filters.Limit+1).WillReturnRows(rows)
+
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM products p").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			expected: jewerly.ProductsList{},
+		},
+		{
+			name: "OK - Next Cursor",
+			filters: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Limit:    1,
+				Sort:     jewerly.SortPriceAsc,
+			},
+			mockBehavior: func(filters jewerly.GetAllProductsFilters) {
+				rows := sqlmock.NewRows(productColumns).
+					AddRow(1, "Ring", "Silver ring", "Silver", 100, "R1", 1, true, "100.00").
+					AddRow(2, "Ring 2", "Gold ring", "Gold", 200, "R2", 1, true, "200.00")
+				mock.ExpectQuery("SELECT (.+) FROM products p (.+) ORDER BY CASE WHEN (.+) THEN p.sale_price ELSE p.price END, p.id OFFSET \\$1 LIMIT \\$2").
+					WithArgs(filters.Offset, filters.Limit+1).WillReturnRows(rows)
+
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM products p").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			},
+			expected: jewerly.ProductsList{
+				Products: []jewerly.ProductResponse{
+					{Id: 1, Title: "Ring", Description: "Silver ring", Material: "Silver", Price: 10000, Code: null.StringFrom("R1"), CategoryId: 1, InStock: true},
+				},
+				Total:      2,
+				NextCursor: encodeCursor(cursor{Sort: jewerly.SortPriceAsc, Value: "100.00", Id: 1}),
+			},
+		},
+		{
+			name: "OK - With Cursor",
+			filters: jewerly.GetAllProductsFilters{
+				Language:    jewerly.English,
+				Offset:      40,
+				Limit:       1,
+				CategoryIds: []int{1},
+				Sort:        jewerly.SortPriceDesc,
+				Cursor:      encodeCursor(cursor{Sort: jewerly.SortPriceDesc, Value: "200.00", Id: 2}),
+			},
+			mockBehavior: func(filters jewerly.GetAllProductsFilters) {
+				rows := sqlmock.NewRows(productColumns).
+					AddRow(1, "Ring", "Silver ring", "Silver", 100, "R1", 1, true, "100.00")
+				mock.ExpectQuery("SELECT (.+) FROM products p (.+) WHERE p.category_id = ANY\\(\\$1\\) "+
+					"AND \\(CASE WHEN (.+) END < \\$2 OR \\(CASE WHEN (.+) END = \\$2 AND p.id > \\$3\\)\\) ORDER BY CASE WHEN (.+) END DESC, p.id OFFSET \\$4 LIMIT \\$5").
+					WithArgs("{1}", "200.00", 2, 0, filters.Limit+1).WillReturnRows(rows)
+			},
+			expected: jewerly.ProductsList{
+				Products: []jewerly.ProductResponse{
+					{Id: 1, Title: "Ring", Description: "Silver ring", Material: "Silver", Price: 10000, Code: null.StringFrom("R1"), CategoryId: 1, InStock: true},
+				},
+			},
+		},
+		{
+			name: "Invalid Cursor",
+			filters: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Limit:    20,
+				Cursor:   "not a cursor",
+			},
+			mockBehavior: func(filters jewerly.GetAllProductsFilters) {},
+			shouldFail:   true,
+		},
+		{
+			name: "Cursor Of Another Sort",
+			filters: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Limit:    20,
+				Sort:     jewerly.SortPriceAsc,
+				Cursor:   encodeCursor(cursor{Sort: jewerly.SortNewest, Value: "2020-10-01T10:00:00Z", Id: 2}),
+			},
+			mockBehavior: func(filters jewerly.GetAllProductsFilters) {},
+			shouldFail:   true,
+		},
+		{
+			name: "Select Error",
+			filters: jewerly.GetAllProductsFilters{
+				Language: jewerly.English,
+				Limit:    20,
+			},
+			mockBehavior: func(filters jewerly.GetAllProductsFilters) {
+				mock.ExpectQuery("SELECT (.+) FROM products p").WillReturnError(errors.New("fail"))
+			},
+			shouldFail: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.filters)
+
+			r := NewProductRepository(db)
+
+			got, err := r.GetAll(testCase.filters)
+			if testCase.shouldFail {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expected, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepository_Search(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	type mockBehavior func(filters jewerly.SearchProductsFilters)
+
+	productColumns := []string{"id", "title", "description", "material", "price", "code", "category_id", "in_stock", "stock", "made_to_order",
+		"sale_price", "sale_starts_at", "sale_ends_at", "on_sale"}
+
+	testTable := []struct {
+		name         string
+		filters      jewerly.SearchProductsFilters
+		mockBehavior mockBehavior
+		expected     jewerly.ProductsList
+		shouldFail   bool
+	}{
+		{
+			name: "OK",
+			filters: jewerly.SearchProductsFilters{
+				Query:    "silver ring",
+				Language: jewerly.English,
+				Limit:    20,
+			},
+			mockBehavior: func(filters jewerly.SearchProductsFilters) {
+				rows := sqlmock.NewRows(productColumns).
+					AddRow(1, "Ring", "Silver ring", "Silver", "100.00", "R1", 1, true, 2, false, nil, nil, nil, false)
+				mock.ExpectQuery("SELECT (.+) FROM products p (.+) CROSS JOIN plainto_tsquery\\('english', \\$1\\) q "+
+					"WHERE to_tsvector\\('english', t.english\\) @@ q (.+) ORDER BY ts_rank\\((.+)\\) DESC, p.id OFFSET \\$2 LIMIT \\$3").
+					WithArgs(filters.Query, filters.Offset, filters.Limit).WillReturnRows(rows)
+
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM products p (.+) WHERE (.+)").
+					WithArgs(filters.Query).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			expected: jewerly.ProductsList{
+				Products: []jewerly.ProductResponse{
+					{Id: 1, Title: "Ring", Description: "Silver ring", Material: "Silver", Price: 10000, Code: null.StringFrom("R1"),
+						CategoryId: 1, InStock: true, Stock: 2},
+				},
+				Total: 1,
+			},
+		},
+		{
+			name: "OK - Language ru",
+			filters: jewerly.SearchProductsFilters{
+				Query:    "кольцо",
+				Language: jewerly.Russian,
+				Offset:   20,
+				Limit:    10,
+			},
+			mockBehavior: func(filters jewerly.SearchProductsFilters) {
+				mock.ExpectQuery("SELECT (.+) FROM products p (.+) CROSS JOIN plainto_tsquery\\('russian', \\$1\\) q "+
+					"WHERE to_tsvector\\('russian', t.russian\\) @@ q (.+) OFFSET \\$2 LIMIT \\$3").
+					WithArgs(filters.Query, filters.Offset, filters.Limit).WillReturnRows(sqlmock.NewRows(productColumns))
+
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM products p").
+					WithArgs(filters.Query).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			expected: jewerly.ProductsList{},
+		},
+		{
+			name: "Select Error",
+			filters: jewerly.SearchProductsFilters{
+				Query:    "ring",
+				Language: jewerly.English,
+				Limit:    20,
+			},
+			mockBehavior: func(filters jewerly.SearchProductsFilters) {
+				mock.ExpectQuery("SELECT (.+) FROM products p").WillReturnError(errors.New("fail"))
+			},
+			shouldFail: true,
+		},
+		{
+			name: "Count Error",
+			filters: jewerly.SearchProductsFilters{
+				Query:    "ring",
+				Language: jewerly.English,
+				Limit:    20,
+			},
+			mockBehavior: func(filters jewerly.SearchProductsFilters) {
+				mock.ExpectQuery("SELECT (.+) FROM products p").WillReturnRows(sqlmock.NewRows(productColumns))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM products p").WillReturnError(errors.New("fail"))
+			},
+			shouldFail: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.filters)
+
+			r := NewProductRepository(db)
+
+			got, err := r.Search(testCase.filters)
+			if testCase.shouldFail {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expected, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"strings"
+)
+
+var promoCodeColumns = fmt.Sprintf(`pc.id, pc.code, pc.type, pc.percent, pc.amount, pc.min_order_sum, pc.category_ids, pc.valid_from, pc.valid_to,
+						pc.usage_limit, pc.per_customer_limit, pc.active, pc.created_at,
+						(SELECT count(*) FROM %s u WHERE u.promo_code_id = pc.id) as used_count`, promoCodeUsagesTable)
+
+// promoCodeRow scans postgres int[] column, that can't be mapped to the categories slice directly.
+type promoCodeRow struct {
+	jewerly.PromoCode
+	CategoryIds pq.Int64Array `db:"category_ids"`
+}
+
+func (r promoCodeRow) toPromoCode() jewerly.PromoCode {
+	promo := r.PromoCode
+	promo.CategoryIds = make([]jewerly.Category, len(r.CategoryIds))
+	for i, id := range r.CategoryIds {
+		promo.CategoryIds[i] = jewerly.Category(id)
+	}
+
+	return promo
+}
+
+type PromoRepository struct {
+	db *sqlx.DB
+}
+
+func NewPromoRepository(db *sqlx.DB) *PromoRepository {
+	return &PromoRepository{db: db}
+}
+
+func (r *PromoRepository) Create(inp jewerly.CreatePromoCodeInput) (int, error) {
+	var id int
+	err := r.db.QueryRow(fmt.Sprintf(`INSERT INTO %s (code, type, percent, amount, min_order_sum, category_ids, valid_from, valid_to, usage_limit,
+									per_customer_limit) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`, promoCodesTable),
+		inp.Code, inp.Type, inp.Percent, inp.Amount, inp.MinOrderSum, pq.Array(categoryIds(inp.CategoryIds)), inp.ValidFrom, inp.ValidTo,
+		inp.UsageLimit, inp.PerCustomerLimit).Scan(&id)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolationCode {
+		return 0, jewerly.ErrPromoCodeAlreadyExists
+	}
+
+	return id, err
+}
+
+func (r *PromoRepository) GetAll() ([]jewerly.PromoCode, error) {
+	var rows []promoCodeRow
+	err := r.db.Select(&rows, fmt.Sprintf("SELECT %s FROM %s pc ORDER BY pc.id DESC", promoCodeColumns, promoCodesTable))
+	if err != nil {
+		logrus.Errorf("failed to get promo codes: %s", err.Error())
+		return nil, err
+	}
+
+	promoCodes := make([]jewerly.PromoCode, len(rows))
+	for i := range rows {
+		promoCodes[i] = rows[i].toPromoCode()
+	}
+
+	return promoCodes, nil
+}
+
+func (r *PromoRepository) GetById(id int) (jewerly.PromoCode, error) {
+	return r.get("pc.id = $1", id)
+}
+
+func (r *PromoRepository) GetByCode(code string) (jewerly.PromoCode, error) {
+	return r.get("pc.code = $1", code)
+}
+
+func (r *PromoRepository) get(condition string, arg interface{}) (jewerly.PromoCode, error) {
+	var row promoCodeRow
+	err := r.db.Get(&row, fmt.Sprintf("SELECT %s FROM %s pc WHERE %s", promoCodeColumns, promoCodesTable, condition), arg)
+	if err == sql.ErrNoRows {
+		return jewerly.PromoCode{}, jewerly.ErrPromoCodeNotFound
+	}
+	if err != nil {
+		return jewerly.PromoCode{}, err
+	}
+
+	return row.toPromoCode(), nil
+}
+
+func (r *PromoRepository) Update(id int, inp jewerly.UpdatePromoCodeInput) error {
+	argId := 1
+	args := make([]interface{}, 0)
+	updateValues := make([]string, 0)
+
+	setValue := func(column string, value interface{}) {
+		updateValues = append(updateValues, fmt.Sprintf("%s=$%d", column, argId))
+		args = append(args, value)
+		argId++
+	}
+
+	if inp.Percent.Valid {
+		setValue("percent", inp.Percent.Float64)
+	}
+
+	if inp.Amount.Valid {
+		setValue("amount", inp.Amount.Money)
+	}
+
+	if inp.MinOrderSum.Valid {
+		setValue("min_order_sum", inp.MinOrderSum.Money)
+	}
+
+	if inp.CategoryIds != nil {
+		setValue("category_ids", pq.Array(categoryIds(*inp.CategoryIds)))
+	}
+
+	if inp.ValidFrom.Valid {
+		setValue("valid_from", inp.ValidFrom.Time)
+	}
+
+	if inp.ValidTo.Valid {
+		setValue("valid_to", inp.ValidTo.Time)
+	}
+
+	if inp.UsageLimit.Valid {
+		setValue("usage_limit", inp.UsageLimit.Int64)
+	}
+
+	if inp.PerCustomerLimit.Valid {
+		setValue("per_customer_limit", inp.PerCustomerLimit.Int64)
+	}
+
+	if inp.Active.Valid {
+		setValue("active", inp.Active.Bool)
+	}
+
+	args = append(args, id)
+	res, err := r.db.Exec(fmt.Sprintf("UPDATE %s SET %s WHERE id = $%d", promoCodesTable, strings.Join(updateValues, ", "), argId), args...)
+	if err != nil {
+		logrus.Errorf("failed to update promo code: %s", err.Error())
+		return err
+	}
+
+	return checkPromoCodeAffected(res)
+}
+
+func (r *PromoRepository) Delete(id int) error {
+	res, err := r.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = $1", promoCodesTable), id)
+	if err != nil {
+		return err
+	}
+
+	return checkPromoCodeAffected(res)
+}
+
+// CountUsages returns how many times the promo code was used in total and by the customer with the email.
+func (r *PromoRepository) CountUsages(promoCodeId int, email string) (int, int, error) {
+	var usages struct {
+		Total    int `db:"total"`
+		Customer int `db:"customer"`
+	}
+
+	err := r.db.Get(&usages, fmt.Sprintf("SELECT count(*) as total, count(*) FILTER (WHERE email = $2) as customer FROM %s WHERE promo_code_id = $1",
+		promoCodeUsagesTable), promoCodeId, email)
+
+	return usages.Total, usages.Customer, err
+}
+
+func checkPromoCodeAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return jewerly.ErrPromoCodeNotFound
+	}
+
+	return nil
+}
+
+func categoryIds(categories []jewerly.Category) []int {
+	ids := make([]int, len(categories))
+	for i := range categories {
+		ids[i] = int(categories[i])
+	}
+
+	return ids
+}
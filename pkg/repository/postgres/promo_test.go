@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"gopkg.in/guregu/null.v3"
+	"testing"
+	"time"
+)
+
+func TestPromoRepository_GetByCode(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewPromoRepository(db)
+
+	columns := []string{"id", "code", "type", "percent", "amount", "min_order_sum", "category_ids", "valid_from", "valid_to", "usage_limit",
+		"per_customer_limit", "active", "created_at", "used_count"}
+
+	type mockBehavior func(code string)
+
+	testTable := []struct {
+		name         string
+		code         string
+		mockBehavior mockBehavior
+		want         jewerly.PromoCode
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			code: "RINGS10",
+			mockBehavior: func(code string) {
+				mock.ExpectQuery("SELECT (.+) FROM promo_codes pc WHERE pc.code = \\$1").WithArgs(code).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, code, jewerly.PromoTypePercentage, 10, 0, 100, "{1,5}",
+						nil, nil, 100, 1, true, time.Time{}, 3))
+			},
+			want: jewerly.PromoCode{
+				Id:               1,
+				Code:             "RINGS10",
+				Type:             jewerly.PromoTypePercentage,
+				Percent:          10,
+				MinOrderSum:      10000,
+				CategoryIds:      []jewerly.Category{jewerly.CategoryRings, jewerly.CategoryNecklaces},
+				UsageLimit:       null.IntFrom(100),
+				PerCustomerLimit: null.IntFrom(1),
+				Active:           true,
+				UsedCount:        3,
+			},
+		},
+		{
+			// postgres driver returns DECIMAL columns as text
+			name: "Ok Fixed",
+			code: "MINUS265",
+			mockBehavior: func(code string) {
+				mock.ExpectQuery("SELECT (.+) FROM promo_codes pc WHERE pc.code = \\$1").WithArgs(code).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, code, jewerly.PromoTypeFixed, []byte("0.00"), []byte("265.10"),
+						[]byte("1000.00"), "{}", nil, nil, nil, nil, true, time.Time{}, 0))
+			},
+			want: jewerly.PromoCode{
+				Id:          2,
+				Code:        "MINUS265",
+				Type:        jewerly.PromoTypeFixed,
+				Amount:      26510,
+				MinOrderSum: 100000,
+				CategoryIds: []jewerly.Category{},
+				Active:      true,
+			},
+		},
+		{
+			name: "Not Found",
+			code: "UNKNOWN",
+			mockBehavior: func(code string) {
+				mock.ExpectQuery("SELECT (.+) FROM promo_codes pc WHERE pc.code = \\$1").WithArgs(code).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: jewerly.ErrPromoCodeNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.code)
+
+			got, err := r.GetByCode(testCase.code)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.wantErr == nil {
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPromoRepository_Create(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewPromoRepository(db)
+
+	type mockBehavior func(inp jewerly.CreatePromoCodeInput)
+
+	testTable := []struct {
+		name         string
+		input        jewerly.CreatePromoCodeInput
+		mockBehavior mockBehavior
+		want         int
+		wantErr      error
+	}{
+		{
+			name:  "Ok",
+			input: jewerly.CreatePromoCodeInput{Code: "RINGS10", Type: jewerly.PromoTypeFixed, Amount: 26510, CategoryIds: []jewerly.Category{jewerly.CategoryRings}},
+			mockBehavior: func(inp jewerly.CreatePromoCodeInput) {
+				// amount is written as decimal text, so DECIMAL column gets exact cents
+				mock.ExpectQuery("INSERT INTO promo_codes").
+					WithArgs(inp.Code, inp.Type, float32(0), "265.10", "0.00", "{1}", inp.ValidFrom, inp.ValidTo, inp.UsageLimit, inp.PerCustomerLimit).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			},
+			want: 3,
+		},
+		{
+			name:  "Already Exists",
+			input: jewerly.CreatePromoCodeInput{Code: "RINGS10", Type: jewerly.PromoTypeFreeShipping},
+			mockBehavior: func(inp jewerly.CreatePromoCodeInput) {
+				mock.ExpectQuery("INSERT INTO promo_codes").
+					WithArgs(inp.Code, inp.Type, inp.Percent, inp.Amount, inp.MinOrderSum, "{}", inp.ValidFrom, inp.ValidTo, inp.UsageLimit, inp.PerCustomerLimit).
+					WillReturnError(&pq.Error{Code: "23505"})
+			},
+			wantErr: jewerly.ErrPromoCodeAlreadyExists,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			got, err := r.Create(testCase.input)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.Equal(t, testCase.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"time"
+)
+
+type ReconciliationRepository struct {
+	db *sqlx.DB
+}
+
+func NewReconciliationRepository(db *sqlx.DB) *ReconciliationRepository {
+	return &ReconciliationRepository{db: db}
+}
+
+// GetPendingTransactions returns transactions of orders placed in the (from, to) period, that didn't receive any of finalNotifyTypes
+// callbacks, with the status they got last.
+func (r *ReconciliationRepository) GetPendingTransactions(from, to time.Time, finalNotifyTypes []string) ([]jewerly.PendingTransaction, error) {
+	var transactions []jewerly.PendingTransaction
+
+	query := fmt.Sprintf(`SELECT t.uuid, t.order_id, o.status AS order_status,
+							COALESCE((SELECT th.status FROM %[3]s th WHERE th.uuid = t.uuid ORDER BY th.id DESC LIMIT 1), '') AS last_status
+							FROM %[1]s t JOIN %[2]s o ON o.id = t.order_id
+							WHERE o.ordered_at > $1 AND o.ordered_at < $2
+							AND NOT EXISTS (SELECT 1 FROM %[3]s th WHERE th.uuid = t.uuid AND th.status = ANY($3)) ORDER BY t.order_id`,
+		transactionsTable, ordersTable, transactionsHistoryTable)
+	err := r.db.Select(&transactions, query, from, to, pq.Array(finalNotifyTypes))
+
+	return transactions, err
+}
+
+// CreateDiscrepancy doesn't report the same discrepancy again, reconciliation finds not resolved ones on every run.
+func (r *ReconciliationRepository) CreateDiscrepancy(discrepancy jewerly.PaymentDiscrepancy) error {
+	_, err := r.db.Exec(fmt.Sprintf(`INSERT INTO %s (order_id, uuid, kind, local_status, provider_status, details, resolved) 
+										VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (uuid, kind, provider_status) DO NOTHING`,
+		paymentDiscrepanciesTable),
+		discrepancy.OrderId, discrepancy.TransactionId, discrepancy.Kind, discrepancy.LocalStatus, discrepancy.ProviderStatus,
+		discrepancy.Details, discrepancy.Resolved)
+
+	return err
+}
+
+func (r *ReconciliationRepository) GetDiscrepancies(filters jewerly.PaymentDiscrepancyFilters) ([]jewerly.PaymentDiscrepancy, error) {
+	discrepancies := make([]jewerly.PaymentDiscrepancy, 0)
+
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	if filters.Resolved.Valid {
+		args = append(args, filters.Resolved.Bool)
+		conditions = append(conditions, fmt.Sprintf("resolved = $%d", len(args)))
+	}
+
+	args = append(args, filters.Offset, filters.Limit)
+	query := fmt.Sprintf(`SELECT id, order_id, uuid, kind, local_status, provider_status, details, resolved, created_at FROM %s %s 
+							ORDER BY id DESC OFFSET $%d LIMIT $%d`, paymentDiscrepanciesTable, buildWhereQuery(conditions), len(args)-1, len(args))
+	err := r.db.Select(&discrepancies, query, args...)
+
+	return discrepancies, err
+}
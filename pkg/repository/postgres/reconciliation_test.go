@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"gopkg.in/guregu/null.v3"
+	"testing"
+	"time"
+)
+
+func TestReconciliationRepository_GetPendingTransactions(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewReconciliationRepository(db)
+
+	to := time.Now()
+	from := to.Add(-time.Hour * 72)
+	notifyTypes := []string{"sale-complete", "sale-failure"}
+
+	mock.ExpectQuery("SELECT t.uuid, t.order_id, o.status AS order_status, (.+) AS last_status FROM transactions t JOIN orders o (.+) "+
+		"AND NOT EXISTS \\(SELECT 1 FROM transactions_history th WHERE th.uuid = t.uuid AND th.status = ANY\\(\\$3\\)\\) ORDER BY t.order_id").
+		WithArgs(from, to, pq.Array(notifyTypes)).
+		WillReturnRows(sqlmock.NewRows([]string{"uuid", "order_id", "order_status", "last_status"}).
+			AddRow("0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11", 1, jewerly.OrderStatusNew, "created").
+			AddRow("5d0e9f4c-1b7a-4a8e-b6f2-3c9d8e7a6b51", 2, jewerly.OrderStatusAuthorized, "sale-authorized"))
+
+	got, err := r.GetPendingTransactions(from, to, notifyTypes)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []jewerly.PendingTransaction{
+		{TransactionId: "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11", OrderId: 1, OrderStatus: jewerly.OrderStatusNew, LastStatus: "created"},
+		{TransactionId: "5d0e9f4c-1b7a-4a8e-b6f2-3c9d8e7a6b51", OrderId: 2, OrderStatus: jewerly.OrderStatusAuthorized, LastStatus: "sale-authorized"},
+	}, got)
+}
+
+func TestReconciliationRepository_CreateDiscrepancy(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewReconciliationRepository(db)
+
+	discrepancy := jewerly.PaymentDiscrepancy{
+		OrderId:        1,
+		TransactionId:  "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11",
+		Kind:           jewerly.DiscrepancyMissingStatus,
+		LocalStatus:    "created",
+		ProviderStatus: "sale-complete",
+		Details:        "status callback was lost",
+		Resolved:       true,
+	}
+
+	mock.ExpectExec("INSERT INTO payment_discrepancies \\(order_id, uuid, kind, local_status, provider_status, details, resolved\\)"+
+		"(.+)ON CONFLICT \\(uuid, kind, provider_status\\) DO NOTHING").
+		WithArgs(1, discrepancy.TransactionId, discrepancy.Kind, "created", "sale-complete", discrepancy.Details, true).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = r.CreateDiscrepancy(discrepancy)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReconciliationRepository_GetDiscrepancies(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewReconciliationRepository(db)
+
+	createdAt := time.Now()
+	columns := []string{"id", "order_id", "uuid", "kind", "local_status", "provider_status", "details", "resolved", "created_at"}
+
+	testTable := []struct {
+		name         string
+		filters      jewerly.PaymentDiscrepancyFilters
+		mockBehavior func(filters jewerly.PaymentDiscrepancyFilters)
+		want         []jewerly.PaymentDiscrepancy
+	}{
+		{
+			name:    "Not Resolved",
+			filters: jewerly.PaymentDiscrepancyFilters{Limit: 20, Resolved: null.BoolFrom(false)},
+			mockBehavior: func(filters jewerly.PaymentDiscrepancyFilters) {
+				mock.ExpectQuery("SELECT (.+) FROM payment_discrepancies WHERE resolved = \\$1\\s+ORDER BY id DESC OFFSET \\$2 LIMIT \\$3").
+					WithArgs(false, 0, 20).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(2, 5, "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11", jewerly.DiscrepancyAmountMismatch, "created", "sale-complete",
+							"provider charged 100.00 ILS", false, createdAt))
+			},
+			want: []jewerly.PaymentDiscrepancy{
+				{Id: 2, OrderId: 5, TransactionId: "0b4b1b0e-6b3e-4bd7-9a4c-2f1d5f4e8c11", Kind: jewerly.DiscrepancyAmountMismatch,
+					LocalStatus: "created", ProviderStatus: "sale-complete", Details: "provider charged 100.00 ILS", CreatedAt: createdAt},
+			},
+		},
+		{
+			name:    "Empty",
+			filters: jewerly.PaymentDiscrepancyFilters{Limit: 20, Offset: 20},
+			mockBehavior: func(filters jewerly.PaymentDiscrepancyFilters) {
+				mock.ExpectQuery("SELECT (.+) FROM payment_discrepancies\\s+ORDER BY id DESC OFFSET \\$1 LIMIT \\$2").
+					WithArgs(20, 20).WillReturnRows(sqlmock.NewRows(columns))
+			},
+			want: []jewerly.PaymentDiscrepancy{},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.filters)
+
+			got, err := r.GetDiscrepancies(testCase.filters)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"time"
+)
+
+type ReminderRepository struct {
+	db *sqlx.DB
+}
+
+func NewReminderRepository(db *sqlx.DB) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+// GetAbandonedCarts returns not empty carts of customers, that were last updated in the (from, to) period and weren't reminded yet.
+func (r *ReminderRepository) GetAbandonedCarts(from, to time.Time) ([]jewerly.CartReminder, error) {
+	var carts []jewerly.CartReminder
+
+	query := fmt.Sprintf(`SELECT c.id, u.first_name, u.email FROM %[1]s c JOIN %[2]s u ON u.id = c.user_id
+							WHERE c.updated_at > $1 AND c.updated_at < $2 AND c.reminder_sent_at IS NULL
+							AND EXISTS (SELECT 1 FROM %[3]s ci WHERE ci.cart_id = c.id)
+							AND NOT EXISTS (SELECT 1 FROM %[4]s eo WHERE eo.email = lower(u.email)) ORDER BY c.id`,
+		cartsTable, usersTable, cartItemsTable, emailOptOutsTable)
+	err := r.db.Select(&carts, query, from, to)
+
+	return carts, err
+}
+
+// GetUnpaidOrders returns new orders placed in the (from, to) period, that didn't receive any of paidNotifyTypes callbacks
+// and weren't reminded yet.
+func (r *ReminderRepository) GetUnpaidOrders(from, to time.Time, paidNotifyTypes []string) ([]jewerly.OrderReminder, error) {
+	var orders []jewerly.OrderReminder
+
+	query := fmt.Sprintf(`SELECT o.id, o.first_name, o.email, o.total_cost, o.currency, t.payment_url FROM %[1]s o JOIN %[2]s t ON t.order_id = o.id
+							WHERE o.ordered_at > $1 AND o.ordered_at < $2 AND o.status = $3 AND o.payment_reminder_sent_at IS NULL
+							AND t.payment_url IS NOT NULL
+							AND NOT EXISTS (SELECT 1 FROM %[3]s th WHERE th.uuid = t.uuid AND th.status = ANY($4))
+							AND NOT EXISTS (SELECT 1 FROM %[4]s eo WHERE eo.email = lower(o.email)) ORDER BY o.id`,
+		ordersTable, transactionsTable, transactionsHistoryTable, emailOptOutsTable)
+	err := r.db.Select(&orders, query, from, to, jewerly.OrderStatusNew, pq.Array(paidNotifyTypes))
+
+	return orders, err
+}
+
+// MarkCartReminded returns false if the reminder was already sent, so each reminder is sent only once
+// even if several instances are running.
+func (r *ReminderRepository) MarkCartReminded(cartId int) (bool, error) {
+	return r.mark(fmt.Sprintf("UPDATE %s SET reminder_sent_at=NOW() WHERE id=$1 AND reminder_sent_at IS NULL", cartsTable), cartId)
+}
+
+func (r *ReminderRepository) MarkOrderReminded(orderId int) (bool, error) {
+	return r.mark(fmt.Sprintf("UPDATE %s SET payment_reminder_sent_at=NOW() WHERE id=$1 AND payment_reminder_sent_at IS NULL", ordersTable), orderId)
+}
+
+func (r *ReminderRepository) mark(query string, id int) (bool, error) {
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *ReminderRepository) OptOut(email string) error {
+	_, err := r.db.Exec(fmt.Sprintf("INSERT INTO %s (email) VALUES ($1) ON CONFLICT (email) DO NOTHING", emailOptOutsTable), email)
+	return err
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"testing"
+	"time"
+)
+
+func TestReminderRepository_GetUnpaidOrders(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewReminderRepository(db)
+
+	to := time.Now()
+	from := to.Add(-time.Hour * 24)
+	notifyTypes := []string{"sale-complete"}
+
+	mock.ExpectQuery("SELECT o.id, o.first_name, o.email, o.total_cost, o.currency, t.payment_url FROM orders o JOIN transactions t (.+) "+
+		"AND NOT EXISTS \\(SELECT 1 FROM transactions_history th (.+)\\) AND NOT EXISTS \\(SELECT 1 FROM email_opt_outs eo (.+)\\)").
+		WithArgs(from, to, jewerly.OrderStatusNew, pq.Array(notifyTypes)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "email", "total_cost", "currency", "payment_url"}).
+			AddRow(1, "Vasya", "vasya@pupkin.com", 500, "USD", "http://payment.link"))
+
+	got, err := r.GetUnpaidOrders(from, to, notifyTypes)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []jewerly.OrderReminder{
+		{OrderId: 1, FirstName: "Vasya", Email: "vasya@pupkin.com", TotalCost: 50000, Currency: "USD", PaymentURL: "http://payment.link"},
+	}, got)
+}
+
+func TestReminderRepository_MarkCartReminded(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewReminderRepository(db)
+
+	testTable := []struct {
+		name     string
+		affected int64
+		want     bool
+	}{
+		{
+			name:     "Ok",
+			affected: 1,
+			want:     true,
+		},
+		{
+			name:     "Already Reminded",
+			affected: 0,
+			want:     false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mock.ExpectExec("UPDATE carts SET reminder_sent_at=NOW\\(\\) WHERE id=\\$1 AND reminder_sent_at IS NULL").
+				WithArgs(1).WillReturnResult(sqlmock.NewResult(0, testCase.affected))
+
+			got, err := r.MarkCartReminded(1)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+)
+
+// activeSaleCondition is true while the product has sale price and the sale period includes the current time.
+const activeSaleCondition = `(p.sale_price IS NOT NULL AND (p.sale_starts_at IS NULL OR p.sale_starts_at <= NOW())
+							AND (p.sale_ends_at IS NULL OR p.sale_ends_at > NOW()))`
+
+var (
+	productSaleColumns = "p.sale_price, p.sale_starts_at, p.sale_ends_at, " + activeSaleCondition + " as on_sale"
+
+	// currentPriceColumn is the price the product is sold for at the moment.
+	currentPriceColumn = fmt.Sprintf("CASE WHEN %s THEN p.sale_price ELSE p.price END", activeSaleCondition)
+)
+
+// SetCategorySale updates sale of all products in the category and returns the number of updated products.
+// Products which price with the discount is rounded to zero are skipped.
+func (r *ProductRepository) SetCategorySale(inp jewerly.BulkSaleInput) (int, error) {
+	query := fmt.Sprintf(`UPDATE %s SET sale_price = ROUND(price * (100 - $1::numeric) / 100, 2), sale_starts_at = $2, sale_ends_at = $3
+						WHERE category_id = $4 AND ROUND(price * (100 - $1::numeric) / 100, 2) > 0`, productsTable)
+	args := []interface{}{inp.DiscountPercent, inp.StartsAt, inp.EndsAt, inp.CategoryId}
+
+	if inp.DiscountPercent == 0 {
+		query = fmt.Sprintf("UPDATE %s SET sale_price = NULL, sale_starts_at = NULL, sale_ends_at = NULL WHERE category_id = $1", productsTable)
+		args = []interface{}{inp.CategoryId}
+	}
+
+	res, err := r.db.Exec(query, args...)
+	if err != nil {
+		logrus.Errorf("[Bulk Sale] update products sale error: %s", err.Error())
+		return 0, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	return int(affected), err
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"gopkg.in/guregu/null.v3"
+	"testing"
+	"time"
+)
+
+func TestProductRepository_SetCategorySale(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewProductRepository(db)
+
+	endsAt := time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	type mockBehavior func(inp jewerly.BulkSaleInput)
+
+	testTable := []struct {
+		name         string
+		input        jewerly.BulkSaleInput
+		mockBehavior mockBehavior
+		want         int
+		wantErr      bool
+	}{
+		{
+			name:  "Ok",
+			input: jewerly.BulkSaleInput{CategoryId: jewerly.CategoryRings, DiscountPercent: 20, EndsAt: null.TimeFrom(endsAt)},
+			mockBehavior: func(inp jewerly.BulkSaleInput) {
+				mock.ExpectExec("UPDATE products SET sale_price = ROUND\\(price \\* \\(100 - \\$1::numeric\\) / 100, 2\\), sale_starts_at = \\$2, "+
+					"sale_ends_at = \\$3 WHERE category_id = \\$4 AND ROUND\\(price \\* \\(100 - \\$1::numeric\\) / 100, 2\\) > 0").
+					WithArgs(inp.DiscountPercent, inp.StartsAt, inp.EndsAt, inp.CategoryId).WillReturnResult(sqlmock.NewResult(0, 12))
+			},
+			want: 12,
+		},
+		{
+			name:  "Remove Sale",
+			input: jewerly.BulkSaleInput{CategoryId: jewerly.CategoryRings},
+			mockBehavior: func(inp jewerly.BulkSaleInput) {
+				mock.ExpectExec("UPDATE products SET sale_price = NULL, sale_starts_at = NULL, sale_ends_at = NULL WHERE category_id = \\$1").
+					WithArgs(inp.CategoryId).WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+			want: 3,
+		},
+		{
+			name:  "Update Error",
+			input: jewerly.BulkSaleInput{CategoryId: jewerly.CategoryRings, DiscountPercent: 20},
+			mockBehavior: func(inp jewerly.BulkSaleInput) {
+				mock.ExpectExec("UPDATE products").WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			got, err := r.SetCategorySale(testCase.input)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"strings"
+)
+
+const shippingMethodColumns = "m.id, m.zone_id, m.name, m.type, m.free_from, m.active"
+
+// shippingZoneRow scans postgres varchar[] column, that can't be mapped to the countries slice directly.
+type shippingZoneRow struct {
+	jewerly.ShippingZone
+	Countries pq.StringArray `db:"countries"`
+}
+
+type shippingRateRow struct {
+	MethodId int `db:"method_id"`
+	jewerly.ShippingRate
+}
+
+type ShippingRepository struct {
+	db *sqlx.DB
+}
+
+func NewShippingRepository(db *sqlx.DB) *ShippingRepository {
+	return &ShippingRepository{db: db}
+}
+
+func (r *ShippingRepository) CreateZone(inp jewerly.CreateShippingZoneInput) (int, error) {
+	var id int
+	err := r.db.QueryRow(fmt.Sprintf("INSERT INTO %s (name, countries) VALUES ($1, $2) RETURNING id", shippingZonesTable),
+		inp.Name, pq.Array(inp.Countries)).Scan(&id)
+
+	return id, err
+}
+
+// GetZones returns all zones with their methods, including inactive ones.
+func (r *ShippingRepository) GetZones() ([]jewerly.ShippingZone, error) {
+	var rows []shippingZoneRow
+	err := r.db.Select(&rows, fmt.Sprintf("SELECT id, name, countries FROM %s ORDER BY id", shippingZonesTable))
+	if err != nil {
+		logrus.Errorf("failed to get shipping zones: %s", err.Error())
+		return nil, err
+	}
+
+	var methods []jewerly.ShippingMethod
+	err = r.db.Select(&methods, fmt.Sprintf("SELECT %s FROM %s m ORDER BY m.id", shippingMethodColumns, shippingMethodsTable))
+	if err != nil {
+		logrus.Errorf("failed to get shipping methods: %s", err.Error())
+		return nil, err
+	}
+
+	if err := r.setMethodsRates(methods); err != nil {
+		return nil, err
+	}
+
+	positions := make(map[int]int, len(rows))
+	zones := make([]jewerly.ShippingZone, len(rows))
+	for i, row := range rows {
+		zones[i] = row.ShippingZone
+		zones[i].Countries = row.Countries
+		zones[i].Methods = make([]jewerly.ShippingMethod, 0)
+		positions[row.Id] = i
+	}
+
+	for _, method := range methods {
+		i := positions[method.ZoneId]
+		zones[i].Methods = append(zones[i].Methods, method)
+	}
+
+	return zones, nil
+}
+
+func (r *ShippingRepository) UpdateZone(id int, inp jewerly.UpdateShippingZoneInput) error {
+	argId := 1
+	args := make([]interface{}, 0)
+	updateValues := make([]string, 0)
+
+	if inp.Name.Valid {
+		updateValues = append(updateValues, fmt.Sprintf("name=$%d", argId))
+		args = append(args, inp.Name.String)
+		argId++
+	}
+
+	if inp.Countries != nil {
+		updateValues = append(updateValues, fmt.Sprintf("countries=$%d", argId))
+		args = append(args, pq.Array(*inp.Countries))
+		argId++
+	}
+
+	args = append(args, id)
+	res, err := r.db.Exec(fmt.Sprintf("UPDATE %s SET %s WHERE id = $%d", shippingZonesTable, strings.Join(updateValues, ", "), argId), args...)
+	if err != nil {
+		logrus.Errorf("failed to update shipping zone: %s", err.Error())
+		return err
+	}
+
+	return checkShippingAffected(res, jewerly.ErrShippingZoneNotFound)
+}
+
+// DeleteZone deletes the zone with all its methods.
+func (r *ShippingRepository) DeleteZone(id int) error {
+	res, err := r.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = $1", shippingZonesTable), id)
+	if err != nil {
+		return err
+	}
+
+	return checkShippingAffected(res, jewerly.ErrShippingZoneNotFound)
+}
+
+func (r *ShippingRepository) CreateMethod(inp jewerly.CreateShippingMethodInput) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	err = tx.QueryRow(fmt.Sprintf("INSERT INTO %s (zone_id, name, type, free_from) VALUES ($1, $2, $3, $4) RETURNING id", shippingMethodsTable),
+		inp.ZoneId, inp.Name, inp.Type, inp.FreeFrom).Scan(&id)
+	if err != nil {
+		logrus.Errorf("failed to create shipping method: %s", err.Error())
+		tx.Rollback()
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == foreignKeyViolationCode {
+			return 0, jewerly.ErrShippingZoneNotFound
+		}
+
+		return 0, err
+	}
+
+	if err := createShippingRates(tx, id, inp.Rates); err != nil {
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+func (r *ShippingRepository) UpdateMethod(id int, inp jewerly.UpdateShippingMethodInput) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	argId := 1
+	args := make([]interface{}, 0)
+	updateValues := make([]string, 0)
+
+	if inp.Name.Valid {
+		updateValues = append(updateValues, fmt.Sprintf("name=$%d", argId))
+		args = append(args, inp.Name.String)
+		argId++
+	}
+
+	if inp.Type.Valid {
+		updateValues = append(updateValues, fmt.Sprintf("type=$%d", argId))
+		args = append(args, inp.Type.String)
+		argId++
+	}
+
+	if inp.RemoveFreeFrom {
+		updateValues = append(updateValues, "free_from=NULL")
+	} else if inp.FreeFrom.Valid {
+		updateValues = append(updateValues, fmt.Sprintf("free_from=$%d", argId))
+		args = append(args, inp.FreeFrom.Money)
+		argId++
+	}
+
+	if inp.Active.Valid {
+		updateValues = append(updateValues, fmt.Sprintf("active=$%d", argId))
+		args = append(args, inp.Active.Bool)
+		argId++
+	}
+
+	// id is updated to itself when only rates are replaced, so missing method is still detected
+	updateValues = append(updateValues, "id=id")
+
+	args = append(args, id)
+	res, err := tx.Exec(fmt.Sprintf("UPDATE %s SET %s WHERE id = $%d", shippingMethodsTable, strings.Join(updateValues, ", "), argId), args...)
+	if err != nil {
+		logrus.Errorf("failed to update shipping method: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	if err := checkShippingAffected(res, jewerly.ErrShippingMethodNotFound); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if inp.Rates != nil {
+		if _, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE method_id = $1", shippingRatesTable), id); err != nil {
+			logrus.Errorf("failed to delete shipping rates: %s", err.Error())
+			tx.Rollback()
+			return err
+		}
+
+		if err := createShippingRates(tx, id, *inp.Rates); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *ShippingRepository) DeleteMethod(id int) error {
+	res, err := r.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = $1", shippingMethodsTable), id)
+	if err != nil {
+		return err
+	}
+
+	return checkShippingAffected(res, jewerly.ErrShippingMethodNotFound)
+}
+
+// GetCountryMethods returns active methods of the zone the country belongs to,
+// zone without countries is used when the country isn't listed in any zone.
+func (r *ShippingRepository) GetCountryMethods(country string) ([]jewerly.ShippingMethod, error) {
+	var methods []jewerly.ShippingMethod
+	err := r.db.Select(&methods, fmt.Sprintf(`SELECT %s FROM %s m WHERE m.active AND m.zone_id = (
+									SELECT z.id FROM %s z WHERE $1 = ANY(z.countries) OR cardinality(z.countries) = 0
+									ORDER BY cardinality(z.countries) = 0, z.id LIMIT 1) ORDER BY m.id`,
+		shippingMethodColumns, shippingMethodsTable, shippingZonesTable), country)
+	if err != nil {
+		logrus.Errorf("failed to get shipping methods: %s", err.Error())
+		return nil, err
+	}
+
+	return methods, r.setMethodsRates(methods)
+}
+
+// setMethodsRates loads rates of all methods in one query.
+func (r *ShippingRepository) setMethodsRates(methods []jewerly.ShippingMethod) error {
+	if len(methods) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(methods))
+	positions := make(map[int]int, len(methods))
+	for i := range methods {
+		ids[i] = methods[i].Id
+		positions[methods[i].Id] = i
+		methods[i].Rates = make([]jewerly.ShippingRate, 0)
+	}
+
+	var rows []shippingRateRow
+	err := r.db.Select(&rows, fmt.Sprintf(`SELECT method_id, min_weight, min_order_sum, cost FROM %s WHERE method_id = ANY($1)
+									ORDER BY min_weight, min_order_sum`, shippingRatesTable), pq.Array(ids))
+	if err != nil {
+		logrus.Errorf("failed to get shipping rates: %s", err.Error())
+		return err
+	}
+
+	for _, row := range rows {
+		i := positions[row.MethodId]
+		methods[i].Rates = append(methods[i].Rates, row.ShippingRate)
+	}
+
+	return nil
+}
+
+func createShippingRates(tx *sql.Tx, methodId int, rates []jewerly.ShippingRate) error {
+	values := make([]string, len(rates))
+	args := []interface{}{methodId}
+	for i, rate := range rates {
+		values[i] = fmt.Sprintf("($1, $%d, $%d, $%d)", len(args)+1, len(args)+2, len(args)+3)
+		args = append(args, rate.MinWeight, rate.MinOrderSum, rate.Cost)
+	}
+
+	_, err := tx.Exec(fmt.Sprintf("INSERT INTO %s (method_id, min_weight, min_order_sum, cost) VALUES %s", shippingRatesTable,
+		strings.Join(values, ", ")), args...)
+	if err != nil {
+		logrus.Errorf("failed to create shipping rates: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func checkShippingAffected(res sql.Result, notFoundErr error) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return notFoundErr
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"gopkg.in/guregu/null.v3"
+	"testing"
+)
+
+var shippingMethodRowColumns = []string{"id", "zone_id", "name", "type", "free_from", "active"}
+
+func TestShippingRepository_GetCountryMethods(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewShippingRepository(db)
+
+	testTable := []struct {
+		name         string
+		country      string
+		mockBehavior func(country string)
+		want         []jewerly.ShippingMethod
+		wantErr      bool
+	}{
+		{
+			name:    "Ok",
+			country: "IL",
+			mockBehavior: func(country string) {
+				mock.ExpectQuery("SELECT (.+) FROM shipping_methods m WHERE m.active AND m.zone_id = (.+) FROM shipping_zones z").
+					WithArgs(country).WillReturnRows(sqlmock.NewRows(shippingMethodRowColumns).
+					AddRow(1, 2, "Israel Post", jewerly.ShippingTypeStandard, "150.00", true).
+					AddRow(3, 2, "Courier", jewerly.ShippingTypeExpress, nil, true))
+
+				mock.ExpectQuery("SELECT method_id, min_weight, min_order_sum, cost FROM shipping_rates WHERE method_id = ANY\\(\\$1\\)").
+					WithArgs(pq.Array([]int{1, 3})).WillReturnRows(sqlmock.NewRows([]string{"method_id", "min_weight", "min_order_sum", "cost"}).
+					AddRow(1, 0, "0.00", "10.00").
+					AddRow(3, 0, "0.00", "25.00").
+					AddRow(1, 500, "0.00", "15.50"))
+			},
+			want: []jewerly.ShippingMethod{
+				{Id: 1, ZoneId: 2, Name: "Israel Post", Type: jewerly.ShippingTypeStandard, FreeFrom: jewerly.NullMoneyFrom(15000), Active: true,
+					Rates: []jewerly.ShippingRate{{Cost: 1000}, {MinWeight: 500, Cost: 1550}}},
+				{Id: 3, ZoneId: 2, Name: "Courier", Type: jewerly.ShippingTypeExpress, Active: true,
+					Rates: []jewerly.ShippingRate{{Cost: 2500}}},
+			},
+		},
+		{
+			name:    "No Zone",
+			country: "JP",
+			mockBehavior: func(country string) {
+				mock.ExpectQuery("SELECT (.+) FROM shipping_methods m").WithArgs(country).
+					WillReturnRows(sqlmock.NewRows(shippingMethodRowColumns))
+			},
+		},
+		{
+			name:    "Failure",
+			country: "IL",
+			mockBehavior: func(country string) {
+				mock.ExpectQuery("SELECT (.+) FROM shipping_methods m").WithArgs(country).
+					WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.country)
+
+			got, err := r.GetCountryMethods(testCase.country)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestShippingRepository_UpdateMethod(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewShippingRepository(db)
+
+	rates := []jewerly.ShippingRate{{Cost: 1000}, {MinWeight: 500, Cost: 1500}}
+
+	testTable := []struct {
+		name         string
+		id           int
+		input        jewerly.UpdateShippingMethodInput
+		mockBehavior func(id int, input jewerly.UpdateShippingMethodInput)
+		wantErr      error
+	}{
+		{
+			name:  "Ok",
+			id:    1,
+			input: jewerly.UpdateShippingMethodInput{Name: null.StringFrom("Courier"), FreeFrom: jewerly.NullMoneyFrom(20000)},
+			mockBehavior: func(id int, input jewerly.UpdateShippingMethodInput) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE shipping_methods SET name=\\$1, free_from=\\$2, id=id WHERE id = \\$3").
+					WithArgs("Courier", jewerly.Money(20000), id).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Replace Rates",
+			id:    1,
+			input: jewerly.UpdateShippingMethodInput{RemoveFreeFrom: true, Rates: &rates},
+			mockBehavior: func(id int, input jewerly.UpdateShippingMethodInput) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE shipping_methods SET free_from=NULL, id=id WHERE id = \\$1").
+					WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM shipping_rates WHERE method_id = \\$1").WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec("INSERT INTO shipping_rates \\(method_id, min_weight, min_order_sum, cost\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\), \\(\\$1, \\$5, \\$6, \\$7\\)").
+					WithArgs(id, 0, jewerly.Money(0), jewerly.Money(1000), 500, jewerly.Money(0), jewerly.Money(1500)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Not Found",
+			id:    404,
+			input: jewerly.UpdateShippingMethodInput{Rates: &rates},
+			mockBehavior: func(id int, input jewerly.UpdateShippingMethodInput) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE shipping_methods SET id=id WHERE id = \\$1").
+					WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: jewerly.ErrShippingMethodNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.id, testCase.input)
+
+			err := r.UpdateMethod(testCase.id, testCase.input)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+)
+
+type TaxRepository struct {
+	db *sqlx.DB
+}
+
+func NewTaxRepository(db *sqlx.DB) *TaxRepository {
+	return &TaxRepository{db: db}
+}
+
+func (r *TaxRepository) GetRates() ([]jewerly.TaxRate, error) {
+	var rates []jewerly.TaxRate
+	err := r.db.Select(&rates, fmt.Sprintf("SELECT country, rate, inclusive, updated_at FROM %s ORDER BY country", taxRatesTable))
+
+	return rates, err
+}
+
+func (r *TaxRepository) GetRate(country string) (jewerly.TaxRate, error) {
+	var rate jewerly.TaxRate
+	err := r.db.Get(&rate, fmt.Sprintf("SELECT country, rate, inclusive, updated_at FROM %s WHERE country = $1", taxRatesTable), country)
+	if err == sql.ErrNoRows {
+		return rate, jewerly.ErrTaxRateNotFound
+	}
+
+	return rate, err
+}
+
+func (r *TaxRepository) SetRate(rate jewerly.TaxRate) error {
+	_, err := r.db.Exec(fmt.Sprintf(`INSERT INTO %s (country, rate, inclusive) VALUES ($1, $2, $3)
+							ON CONFLICT (country) DO UPDATE SET rate = excluded.rate, inclusive = excluded.inclusive, updated_at = NOW()`,
+		taxRatesTable), rate.Country, rate.Rate, rate.Inclusive)
+
+	return err
+}
+
+func (r *TaxRepository) DeleteRate(country string) error {
+	res, err := r.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE country = $1", taxRatesTable), country)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return jewerly.ErrTaxRateNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"testing"
+	"time"
+)
+
+func TestTaxRepository_GetRate(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewTaxRepository(db)
+
+	updatedAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name         string
+		country      string
+		mockBehavior func(country string)
+		want         jewerly.TaxRate
+		wantErr      error
+	}{
+		{
+			name:    "Ok",
+			country: "IL",
+			mockBehavior: func(country string) {
+				mock.ExpectQuery("SELECT country, rate, inclusive, updated_at FROM tax_rates WHERE country = \\$1").WithArgs(country).
+					WillReturnRows(sqlmock.NewRows([]string{"country", "rate", "inclusive", "updated_at"}).AddRow(country, 17, true, updatedAt))
+			},
+			want: jewerly.TaxRate{Country: "IL", Rate: 17, Inclusive: true, UpdatedAt: updatedAt},
+		},
+		{
+			name:    "Not Found",
+			country: "US",
+			mockBehavior: func(country string) {
+				mock.ExpectQuery("SELECT country, rate, inclusive, updated_at FROM tax_rates WHERE country = \\$1").WithArgs(country).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: jewerly.ErrTaxRateNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.country)
+
+			got, err := r.GetRate(testCase.country)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.wantErr == nil {
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTaxRepository_SetRate(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewTaxRepository(db)
+
+	testTable := []struct {
+		name         string
+		rate         jewerly.TaxRate
+		mockBehavior func(rate jewerly.TaxRate)
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			rate: jewerly.TaxRate{Country: "DE", Rate: 19, Inclusive: true},
+			mockBehavior: func(rate jewerly.TaxRate) {
+				mock.ExpectExec("INSERT INTO tax_rates \\(country, rate, inclusive\\) VALUES (.+) ON CONFLICT \\(country\\) DO UPDATE").
+					WithArgs(rate.Country, rate.Rate, rate.Inclusive).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Failure",
+			rate: jewerly.TaxRate{Country: "DE", Rate: 19},
+			mockBehavior: func(rate jewerly.TaxRate) {
+				mock.ExpectExec("INSERT INTO tax_rates").WithArgs(rate.Country, rate.Rate, rate.Inclusive).
+					WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.rate)
+
+			err := r.SetRate(testCase.rate)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+)
+
+const uniqueViolationCode = "23505"
+
+type UserRepository struct {
+	db *sqlx.DB
+}
+
+func NewUserRepository(db *sqlx.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) Create(user jewerly.User) (int64, error) {
+	var id int64
+
+	row := r.db.QueryRow(fmt.Sprintf("INSERT INTO %s (email, password_hash, first_name, last_name) VALUES ($1, $2, $3, $4) RETURNING id",
+		usersTable), user.Email, user.PasswordHash, user.FirstName, user.LastName)
+	if err := row.Scan(&id); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolationCode {
+			return 0, jewerly.ErrUserAlreadyExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *UserRepository) GetByEmail(email string) (jewerly.User, error) {
+	var user jewerly.User
+
+	err := r.db.Get(&user, fmt.Sprintf("SELECT id, email, password_hash, first_name, last_name, registered_at, email_verified FROM %s WHERE email=$1",
+		usersTable), email)
+	if err == sql.ErrNoRows {
+		return user, jewerly.ErrUserNotFound
+	}
+
+	return user, err
+}
+
+func (r *UserRepository) CreateToken(token jewerly.UserToken) error {
+	_, err := r.db.Exec(fmt.Sprintf("INSERT INTO %s (user_id, token_hash, purpose, expires_at) VALUES ($1, $2, $3, $4)", userTokensTable),
+		token.UserId, token.Hash, token.Purpose, token.ExpiresAt)
+	return err
+}
+
+func (r *UserRepository) VerifyEmail(tokenHash string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	userId, err := r.useToken(tx, tokenHash, jewerly.TokenPurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("UPDATE %s SET email_verified=true WHERE id=$1", usersTable), userId)
+	if err != nil {
+		logrus.Errorf("failed to set email verified: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *UserRepository) ResetPassword(tokenHash, passwordHash string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	userId, err := r.useToken(tx, tokenHash, jewerly.TokenPurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("UPDATE %s SET password_hash=$1 WHERE id=$2", usersTable), passwordHash, userId)
+	if err != nil {
+		logrus.Errorf("failed to update password: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	// password change invalidates all the other reset tokens sent before
+	_, err = tx.Exec(fmt.Sprintf("UPDATE %s SET used_at=NOW() WHERE user_id=$1 AND purpose=$2 AND used_at IS NULL", userTokensTable),
+		userId, jewerly.TokenPurposePasswordReset)
+	if err != nil {
+		logrus.Errorf("failed to invalidate password reset tokens: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// useToken marks token as used and returns id of its owner, expired or already used token is rejected.
+func (r *UserRepository) useToken(tx *sql.Tx, tokenHash, purpose string) (int64, error) {
+	var userId int64
+
+	err := tx.QueryRow(fmt.Sprintf(`UPDATE %s SET used_at=NOW() WHERE token_hash=$1 AND purpose=$2 AND used_at IS NULL AND expires_at > NOW()
+										RETURNING user_id`, userTokensTable), tokenHash, purpose).Scan(&userId)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return 0, jewerly.ErrInvalidUserToken
+		}
+		return 0, err
+	}
+
+	return userId, nil
+}
@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"testing"
+	"time"
+)
+
+func TestUserRepository_Create(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewUserRepository(db)
+
+	type mockBehavior func(user jewerly.User)
+
+	testTable := []struct {
+		name         string
+		user         jewerly.User
+		mockBehavior mockBehavior
+		want         int64
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			user: jewerly.User{Email: "test@test.com", PasswordHash: "hash", FirstName: "Test", LastName: "Test"},
+			mockBehavior: func(user jewerly.User) {
+				mock.ExpectQuery("INSERT INTO users").
+					WithArgs(user.Email, user.PasswordHash, user.FirstName, user.LastName).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			want: 1,
+		},
+		{
+			name: "Email Taken",
+			user: jewerly.User{Email: "test@test.com", PasswordHash: "hash", FirstName: "Test", LastName: "Test"},
+			mockBehavior: func(user jewerly.User) {
+				mock.ExpectQuery("INSERT INTO users").
+					WithArgs(user.Email, user.PasswordHash, user.FirstName, user.LastName).
+					WillReturnError(&pq.Error{Code: "23505"})
+			},
+			wantErr: jewerly.ErrUserAlreadyExists,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.user)
+
+			got, err := r.Create(testCase.user)
+			if testCase.wantErr != nil {
+				assert.Equal(t, testCase.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_GetByEmail(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewUserRepository(db)
+
+	columns := []string{"id", "email", "password_hash", "first_name", "last_name", "registered_at", "email_verified"}
+
+	testTable := []struct {
+		name         string
+		email        string
+		mockBehavior func(email string)
+		want         jewerly.User
+		wantErr      error
+	}{
+		{
+			name:  "Ok",
+			email: "test@test.com",
+			mockBehavior: func(email string) {
+				mock.ExpectQuery("SELECT id, email, password_hash, first_name, last_name, registered_at, email_verified FROM users WHERE email=\\$1").WithArgs(email).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, email, "hash", "Test", "Test", time.Time{}, true))
+			},
+			want: jewerly.User{Id: 1, Email: "test@test.com", PasswordHash: "hash", FirstName: "Test", LastName: "Test", EmailVerified: true},
+		},
+		{
+			name:  "Not Found",
+			email: "test@test.com",
+			mockBehavior: func(email string) {
+				mock.ExpectQuery("SELECT id, email, password_hash, first_name, last_name, registered_at, email_verified FROM users WHERE email=\\$1").WithArgs(email).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			wantErr: jewerly.ErrUserNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.email)
+
+			got, err := r.GetByEmail(testCase.email)
+			if testCase.wantErr != nil {
+				assert.Equal(t, testCase.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_ResetPassword(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewUserRepository(db)
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("UPDATE user_tokens SET used_at=NOW\\(\\) WHERE token_hash=\\$1 AND purpose=\\$2 AND used_at IS NULL AND expires_at > NOW\\(\\)").
+					WithArgs("token_hash", jewerly.TokenPurposePasswordReset).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1))
+				mock.ExpectExec("UPDATE users SET password_hash=\\$1 WHERE id=\\$2").
+					WithArgs("password_hash", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE user_tokens SET used_at=NOW\\(\\) WHERE user_id=\\$1").
+					WithArgs(1, jewerly.TokenPurposePasswordReset).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Used Or Expired Token",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("UPDATE user_tokens").
+					WithArgs("token_hash", jewerly.TokenPurposePasswordReset).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+				mock.ExpectRollback()
+			},
+			wantErr: jewerly.ErrInvalidUserToken,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			err := r.ResetPassword("token_hash", "password_hash")
+			if testCase.wantErr != nil {
+				assert.Equal(t, testCase.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_VerifyEmail(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewUserRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE user_tokens SET used_at=NOW\\(\\)").
+		WithArgs("token_hash", jewerly.TokenPurposeEmailVerification).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1))
+	mock.ExpectExec("UPDATE users SET email_verified=true WHERE id=\\$1").
+		WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.VerifyEmail("token_hash"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"strings"
+)
+
+func (r *ProductRepository) GetProductsVariants(productIds []int) (map[int][]jewerly.ProductVariant, error) {
+	return selectProductsVariants(r.db, productIds)
+}
+
+// selectProductsVariants loads variants for all products in one query, grouped by product id.
+func selectProductsVariants(db *sqlx.DB, productIds []int) (map[int][]jewerly.ProductVariant, error) {
+	variants := make(map[int][]jewerly.ProductVariant, len(productIds))
+	if len(productIds) == 0 {
+		return variants, nil
+	}
+
+	var rows []jewerly.ProductVariant
+	err := db.Select(&rows, fmt.Sprintf(`SELECT v.id, v.product_id, v.sku, v.option_type, v.option_value, v.price_delta,
+									%s + v.price_delta as price, v.stock, v.in_stock FROM %s v JOIN %s p ON p.id = v.product_id
+									WHERE v.product_id = ANY($1) ORDER BY v.id`, currentPriceColumn, productVariantsTable, productsTable),
+		pq.Array(productIds))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		variants[row.ProductId] = append(variants[row.ProductId], row)
+	}
+
+	return variants, nil
+}
+
+func (r *ProductRepository) CreateVariant(productId int, inp jewerly.CreateVariantInput) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	err = tx.QueryRow(fmt.Sprintf(`INSERT INTO %s (product_id, sku, option_type, option_value, price_delta, stock)
+									VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, productVariantsTable),
+		productId, inp.SKU, inp.Option, inp.Value, inp.PriceDelta, inp.Stock).Scan(&id)
+	if err != nil {
+		logrus.Errorf("[Create Variant] insert variant error: %s", err.Error())
+		tx.Rollback()
+		return 0, variantError(err)
+	}
+
+	if err := syncProductStock(tx, productId); err != nil {
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+func (r *ProductRepository) UpdateVariant(productId, variantId int, inp jewerly.UpdateVariantInput) error {
+	argId := 1
+	args := make([]interface{}, 0)
+	updateValues := make([]string, 0)
+
+	if inp.SKU.Valid {
+		updateValues = append(updateValues, fmt.Sprintf("sku=$%d", argId))
+		args = append(args, inp.SKU.String)
+		argId++
+	}
+
+	if inp.Value.Valid {
+		updateValues = append(updateValues, fmt.Sprintf("option_value=$%d", argId))
+		args = append(args, inp.Value.String)
+		argId++
+	}
+
+	if inp.PriceDelta.Valid {
+		updateValues = append(updateValues, fmt.Sprintf("price_delta=$%d", argId))
+		args = append(args, inp.PriceDelta.Money)
+		argId++
+	}
+
+	if inp.Stock.Valid {
+		updateValues = append(updateValues, fmt.Sprintf("stock=$%d", argId))
+		args = append(args, inp.Stock.Int64)
+		argId++
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	args = append(args, variantId, productId)
+	res, err := tx.Exec(fmt.Sprintf("UPDATE %s SET %s WHERE id = $%d AND product_id = $%d", productVariantsTable,
+		strings.Join(updateValues, ", "), argId, argId+1), args...)
+	if err != nil {
+		logrus.Errorf("[Update Variant] update variant error: %s", err.Error())
+		tx.Rollback()
+		return variantError(err)
+	}
+
+	if err := checkVariantAffected(tx, res); err != nil {
+		return err
+	}
+
+	if err := syncProductStock(tx, productId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ProductRepository) DeleteVariant(productId, variantId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND product_id = $2", productVariantsTable), variantId, productId)
+	if err != nil {
+		logrus.Errorf("[Delete Variant] delete variant error: %s", err.Error())
+		tx.Rollback()
+		return err
+	}
+
+	if err := checkVariantAffected(tx, res); err != nil {
+		return err
+	}
+
+	if err := syncProductStock(tx, productId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// syncProductStock keeps stock of the product equal to the stock of its variants, so in_stock of the product
+// shows whether any of variants is available. When the last variant is deleted the product stock is left as is.
+func syncProductStock(tx *sql.Tx, productId int) error {
+	_, err := tx.Exec(fmt.Sprintf(`UPDATE %[1]s SET stock = (SELECT sum(stock) FROM %[2]s WHERE product_id = $1)
+									WHERE id = $1 AND EXISTS (SELECT 1 FROM %[2]s WHERE product_id = $1)`,
+		productsTable, productVariantsTable), productId)
+	if err != nil {
+		logrus.Errorf("failed to sync product stock: %s", err.Error())
+		tx.Rollback()
+	}
+
+	return err
+}
+
+func checkVariantAffected(tx *sql.Tx, res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affected == 0 {
+		tx.Rollback()
+		return jewerly.ErrVariantNotFound
+	}
+
+	return nil
+}
+
+func variantError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case uniqueViolationCode:
+			return jewerly.ErrVariantAlreadyExists
+		case foreignKeyViolationCode:
+			return jewerly.ErrProductNotFound
+		}
+	}
+
+	return err
+}
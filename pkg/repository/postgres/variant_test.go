@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"gopkg.in/guregu/null.v3"
+	"testing"
+)
+
+func TestProductRepository_CreateVariant(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewProductRepository(db)
+
+	type mockBehavior func(productId int, inp jewerly.CreateVariantInput)
+
+	testTable := []struct {
+		name         string
+		productId    int
+		input        jewerly.CreateVariantInput
+		mockBehavior mockBehavior
+		want         int
+		wantErr      error
+	}{
+		{
+			name:      "Ok",
+			productId: 1,
+			input:     jewerly.CreateVariantInput{SKU: "R1-17", Option: jewerly.VariantOptionSize, Value: "17", PriceDelta: 1000, Stock: 2},
+			mockBehavior: func(productId int, inp jewerly.CreateVariantInput) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO product_variants").
+					WithArgs(productId, inp.SKU, inp.Option, inp.Value, inp.PriceDelta, inp.Stock).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectExec("UPDATE products SET stock = \\(SELECT sum\\(stock\\) FROM product_variants WHERE product_id = \\$1\\)\\s+WHERE id = \\$1 AND EXISTS \\(SELECT 1 FROM product_variants WHERE product_id = \\$1\\)").
+					WithArgs(productId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: 5,
+		},
+		{
+			name:      "Already Exists",
+			productId: 1,
+			input:     jewerly.CreateVariantInput{SKU: "R1-17", Option: jewerly.VariantOptionSize, Value: "17"},
+			mockBehavior: func(productId int, inp jewerly.CreateVariantInput) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO product_variants").
+					WithArgs(productId, inp.SKU, inp.Option, inp.Value, inp.PriceDelta, inp.Stock).
+					WillReturnError(&pq.Error{Code: "23505"})
+				mock.ExpectRollback()
+			},
+			wantErr: jewerly.ErrVariantAlreadyExists,
+		},
+		{
+			name:      "Product Not Found",
+			productId: 100,
+			input:     jewerly.CreateVariantInput{SKU: "R100-17", Option: jewerly.VariantOptionSize, Value: "17"},
+			mockBehavior: func(productId int, inp jewerly.CreateVariantInput) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO product_variants").
+					WithArgs(productId, inp.SKU, inp.Option, inp.Value, inp.PriceDelta, inp.Stock).
+					WillReturnError(&pq.Error{Code: "23503"})
+				mock.ExpectRollback()
+			},
+			wantErr: jewerly.ErrProductNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.productId, testCase.input)
+
+			got, err := r.CreateVariant(testCase.productId, testCase.input)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.Equal(t, testCase.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepository_UpdateVariant(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	r := NewProductRepository(db)
+
+	type args struct {
+		productId int
+		variantId int
+		input     jewerly.UpdateVariantInput
+	}
+
+	type mockBehavior func(args args)
+
+	testTable := []struct {
+		name         string
+		args         args
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			args: args{productId: 1, variantId: 5, input: jewerly.UpdateVariantInput{PriceDelta: jewerly.NullMoneyFrom(1500), Stock: null.IntFrom(3)}},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE product_variants SET price_delta=\\$1, stock=\\$2 WHERE id = \\$3 AND product_id = \\$4").
+					WithArgs(jewerly.Money(1500), 3, args.variantId, args.productId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE products SET stock").
+					WithArgs(args.productId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Not Found",
+			args: args{productId: 2, variantId: 5, input: jewerly.UpdateVariantInput{Stock: null.IntFrom(3)}},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE product_variants SET stock=\\$1 WHERE id = \\$2 AND product_id = \\$3").
+					WithArgs(3, args.variantId, args.productId).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: jewerly.ErrVariantNotFound,
+		},
+		{
+			name: "Failed To Sync Product Stock",
+			args: args{productId: 1, variantId: 5, input: jewerly.UpdateVariantInput{Stock: null.IntFrom(3)}},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE product_variants SET stock=\\$1").
+					WithArgs(3, args.variantId, args.productId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE products SET stock").
+					WithArgs(args.productId).WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.args)
+
+			err := r.UpdateVariant(testCase.args.productId, testCase.args.variantId, testCase.args.input)
+			assert.Equal(t, testCase.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -4,33 +4,127 @@ import (
 	"github.com/jmoiron/sqlx"
 	jewerly "github.com/zhashkevych/jewelry-shop-backend"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository/postgres"
+	"gopkg.in/guregu/null.v3"
+	"time"
 )
 
-// todo remove user from DB schema
-
 //go:generate mockgen -source=repositories.go -destination=mocks/mock.go
 
 type Admin interface {
 	Authorize(login, passwordHash string) error
 }
 
+type User interface {
+	Create(user jewerly.User) (int64, error)
+	GetByEmail(email string) (jewerly.User, error)
+	CreateToken(token jewerly.UserToken) error
+	VerifyEmail(tokenHash string) error
+	ResetPassword(tokenHash, passwordHash string) error
+}
+
 type Product interface {
 	Create(product jewerly.CreateProductInput) error
 	GetAll(filters jewerly.GetAllProductsFilters) (jewerly.ProductsList, error)
+	Search(filters jewerly.SearchProductsFilters) (jewerly.ProductsList, error)
 	GetById(id int, language string) (jewerly.ProductResponse, error)
 	Update(id int, inp jewerly.UpdateProductInput) error
 	Delete(id int) error
 	CreateImage(url, altText string) (int, error)
 	GetProductImages(productId int) ([]jewerly.Image, error)
+	GetProductsImages(productIds []int) (map[int][]jewerly.Image, error)
+	GetProductsVariants(productIds []int) (map[int][]jewerly.ProductVariant, error)
+	CreateVariant(productId int, inp jewerly.CreateVariantInput) (int, error)
+	UpdateVariant(productId, variantId int, inp jewerly.UpdateVariantInput) error
+	DeleteVariant(productId, variantId int) error
+	SetCategorySale(inp jewerly.BulkSaleInput) (int, error)
 }
 
 type Order interface {
 	Create(input jewerly.CreateOrderInput) (int, error)
 	GetOrderProducts(items []jewerly.OrderItem) ([]jewerly.ProductResponse, error)
-	CreateTransaction(transactionId, cardMask, status string) error
+	CreateTransaction(transactionId, eventId, cardMask, status string) error
+	SetPaymentURL(transactionId, url string) error
+	SetSaleId(transactionId, saleId string) error
+	GetPayment(orderId int) (jewerly.OrderPayment, error)
 	GetOrderId(transactionId string) (int, error)
 	GetAll(jewerly.GetAllOrdersFilters) (jewerly.OrderList, error)
 	GetById(id int) (jewerly.Order, error)
+	GetItemsDetails(orderIds []int, language string) (map[int][]jewerly.CustomerOrderItem, error)
+	UpdateStatus(orderId int, status, changedBy string) error
+	SetShipment(orderId int, inp jewerly.ShipOrderInput, changedBy string) error
+	CancelUnpaid(orderId int, changedBy string) error
+	GetUnpaidOrderIds(before time.Time, paidNotifyTypes []string) ([]int, error)
+	ReserveRefund(refund jewerly.Refund, refunded jewerly.Money) (int, error)
+	CompleteRefund(refund jewerly.Refund, transactionId string) error
+	CancelRefund(refundId int) error
+	CompleteAuthorization(orderId int, transactionId, eventId, cardMask, notifyType, status, changedBy string) (bool, error)
+	GetStaleAuthorizedOrderIds(before time.Time) ([]int, error)
+}
+
+type Cart interface {
+	Create(token string, userId null.Int) (jewerly.Cart, error)
+	GetByToken(token string) (jewerly.Cart, error)
+	GetByUserId(userId int64) (jewerly.Cart, error)
+	AssignUser(cartId int, userId int64) error
+	Merge(fromCartId, toCartId int) error
+	GetItems(cartId int, language string) ([]jewerly.CartItem, error)
+	AddItem(cartId, productId int, variantId null.Int, quantity int) error
+	SetItemQuantity(cartId, productId int, variantId null.Int, quantity int) error
+	DeleteItem(cartId, productId int, variantId null.Int) error
+	Clear(cartId int) error
+}
+
+type Reminder interface {
+	GetAbandonedCarts(from, to time.Time) ([]jewerly.CartReminder, error)
+	GetUnpaidOrders(from, to time.Time, paidNotifyTypes []string) ([]jewerly.OrderReminder, error)
+	MarkCartReminded(cartId int) (bool, error)
+	MarkOrderReminded(orderId int) (bool, error)
+	OptOut(email string) error
+}
+
+type Reconciliation interface {
+	GetPendingTransactions(from, to time.Time, finalNotifyTypes []string) ([]jewerly.PendingTransaction, error)
+	CreateDiscrepancy(discrepancy jewerly.PaymentDiscrepancy) error
+	GetDiscrepancies(filters jewerly.PaymentDiscrepancyFilters) ([]jewerly.PaymentDiscrepancy, error)
+}
+
+type Promo interface {
+	Create(inp jewerly.CreatePromoCodeInput) (int, error)
+	GetAll() ([]jewerly.PromoCode, error)
+	GetById(id int) (jewerly.PromoCode, error)
+	GetByCode(code string) (jewerly.PromoCode, error)
+	Update(id int, inp jewerly.UpdatePromoCodeInput) error
+	Delete(id int) error
+	CountUsages(promoCodeId int, email string) (int, int, error)
+}
+
+type Currency interface {
+	GetRates() ([]jewerly.ExchangeRate, error)
+	GetRate(currency string) (jewerly.ExchangeRate, error)
+	SetRates(rates []jewerly.ExchangeRate) error
+}
+
+type Shipping interface {
+	CreateZone(inp jewerly.CreateShippingZoneInput) (int, error)
+	GetZones() ([]jewerly.ShippingZone, error)
+	UpdateZone(id int, inp jewerly.UpdateShippingZoneInput) error
+	DeleteZone(id int) error
+	CreateMethod(inp jewerly.CreateShippingMethodInput) (int, error)
+	UpdateMethod(id int, inp jewerly.UpdateShippingMethodInput) error
+	DeleteMethod(id int) error
+	GetCountryMethods(country string) ([]jewerly.ShippingMethod, error)
+}
+
+type Tax interface {
+	GetRates() ([]jewerly.TaxRate, error)
+	GetRate(country string) (jewerly.TaxRate, error)
+	SetRate(rate jewerly.TaxRate) error
+	DeleteRate(country string) error
+}
+
+type Invoice interface {
+	Create(orderId int, saveFile func(number int) (string, error)) (jewerly.Invoice, error)
+	GetByOrderId(orderId int) (jewerly.Invoice, error)
 }
 
 type Settings interface {
@@ -52,16 +146,34 @@ type PageText interface {
 
 type Repository struct {
 	Admin
+	User
 	Product
 	Order
+	Cart
+	Reminder
+	Reconciliation
+	Promo
+	Currency
+	Shipping
+	Tax
+	Invoice
 	Settings
 }
 
 func NewRepository(db *sqlx.DB) *Repository {
 	return &Repository{
-		Admin:    postgres.NewAdminRepository(db),
-		Product:  postgres.NewProductRepository(db),
-		Order:    postgres.NewOrderRepository(db),
-		Settings: postgres.NewSettingsRepository(db),
+		Admin:          postgres.NewAdminRepository(db),
+		User:           postgres.NewUserRepository(db),
+		Product:        postgres.NewProductRepository(db),
+		Order:          postgres.NewOrderRepository(db),
+		Cart:           postgres.NewCartRepository(db),
+		Reminder:       postgres.NewReminderRepository(db),
+		Reconciliation: postgres.NewReconciliationRepository(db),
+		Promo:          postgres.NewPromoRepository(db),
+		Currency:       postgres.NewCurrencyRepository(db),
+		Shipping:       postgres.NewShippingRepository(db),
+		Tax:            postgres.NewTaxRepository(db),
+		Invoice:        postgres.NewInvoiceRepository(db),
+		Settings:       postgres.NewSettingsRepository(db),
 	}
 }
@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      func() error
+}
+
+// Scheduler runs background jobs periodically until it's stopped.
+type Scheduler struct {
+	jobs   []job
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Add registers the job, it should be called before Start. Job with non-positive interval
+// (e.g. missing in config) is skipped, ticker can't be created for it.
+func (s *Scheduler) Add(name string, interval time.Duration, run func() error) {
+	if interval <= 0 {
+		logrus.Errorf("scheduled job %s is skipped: invalid interval %s", name, interval)
+		return
+	}
+
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, j)
+	}
+}
+
+// Stop prevents new job runs and waits for the running ones to finish until the context is done.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, j job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.run(); err != nil {
+				logrus.Errorf("scheduled job %s failed: %s", j.name, err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	var runs int32
+
+	s := NewScheduler()
+	s.Add("test", 10*time.Millisecond, func() error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	})
+	s.Start()
+
+	time.Sleep(55 * time.Millisecond)
+
+	err := s.Stop(context.Background())
+	assert.NoError(t, err)
+
+	stopped := atomic.LoadInt32(&runs)
+	assert.True(t, stopped >= 2)
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt32(&runs))
+}
+
+func TestScheduler_StopTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	s := NewScheduler()
+	s.Add("slow", time.Millisecond, func() error {
+		<-release
+		return nil
+	})
+	s.Start()
+
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, s.Stop(ctx))
+}
+
+func TestScheduler_InvalidInterval(t *testing.T) {
+	s := NewScheduler()
+	s.Add("zero", 0, func() error { return nil })
+	s.Add("negative", -time.Second, func() error { return nil })
+	s.Start()
+
+	assert.Empty(t, s.jobs)
+	assert.NoError(t, s.Stop(context.Background()))
+}
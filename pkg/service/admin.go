@@ -10,6 +10,8 @@ import (
 
 const (
 	tokenTTL = 12 * time.Hour
+
+	adminTokenAudience = "admin"
 )
 
 type AdminService struct {
@@ -31,29 +33,47 @@ func (s *AdminService) SignIn(login, password string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.StandardClaims{
 		ExpiresAt: time.Now().Add(tokenTTL).Unix(),
 		IssuedAt:  time.Now().Unix(),
+		Subject:   login,
+		Audience:  adminTokenAudience,
 	})
 
 	return token.SignedString(s.signingKey)
 }
 
-func (s *AdminService) ParseToken(token string) error {
-	t, err := jwt.Parse(token, func(token *jwt.Token) (i interface{}, err error) {
+// ParseToken returns login of the admin the token was issued for.
+func (s *AdminService) ParseToken(token string) (string, error) {
+	claims, err := parseToken(token, s.signingKey, adminTokenAudience)
+	if err != nil {
+		return "", err
+	}
+
+	return claims.Subject, nil
+}
+
+// parseToken verifies token signature and that it was issued for the audience,
+// so tokens of the different kinds signed with the same key can't be used interchangeably.
+func parseToken(token string, signingKey []byte, audience string) (*jwt.StandardClaims, error) {
+	t, err := jwt.ParseWithClaims(token, &jwt.StandardClaims{}, func(token *jwt.Token) (i interface{}, err error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return s.signingKey, nil
+		return signingKey, nil
 	})
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, ok := t.Claims.(jwt.MapClaims)
+	claims, ok := t.Claims.(*jwt.StandardClaims)
 	if !ok {
-		return fmt.Errorf("error get user claims from token")
+		return nil, fmt.Errorf("error get user claims from token")
+	}
+
+	if !claims.VerifyAudience(audience, true) {
+		return nil, fmt.Errorf("token isn't issued for %s", audience)
 	}
 
-	return nil
+	return claims, nil
 }
 
 func (s *AdminService) getPasswordHash(password string) string {
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository"
+	"gopkg.in/guregu/null.v3"
+)
+
+type CartService struct {
+	repo         repository.Cart
+	orderService Order
+}
+
+func NewCartService(repo repository.Cart, orderService Order) *CartService {
+	return &CartService{repo: repo, orderService: orderService}
+}
+
+func (s *CartService) Get(identity jewerly.CartIdentity, language string) (jewerly.Cart, error) {
+	cart, err := s.resolveCart(identity, false)
+	if err != nil {
+		return cart, err
+	}
+
+	return s.withItems(cart, language)
+}
+
+func (s *CartService) AddItem(identity jewerly.CartIdentity, inp jewerly.AddCartItemInput, language string) (jewerly.Cart, error) {
+	cart, err := s.resolveCart(identity, true)
+	if err != nil {
+		return cart, err
+	}
+
+	if err := s.repo.AddItem(cart.Id, inp.ProductId, inp.VariantId, inp.Quantity); err != nil {
+		return cart, err
+	}
+
+	return s.withItems(cart, language)
+}
+
+func (s *CartService) UpdateItem(identity jewerly.CartIdentity, productId int, variantId null.Int, inp jewerly.UpdateCartItemInput, language string) (jewerly.Cart, error) {
+	cart, err := s.resolveCart(identity, false)
+	if err != nil {
+		return cart, err
+	}
+
+	if err := s.repo.SetItemQuantity(cart.Id, productId, variantId, inp.Quantity); err != nil {
+		return cart, err
+	}
+
+	return s.withItems(cart, language)
+}
+
+func (s *CartService) DeleteItem(identity jewerly.CartIdentity, productId int, variantId null.Int, language string) (jewerly.Cart, error) {
+	cart, err := s.resolveCart(identity, false)
+	if err != nil {
+		return cart, err
+	}
+
+	if err := s.repo.DeleteItem(cart.Id, productId, variantId); err != nil {
+		return cart, err
+	}
+
+	return s.withItems(cart, language)
+}
+
+func (s *CartService) Clear(identity jewerly.CartIdentity) error {
+	cart, err := s.resolveCart(identity, false)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Clear(cart.Id)
+}
+
+// Checkout creates order from the cart items through the regular order flow and returns payment url.
+func (s *CartService) Checkout(identity jewerly.CartIdentity, inp jewerly.CheckoutInput) (string, error) {
+	cart, err := s.resolveCart(identity, false)
+	if err != nil {
+		return "", err
+	}
+
+	// language doesn't matter here, only availability and quantities are used
+	cart, err = s.withItems(cart, jewerly.English)
+	if err != nil {
+		return "", err
+	}
+
+	if len(cart.Items) == 0 {
+		return "", jewerly.ErrCartEmpty
+	}
+
+	if cart.HasUnavailable {
+		return "", jewerly.ErrCartItemsUnavailable
+	}
+
+	items := make([]jewerly.OrderItem, len(cart.Items))
+	for i, item := range cart.Items {
+		items[i] = jewerly.OrderItem{ProductId: item.ProductId, Quantity: item.Quantity, VariantId: item.VariantId}
+	}
+
+	url, err := s.orderService.Create(jewerly.CreateOrderInput{
+		Items:          items,
+		FirstName:      inp.FirstName,
+		LastName:       inp.LastName,
+		AdditionalName: inp.AdditionalName,
+		Email:          inp.Email,
+		Phone:          inp.Phone,
+		Country:        inp.Country,
+		Address:        inp.Address,
+		PostalCode:     inp.PostalCode,
+		PromoCode:      inp.PromoCode,
+		Currency:       inp.Currency,
+		UserId:         identity.UserId,
+
+		ShippingMethodId: inp.ShippingMethodId,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// order is already created, failing here would make the customer check out the same cart again
+	if err := s.repo.Clear(cart.Id); err != nil {
+		logrus.Errorf("failed to clear cart %d after checkout: %s", cart.Id, err.Error())
+	}
+
+	return url, nil
+}
+
+// resolveCart finds the cart of the customer or by anonymous token. Anonymous cart filled before
+// sign in is attached to the customer or merged into the existing customer's cart.
+func (s *CartService) resolveCart(identity jewerly.CartIdentity, create bool) (jewerly.Cart, error) {
+	if !identity.UserId.Valid {
+		if identity.Token != "" {
+			cart, err := s.repo.GetByToken(identity.Token)
+			if err != jewerly.ErrCartNotFound || !create {
+				return cart, err
+			}
+		}
+
+		if !create {
+			return jewerly.Cart{}, jewerly.ErrCartNotFound
+		}
+
+		return s.createCart(null.Int{})
+	}
+
+	userId := identity.UserId.Int64
+
+	cart, err := s.repo.GetByUserId(userId)
+	if err != nil && err != jewerly.ErrCartNotFound {
+		return cart, err
+	}
+	exists := err == nil
+
+	if identity.Token != "" {
+		anonymous, err := s.repo.GetByToken(identity.Token)
+		if err != nil && err != jewerly.ErrCartNotFound {
+			return cart, err
+		}
+
+		if err == nil && !anonymous.UserId.Valid {
+			if !exists {
+				anonymous.UserId = identity.UserId
+				return anonymous, s.repo.AssignUser(anonymous.Id, userId)
+			}
+
+			if err := s.repo.Merge(anonymous.Id, cart.Id); err != nil {
+				return cart, err
+			}
+		}
+	}
+
+	if exists {
+		return cart, nil
+	}
+
+	if !create {
+		return cart, jewerly.ErrCartNotFound
+	}
+
+	return s.createCart(identity.UserId)
+}
+
+func (s *CartService) createCart(userId null.Int) (jewerly.Cart, error) {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return jewerly.Cart{}, err
+	}
+
+	return s.repo.Create(hex.EncodeToString(bytes), userId)
+}
+
+func (s *CartService) withItems(cart jewerly.Cart, language string) (jewerly.Cart, error) {
+	items, err := s.repo.GetItems(cart.Id, language)
+	if err != nil {
+		return cart, err
+	}
+
+	cart.Items = items
+	cart.TotalCost = 0
+	cart.HasUnavailable = false
+
+	for _, item := range items {
+		if !item.InStock {
+			cart.HasUnavailable = true
+			continue
+		}
+
+		cart.TotalCost += item.Price.Mul(item.Quantity)
+	}
+
+	return cart, nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"encoding/csv"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type CurrencyService struct {
+	repo repository.Currency
+}
+
+func NewCurrencyService(repo repository.Currency) *CurrencyService {
+	return &CurrencyService{repo: repo}
+}
+
+func (s *CurrencyService) GetRates() ([]jewerly.ExchangeRate, error) {
+	return s.repo.GetRates()
+}
+
+// GetRate returns the actual rate of the currency, empty currency means the base one.
+func (s *CurrencyService) GetRate(currency string) (jewerly.ExchangeRate, error) {
+	currency = jewerly.NormalizeCurrency(currency)
+	if currency == "" || currency == jewerly.BaseCurrency {
+		return jewerly.ExchangeRate{Currency: jewerly.BaseCurrency, Rate: 1}, nil
+	}
+
+	if !jewerly.Currencies[currency] {
+		return jewerly.ExchangeRate{}, jewerly.ErrInvalidCurrency
+	}
+
+	return s.repo.GetRate(currency)
+}
+
+func (s *CurrencyService) SetRate(currency string, rate float64) error {
+	exchangeRate := jewerly.ExchangeRate{Currency: jewerly.NormalizeCurrency(currency), Rate: rate}
+	if err := exchangeRate.Validate(); err != nil {
+		return err
+	}
+
+	return s.repo.SetRates([]jewerly.ExchangeRate{exchangeRate})
+}
+
+// ImportRates sets rates from CSV file with "currency,rate" lines and optional header,
+// nothing is imported if any of the lines is invalid.
+func (s *CurrencyService) ImportRates(file io.Reader) (int, error) {
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return 0, jewerly.ErrInvalidExchangeRateFile
+	}
+
+	if len(records) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), "currency") {
+		records = records[1:]
+	}
+
+	if len(records) == 0 {
+		return 0, jewerly.ErrInvalidExchangeRateFile
+	}
+
+	rates := make([]jewerly.ExchangeRate, len(records))
+	for i, record := range records {
+		if len(record) != 2 {
+			return 0, jewerly.ErrInvalidExchangeRateFile
+		}
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			return 0, jewerly.ErrInvalidExchangeRateFile
+		}
+
+		rates[i] = jewerly.ExchangeRate{Currency: jewerly.NormalizeCurrency(record[0]), Rate: rate}
+		if err := rates[i].Validate(); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := s.repo.SetRates(rates); err != nil {
+		return 0, err
+	}
+
+	return len(rates), nil
+}
@@ -24,6 +24,24 @@ type EmailDeps struct {
 
 	PaymentInfoCustomerTemplate string
 	PaymentInfoCustomerSubject  string
+
+	ShippingInfoCustomerTemplate string
+	ShippingInfoCustomerSubject  string
+
+	RefundInfoCustomerTemplate string
+	RefundInfoCustomerSubject  string
+
+	EmailVerificationTemplate string
+	EmailVerificationSubject  string
+
+	PasswordResetTemplate string
+	PasswordResetSubject  string
+
+	CartReminderTemplate string
+	CartReminderSubject  string
+
+	PaymentReminderTemplate string
+	PaymentReminderSubject  string
 }
 
 type EmailService struct {
@@ -96,9 +114,97 @@ func (s *EmailService) SendPaymentInfoCustomer(inp jewerly.PaymentInfoEmailInput
 		Subject:   fmt.Sprintf(s.PaymentInfoCustomerSubject, inp.OrderId, inp.Status),
 	}
 
+	if len(inp.InvoiceFile) > 0 {
+		message.Attachments = []email.Attachment{{
+			Name:        inp.InvoiceNumber + ".pdf",
+			ContentType: invoiceContentType,
+			Data:        inp.InvoiceFile,
+		}}
+	}
+
 	if err := message.GenerateBodyFromHTML(s.PaymentInfoCustomerTemplate, inp); err != nil {
 		return err
 	}
 
 	return s.client.Send(message)
-}
\ No newline at end of file
+}
+
+func (s *EmailService) SendShippingInfoCustomer(inp jewerly.ShippingInfoEmailInput) error {
+	message := email.Email{
+		ToName:    inp.FirstName,
+		ToEmail:   inp.Email,
+		FromEmail: s.SenderEmail,
+		FromName:  s.SenderName,
+		Subject:   fmt.Sprintf(s.ShippingInfoCustomerSubject, inp.OrderId),
+	}
+
+	if err := message.GenerateBodyFromHTML(s.ShippingInfoCustomerTemplate, inp); err != nil {
+		return err
+	}
+
+	return s.client.Send(message)
+}
+
+func (s *EmailService) SendRefundInfoCustomer(inp jewerly.RefundEmailInput) error {
+	message := email.Email{
+		ToName:    inp.FirstName,
+		ToEmail:   inp.Email,
+		FromEmail: s.SenderEmail,
+		FromName:  s.SenderName,
+		Subject:   fmt.Sprintf(s.RefundInfoCustomerSubject, inp.OrderId),
+	}
+
+	if err := message.GenerateBodyFromHTML(s.RefundInfoCustomerTemplate, inp); err != nil {
+		return err
+	}
+
+	return s.client.Send(message)
+}
+
+func (s *EmailService) SendEmailVerification(inp jewerly.UserTokenEmailInput) error {
+	return s.sendUserTokenEmail(inp, s.EmailVerificationTemplate, s.EmailVerificationSubject)
+}
+
+func (s *EmailService) SendPasswordReset(inp jewerly.UserTokenEmailInput) error {
+	return s.sendUserTokenEmail(inp, s.PasswordResetTemplate, s.PasswordResetSubject)
+}
+
+func (s *EmailService) sendUserTokenEmail(inp jewerly.UserTokenEmailInput, template, subject string) error {
+	message := email.Email{
+		ToName:    inp.FirstName,
+		ToEmail:   inp.Email,
+		FromEmail: s.SenderEmail,
+		FromName:  s.SenderName,
+		Subject:   subject,
+	}
+
+	if err := message.GenerateBodyFromHTML(template, inp); err != nil {
+		return err
+	}
+
+	return s.client.Send(message)
+}
+
+func (s *EmailService) SendCartReminder(inp jewerly.ReminderEmailInput) error {
+	return s.sendReminderEmail(inp, s.CartReminderTemplate, s.CartReminderSubject)
+}
+
+func (s *EmailService) SendPaymentReminder(inp jewerly.ReminderEmailInput) error {
+	return s.sendReminderEmail(inp, s.PaymentReminderTemplate, fmt.Sprintf(s.PaymentReminderSubject, inp.OrderId))
+}
+
+func (s *EmailService) sendReminderEmail(inp jewerly.ReminderEmailInput, template, subject string) error {
+	message := email.Email{
+		ToName:    inp.FirstName,
+		ToEmail:   inp.Email,
+		FromEmail: s.SenderEmail,
+		FromName:  s.SenderName,
+		Subject:   subject,
+	}
+
+	if err := message.GenerateBodyFromHTML(template, inp); err != nil {
+		return err
+	}
+
+	return s.client.Send(message)
+}
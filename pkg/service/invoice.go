@@ -0,0 +1,128 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/invoice"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/storage"
+	"time"
+)
+
+const invoiceContentType = "application/pdf"
+
+type InvoiceDeps struct {
+	NumberPrefix string
+	Seller       invoice.Seller
+}
+
+type InvoiceService struct {
+	repo      repository.Invoice
+	orderRepo repository.Order
+	storage   storage.Storage
+	InvoiceDeps
+}
+
+func NewInvoiceService(repo repository.Invoice, orderRepo repository.Order, storage storage.Storage, deps InvoiceDeps) *InvoiceService {
+	return &InvoiceService{repo: repo, orderRepo: orderRepo, storage: storage, InvoiceDeps: deps}
+}
+
+// Create issues invoice for the paid order and returns it with the PDF file,
+// the invoice issued before is returned when the order already has one.
+func (s *InvoiceService) Create(orderId int) (jewerly.Invoice, []byte, error) {
+	order, err := s.orderRepo.GetById(orderId)
+	if err != nil {
+		return jewerly.Invoice{}, nil, err
+	}
+
+	items, err := s.orderRepo.GetItemsDetails([]int{orderId}, jewerly.English)
+	if err != nil {
+		return jewerly.Invoice{}, nil, err
+	}
+
+	var file []byte
+	inv, err := s.repo.Create(orderId, func(number int) (string, error) {
+		file = invoice.Generate(s.newInvoiceDocument(order, items[orderId], number))
+		name := s.getFileName(number)
+
+		_, err := s.storage.Upload(context.Background(), storage.UploadInput{
+			File:        bytes.NewReader(file),
+			Name:        name,
+			Size:        int64(len(file)),
+			ContentType: invoiceContentType,
+			Private:     true,
+		})
+
+		return name, err
+	})
+	if err != nil {
+		return jewerly.Invoice{}, nil, err
+	}
+	inv.DisplayNumber = jewerly.FormatInvoiceNumber(s.NumberPrefix, inv.Number)
+
+	// file isn't generated when invoice already exists
+	if file == nil {
+		file, err = s.storage.Download(context.Background(), inv.FileName)
+	}
+
+	return inv, file, err
+}
+
+func (s *InvoiceService) GetByOrderId(orderId int) (jewerly.Invoice, []byte, error) {
+	inv, err := s.repo.GetByOrderId(orderId)
+	if err != nil {
+		return jewerly.Invoice{}, nil, err
+	}
+	inv.DisplayNumber = jewerly.FormatInvoiceNumber(s.NumberPrefix, inv.Number)
+
+	file, err := s.storage.Download(context.Background(), inv.FileName)
+
+	return inv, file, err
+}
+
+func (s *InvoiceService) getFileName(number int) string {
+	return fmt.Sprintf("invoices/%s.pdf", jewerly.FormatInvoiceNumber(s.NumberPrefix, number))
+}
+
+// newInvoiceDocument converts item prices to the order currency with the order exchange rate,
+// the rest of the amounts are stored in the order currency.
+func (s *InvoiceService) newInvoiceDocument(order jewerly.Order, items []jewerly.CustomerOrderItem, number int) invoice.Invoice {
+	rate := jewerly.ExchangeRate{Currency: order.Currency, Rate: order.ExchangeRate}
+
+	lines := make([]invoice.Item, len(items))
+	for i, item := range items {
+		title := item.Title
+		if item.VariantOption.Valid {
+			title = fmt.Sprintf("%s (%s: %s)", title, item.VariantOption.String, item.VariantValue.String)
+		}
+
+		lines[i] = invoice.Item{Title: title, Quantity: item.Quantity, Price: rate.Convert(item.Price)}
+	}
+
+	return invoice.Invoice{
+		Number:   jewerly.FormatInvoiceNumber(s.NumberPrefix, number),
+		Date:     time.Now(),
+		OrderId:  order.Id,
+		Currency: order.Currency,
+		Seller:   s.Seller,
+		Buyer: invoice.Buyer{
+			Name:       fmt.Sprintf("%s %s", order.FirstName, order.LastName),
+			Address:    order.Address,
+			PostalCode: order.PostalCode,
+			Country:    order.Country,
+			Email:      order.Email,
+		},
+		Items:          lines,
+		PromoCode:      order.PromoCode.String,
+		Discount:       order.Discount,
+		ShippingMethod: order.ShippingMethod.String,
+		ShippingCost:   order.ShippingCost,
+		Net:            order.NetAmount,
+		Tax:            order.TaxAmount,
+		Total:          order.TotalCost,
+		TaxRate:        order.TaxRate,
+		TaxInclusive:   order.TaxInclusive,
+	}
+}
@@ -8,7 +8,10 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	service "github.com/zhashkevych/jewelry-shop-backend/pkg/service"
+	null_v3 "gopkg.in/guregu/null.v3"
 	io "io"
+	http "net/http"
 	reflect "reflect"
 )
 
@@ -51,11 +54,12 @@ func (mr *MockAdminMockRecorder) SignIn(login, password interface{}) *gomock.Cal
 }
 
 // ParseToken mocks base method
-func (m *MockAdmin) ParseToken(token string) error {
+func (m *MockAdmin) ParseToken(token string) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ParseToken", token)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ParseToken indicates an expected call of ParseToken
@@ -64,6 +68,115 @@ func (mr *MockAdminMockRecorder) ParseToken(token interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAdmin)(nil).ParseToken), token)
 }
 
+// MockUser is a mock of User interface
+type MockUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserMockRecorder
+}
+
+// MockUserMockRecorder is the mock recorder for MockUser
+type MockUserMockRecorder struct {
+	mock *MockUser
+}
+
+// NewMockUser creates a new mock instance
+func NewMockUser(ctrl *gomock.Controller) *MockUser {
+	mock := &MockUser{ctrl: ctrl}
+	mock.recorder = &MockUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockUser) EXPECT() *MockUserMockRecorder {
+	return m.recorder
+}
+
+// SignUp mocks base method
+func (m *MockUser) SignUp(inp service.SignUpInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignUp", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignUp indicates an expected call of SignUp
+func (mr *MockUserMockRecorder) SignUp(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*MockUser)(nil).SignUp), inp)
+}
+
+// SignIn mocks base method
+func (m *MockUser) SignIn(email, password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", email, password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignIn indicates an expected call of SignIn
+func (mr *MockUserMockRecorder) SignIn(email, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockUser)(nil).SignIn), email, password)
+}
+
+// ParseToken mocks base method
+func (m *MockUser) ParseToken(token string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseToken", token)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseToken indicates an expected call of ParseToken
+func (mr *MockUserMockRecorder) ParseToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockUser)(nil).ParseToken), token)
+}
+
+// VerifyEmail mocks base method
+func (m *MockUser) VerifyEmail(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail
+func (mr *MockUserMockRecorder) VerifyEmail(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUser)(nil).VerifyEmail), token)
+}
+
+// ForgotPassword mocks base method
+func (m *MockUser) ForgotPassword(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword
+func (mr *MockUserMockRecorder) ForgotPassword(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockUser)(nil).ForgotPassword), email)
+}
+
+// ResetPassword mocks base method
+func (m *MockUser) ResetPassword(token, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", token, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword
+func (mr *MockUserMockRecorder) ResetPassword(token, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUser)(nil).ResetPassword), token, password)
+}
+
 // MockProduct is a mock of Product interface
 type MockProduct struct {
 	ctrl     *gomock.Controller
@@ -116,19 +229,34 @@ func (mr *MockProductMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockProduct)(nil).GetAll), arg0)
 }
 
+// Search mocks base method
+func (m *MockProduct) Search(arg0 jewerly.SearchProductsFilters) (jewerly.ProductsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0)
+	ret0, _ := ret[0].(jewerly.ProductsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search
+func (mr *MockProductMockRecorder) Search(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockProduct)(nil).Search), arg0)
+}
+
 // GetById mocks base method
-func (m *MockProduct) GetById(id int, language string) (jewerly.ProductResponse, error) {
+func (m *MockProduct) GetById(id int, language, currency string) (jewerly.ProductResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", id, language)
+	ret := m.ctrl.Call(m, "GetById", id, language, currency)
 	ret0, _ := ret[0].(jewerly.ProductResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetById indicates an expected call of GetById
-func (mr *MockProductMockRecorder) GetById(id, language interface{}) *gomock.Call {
+func (mr *MockProductMockRecorder) GetById(id, language, currency interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockProduct)(nil).GetById), id, language)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockProduct)(nil).GetById), id, language, currency)
 }
 
 // Update mocks base method
@@ -174,6 +302,64 @@ func (mr *MockProductMockRecorder) UploadImage(ctx, file, size, contentType inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockProduct)(nil).UploadImage), ctx, file, size, contentType)
 }
 
+// CreateVariant mocks base method
+func (m *MockProduct) CreateVariant(productId int, inp jewerly.CreateVariantInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVariant", productId, inp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVariant indicates an expected call of CreateVariant
+func (mr *MockProductMockRecorder) CreateVariant(productId, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVariant", reflect.TypeOf((*MockProduct)(nil).CreateVariant), productId, inp)
+}
+
+// UpdateVariant mocks base method
+func (m *MockProduct) UpdateVariant(productId, variantId int, inp jewerly.UpdateVariantInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVariant", productId, variantId, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVariant indicates an expected call of UpdateVariant
+func (mr *MockProductMockRecorder) UpdateVariant(productId, variantId, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVariant", reflect.TypeOf((*MockProduct)(nil).UpdateVariant), productId, variantId, inp)
+}
+
+// DeleteVariant mocks base method
+func (m *MockProduct) DeleteVariant(productId, variantId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVariant", productId, variantId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVariant indicates an expected call of DeleteVariant
+func (mr *MockProductMockRecorder) DeleteVariant(productId, variantId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVariant", reflect.TypeOf((*MockProduct)(nil).DeleteVariant), productId, variantId)
+}
+
+// SetCategorySale mocks base method
+func (m *MockProduct) SetCategorySale(inp jewerly.BulkSaleInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCategorySale", inp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCategorySale indicates an expected call of SetCategorySale
+func (mr *MockProductMockRecorder) SetCategorySale(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCategorySale", reflect.TypeOf((*MockProduct)(nil).SetCategorySale), inp)
+}
+
 // MockOrder is a mock of Order interface
 type MockOrder struct {
 	ctrl     *gomock.Controller
@@ -212,10 +398,27 @@ func (mr *MockOrderMockRecorder) Create(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrder)(nil).Create), arg0)
 }
 
+// ParseCallback mocks base method
+func (m *MockOrder) ParseCallback(r *http.Request) (jewerly.TransactionCallbackInput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseCallback", r)
+	ret0, _ := ret[0].(jewerly.TransactionCallbackInput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseCallback indicates an expected call of ParseCallback
+func (mr *MockOrderMockRecorder) ParseCallback(r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseCallback", reflect.TypeOf((*MockOrder)(nil).ParseCallback), r)
+}
+
 // ProcessCallback mocks base method
-func (m *MockOrder) ProcessCallback(arg0 jewerly.TransactionCallbackInput) {
+func (m *MockOrder) ProcessCallback(arg0 jewerly.TransactionCallbackInput) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "ProcessCallback", arg0)
+	ret := m.ctrl.Call(m, "ProcessCallback", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // ProcessCallback indicates an expected call of ProcessCallback
@@ -224,6 +427,20 @@ func (mr *MockOrderMockRecorder) ProcessCallback(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessCallback", reflect.TypeOf((*MockOrder)(nil).ProcessCallback), arg0)
 }
 
+// ApplyTransaction mocks base method
+func (m *MockOrder) ApplyTransaction(arg0 jewerly.TransactionCallbackInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyTransaction", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyTransaction indicates an expected call of ApplyTransaction
+func (mr *MockOrderMockRecorder) ApplyTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyTransaction", reflect.TypeOf((*MockOrder)(nil).ApplyTransaction), arg0)
+}
+
 // GetAll mocks base method
 func (m *MockOrder) GetAll(arg0 jewerly.GetAllOrdersFilters) (jewerly.OrderList, error) {
 	m.ctrl.T.Helper()
@@ -254,81 +471,1160 @@ func (mr *MockOrderMockRecorder) GetById(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockOrder)(nil).GetById), id)
 }
 
-// MockEmail is a mock of Email interface
-type MockEmail struct {
-	ctrl     *gomock.Controller
-	recorder *MockEmailMockRecorder
+// UpdateStatus mocks base method
+func (m *MockOrder) UpdateStatus(id int, status, changedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", id, status, changedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MockEmailMockRecorder is the mock recorder for MockEmail
-type MockEmailMockRecorder struct {
-	mock *MockEmail
+// UpdateStatus indicates an expected call of UpdateStatus
+func (mr *MockOrderMockRecorder) UpdateStatus(id, status, changedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrder)(nil).UpdateStatus), id, status, changedBy)
 }
 
-// NewMockEmail creates a new mock instance
-func NewMockEmail(ctrl *gomock.Controller) *MockEmail {
-	mock := &MockEmail{ctrl: ctrl}
-	mock.recorder = &MockEmailMockRecorder{mock}
-	return mock
+// Ship mocks base method
+func (m *MockOrder) Ship(id int, inp jewerly.ShipOrderInput, changedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ship", id, inp, changedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockEmail) EXPECT() *MockEmailMockRecorder {
-	return m.recorder
+// Ship indicates an expected call of Ship
+func (mr *MockOrderMockRecorder) Ship(id, inp, changedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ship", reflect.TypeOf((*MockOrder)(nil).Ship), id, inp, changedBy)
 }
 
-// SendOrderInfoSupport mocks base method
-func (m *MockEmail) SendOrderInfoSupport(inp jewerly.OrderInfoEmailInput) error {
+// Refund mocks base method
+func (m *MockOrder) Refund(id int, inp jewerly.RefundOrderInput, createdBy string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendOrderInfoSupport", inp)
+	ret := m.ctrl.Call(m, "Refund", id, inp, createdBy)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund
+func (mr *MockOrderMockRecorder) Refund(id, inp, createdBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockOrder)(nil).Refund), id, inp, createdBy)
+}
+
+// CompleteRefund mocks base method
+func (m *MockOrder) CompleteRefund(id, refundId int, inp jewerly.CompleteRefundInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRefund", id, refundId, inp)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SendOrderInfoSupport indicates an expected call of SendOrderInfoSupport
-func (mr *MockEmailMockRecorder) SendOrderInfoSupport(inp interface{}) *gomock.Call {
+// CompleteRefund indicates an expected call of CompleteRefund
+func (mr *MockOrderMockRecorder) CompleteRefund(id, refundId, inp interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendOrderInfoSupport", reflect.TypeOf((*MockEmail)(nil).SendOrderInfoSupport), inp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRefund", reflect.TypeOf((*MockOrder)(nil).CompleteRefund), id, refundId, inp)
 }
 
-// SendOrderInfoCustomer mocks base method
-func (m *MockEmail) SendOrderInfoCustomer(inp jewerly.OrderInfoEmailInput) error {
+// Capture mocks base method
+func (m *MockOrder) Capture(id int, changedBy string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendOrderInfoCustomer", inp)
+	ret := m.ctrl.Call(m, "Capture", id, changedBy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SendOrderInfoCustomer indicates an expected call of SendOrderInfoCustomer
-func (mr *MockEmailMockRecorder) SendOrderInfoCustomer(inp interface{}) *gomock.Call {
+// Capture indicates an expected call of Capture
+func (mr *MockOrderMockRecorder) Capture(id, changedBy interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendOrderInfoCustomer", reflect.TypeOf((*MockEmail)(nil).SendOrderInfoCustomer), inp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockOrder)(nil).Capture), id, changedBy)
 }
 
-// SendPaymentInfoSupport mocks base method
-func (m *MockEmail) SendPaymentInfoSupport(inp jewerly.PaymentInfoEmailInput) error {
+// Void mocks base method
+func (m *MockOrder) Void(id int, changedBy string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendPaymentInfoSupport", inp)
+	ret := m.ctrl.Call(m, "Void", id, changedBy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SendPaymentInfoSupport indicates an expected call of SendPaymentInfoSupport
-func (mr *MockEmailMockRecorder) SendPaymentInfoSupport(inp interface{}) *gomock.Call {
+// Void indicates an expected call of Void
+func (mr *MockOrderMockRecorder) Void(id, changedBy interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPaymentInfoSupport", reflect.TypeOf((*MockEmail)(nil).SendPaymentInfoSupport), inp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Void", reflect.TypeOf((*MockOrder)(nil).Void), id, changedBy)
 }
 
-// SendPaymentInfoCustomer mocks base method
-func (m *MockEmail) SendPaymentInfoCustomer(inp jewerly.PaymentInfoEmailInput) error {
+// Lookup mocks base method
+func (m *MockOrder) Lookup(inp jewerly.OrderLookupInput) (jewerly.CustomerOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendPaymentInfoCustomer", inp)
+	ret := m.ctrl.Call(m, "Lookup", inp)
+	ret0, _ := ret[0].(jewerly.CustomerOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup
+func (mr *MockOrderMockRecorder) Lookup(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockOrder)(nil).Lookup), inp)
+}
+
+// GetUserOrders mocks base method
+func (m *MockOrder) GetUserOrders(userId int64, filters jewerly.GetAllOrdersFilters, language string) (jewerly.CustomerOrderList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrders", userId, filters, language)
+	ret0, _ := ret[0].(jewerly.CustomerOrderList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrders indicates an expected call of GetUserOrders
+func (mr *MockOrderMockRecorder) GetUserOrders(userId, filters, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrders", reflect.TypeOf((*MockOrder)(nil).GetUserOrders), userId, filters, language)
+}
+
+// CancelExpiredOrders mocks base method
+func (m *MockOrder) CancelExpiredOrders() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelExpiredOrders")
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SendPaymentInfoCustomer indicates an expected call of SendPaymentInfoCustomer
-func (mr *MockEmailMockRecorder) SendPaymentInfoCustomer(inp interface{}) *gomock.Call {
+// CancelExpiredOrders indicates an expected call of CancelExpiredOrders
+func (mr *MockOrderMockRecorder) CancelExpiredOrders() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPaymentInfoCustomer", reflect.TypeOf((*MockEmail)(nil).SendPaymentInfoCustomer), inp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelExpiredOrders", reflect.TypeOf((*MockOrder)(nil).CancelExpiredOrders))
+}
+
+// VoidStaleAuthorizations mocks base method
+func (m *MockOrder) VoidStaleAuthorizations() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidStaleAuthorizations")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VoidStaleAuthorizations indicates an expected call of VoidStaleAuthorizations
+func (mr *MockOrderMockRecorder) VoidStaleAuthorizations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidStaleAuthorizations", reflect.TypeOf((*MockOrder)(nil).VoidStaleAuthorizations))
+}
+
+// GetShippingOptions mocks base method
+func (m *MockOrder) GetShippingOptions(inp jewerly.ShippingQuoteInput, currency string) ([]jewerly.ShippingOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShippingOptions", inp, currency)
+	ret0, _ := ret[0].([]jewerly.ShippingOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShippingOptions indicates an expected call of GetShippingOptions
+func (mr *MockOrderMockRecorder) GetShippingOptions(inp, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShippingOptions", reflect.TypeOf((*MockOrder)(nil).GetShippingOptions), inp, currency)
+}
+
+// MockCart is a mock of Cart interface
+type MockCart struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartMockRecorder
+}
+
+// MockCartMockRecorder is the mock recorder for MockCart
+type MockCartMockRecorder struct {
+	mock *MockCart
+}
+
+// NewMockCart creates a new mock instance
+func NewMockCart(ctrl *gomock.Controller) *MockCart {
+	mock := &MockCart{ctrl: ctrl}
+	mock.recorder = &MockCartMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCart) EXPECT() *MockCartMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockCart) Get(identity jewerly.CartIdentity, language string) (jewerly.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", identity, language)
+	ret0, _ := ret[0].(jewerly.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockCartMockRecorder) Get(identity, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCart)(nil).Get), identity, language)
+}
+
+// AddItem mocks base method
+func (m *MockCart) AddItem(identity jewerly.CartIdentity, inp jewerly.AddCartItemInput, language string) (jewerly.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", identity, inp, language)
+	ret0, _ := ret[0].(jewerly.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddItem indicates an expected call of AddItem
+func (mr *MockCartMockRecorder) AddItem(identity, inp, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockCart)(nil).AddItem), identity, inp, language)
+}
+
+// UpdateItem mocks base method
+func (m *MockCart) UpdateItem(identity jewerly.CartIdentity, productId int, variantId null_v3.Int, inp jewerly.UpdateCartItemInput, language string) (jewerly.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", identity, productId, variantId, inp, language)
+	ret0, _ := ret[0].(jewerly.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItem indicates an expected call of UpdateItem
+func (mr *MockCartMockRecorder) UpdateItem(identity, productId, variantId, inp, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockCart)(nil).UpdateItem), identity, productId, variantId, inp, language)
+}
+
+// DeleteItem mocks base method
+func (m *MockCart) DeleteItem(identity jewerly.CartIdentity, productId int, variantId null_v3.Int, language string) (jewerly.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", identity, productId, variantId, language)
+	ret0, _ := ret[0].(jewerly.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteItem indicates an expected call of DeleteItem
+func (mr *MockCartMockRecorder) DeleteItem(identity, productId, variantId, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockCart)(nil).DeleteItem), identity, productId, variantId, language)
+}
+
+// Clear mocks base method
+func (m *MockCart) Clear(identity jewerly.CartIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear
+func (mr *MockCartMockRecorder) Clear(identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockCart)(nil).Clear), identity)
+}
+
+// Checkout mocks base method
+func (m *MockCart) Checkout(identity jewerly.CartIdentity, inp jewerly.CheckoutInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", identity, inp)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout
+func (mr *MockCartMockRecorder) Checkout(identity, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockCart)(nil).Checkout), identity, inp)
+}
+
+// MockEmail is a mock of Email interface
+type MockEmail struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailMockRecorder
+}
+
+// MockEmailMockRecorder is the mock recorder for MockEmail
+type MockEmailMockRecorder struct {
+	mock *MockEmail
+}
+
+// NewMockEmail creates a new mock instance
+func NewMockEmail(ctrl *gomock.Controller) *MockEmail {
+	mock := &MockEmail{ctrl: ctrl}
+	mock.recorder = &MockEmailMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockEmail) EXPECT() *MockEmailMockRecorder {
+	return m.recorder
+}
+
+// SendOrderInfoSupport mocks base method
+func (m *MockEmail) SendOrderInfoSupport(inp jewerly.OrderInfoEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendOrderInfoSupport", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendOrderInfoSupport indicates an expected call of SendOrderInfoSupport
+func (mr *MockEmailMockRecorder) SendOrderInfoSupport(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendOrderInfoSupport", reflect.TypeOf((*MockEmail)(nil).SendOrderInfoSupport), inp)
+}
+
+// SendOrderInfoCustomer mocks base method
+func (m *MockEmail) SendOrderInfoCustomer(inp jewerly.OrderInfoEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendOrderInfoCustomer", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendOrderInfoCustomer indicates an expected call of SendOrderInfoCustomer
+func (mr *MockEmailMockRecorder) SendOrderInfoCustomer(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendOrderInfoCustomer", reflect.TypeOf((*MockEmail)(nil).SendOrderInfoCustomer), inp)
+}
+
+// SendPaymentInfoSupport mocks base method
+func (m *MockEmail) SendPaymentInfoSupport(inp jewerly.PaymentInfoEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPaymentInfoSupport", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPaymentInfoSupport indicates an expected call of SendPaymentInfoSupport
+func (mr *MockEmailMockRecorder) SendPaymentInfoSupport(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPaymentInfoSupport", reflect.TypeOf((*MockEmail)(nil).SendPaymentInfoSupport), inp)
+}
+
+// SendPaymentInfoCustomer mocks base method
+func (m *MockEmail) SendPaymentInfoCustomer(inp jewerly.PaymentInfoEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPaymentInfoCustomer", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPaymentInfoCustomer indicates an expected call of SendPaymentInfoCustomer
+func (mr *MockEmailMockRecorder) SendPaymentInfoCustomer(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPaymentInfoCustomer", reflect.TypeOf((*MockEmail)(nil).SendPaymentInfoCustomer), inp)
+}
+
+// SendShippingInfoCustomer mocks base method
+func (m *MockEmail) SendShippingInfoCustomer(inp jewerly.ShippingInfoEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendShippingInfoCustomer", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendShippingInfoCustomer indicates an expected call of SendShippingInfoCustomer
+func (mr *MockEmailMockRecorder) SendShippingInfoCustomer(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendShippingInfoCustomer", reflect.TypeOf((*MockEmail)(nil).SendShippingInfoCustomer), inp)
+}
+
+// SendRefundInfoCustomer mocks base method
+func (m *MockEmail) SendRefundInfoCustomer(inp jewerly.RefundEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRefundInfoCustomer", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendRefundInfoCustomer indicates an expected call of SendRefundInfoCustomer
+func (mr *MockEmailMockRecorder) SendRefundInfoCustomer(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRefundInfoCustomer", reflect.TypeOf((*MockEmail)(nil).SendRefundInfoCustomer), inp)
+}
+
+// SendEmailVerification mocks base method
+func (m *MockEmail) SendEmailVerification(inp jewerly.UserTokenEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailVerification", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmailVerification indicates an expected call of SendEmailVerification
+func (mr *MockEmailMockRecorder) SendEmailVerification(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailVerification", reflect.TypeOf((*MockEmail)(nil).SendEmailVerification), inp)
+}
+
+// SendPasswordReset mocks base method
+func (m *MockEmail) SendPasswordReset(inp jewerly.UserTokenEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPasswordReset", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPasswordReset indicates an expected call of SendPasswordReset
+func (mr *MockEmailMockRecorder) SendPasswordReset(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPasswordReset", reflect.TypeOf((*MockEmail)(nil).SendPasswordReset), inp)
+}
+
+// SendCartReminder mocks base method
+func (m *MockEmail) SendCartReminder(inp jewerly.ReminderEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCartReminder", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendCartReminder indicates an expected call of SendCartReminder
+func (mr *MockEmailMockRecorder) SendCartReminder(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCartReminder", reflect.TypeOf((*MockEmail)(nil).SendCartReminder), inp)
+}
+
+// SendPaymentReminder mocks base method
+func (m *MockEmail) SendPaymentReminder(inp jewerly.ReminderEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPaymentReminder", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPaymentReminder indicates an expected call of SendPaymentReminder
+func (mr *MockEmailMockRecorder) SendPaymentReminder(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPaymentReminder", reflect.TypeOf((*MockEmail)(nil).SendPaymentReminder), inp)
+}
+
+// MockReminder is a mock of Reminder interface
+type MockReminder struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderMockRecorder
+}
+
+// MockReminderMockRecorder is the mock recorder for MockReminder
+type MockReminderMockRecorder struct {
+	mock *MockReminder
+}
+
+// NewMockReminder creates a new mock instance
+func NewMockReminder(ctrl *gomock.Controller) *MockReminder {
+	mock := &MockReminder{ctrl: ctrl}
+	mock.recorder = &MockReminderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockReminder) EXPECT() *MockReminderMockRecorder {
+	return m.recorder
+}
+
+// SendReminders mocks base method
+func (m *MockReminder) SendReminders() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendReminders")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendReminders indicates an expected call of SendReminders
+func (mr *MockReminderMockRecorder) SendReminders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendReminders", reflect.TypeOf((*MockReminder)(nil).SendReminders))
+}
+
+// Unsubscribe mocks base method
+func (m *MockReminder) Unsubscribe(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe
+func (mr *MockReminderMockRecorder) Unsubscribe(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockReminder)(nil).Unsubscribe), token)
+}
+
+// MockReconciliation is a mock of Reconciliation interface
+type MockReconciliation struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconciliationMockRecorder
+}
+
+// MockReconciliationMockRecorder is the mock recorder for MockReconciliation
+type MockReconciliationMockRecorder struct {
+	mock *MockReconciliation
+}
+
+// NewMockReconciliation creates a new mock instance
+func NewMockReconciliation(ctrl *gomock.Controller) *MockReconciliation {
+	mock := &MockReconciliation{ctrl: ctrl}
+	mock.recorder = &MockReconciliationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockReconciliation) EXPECT() *MockReconciliationMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method
+func (m *MockReconciliation) Reconcile() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reconcile indicates an expected call of Reconcile
+func (mr *MockReconciliationMockRecorder) Reconcile() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockReconciliation)(nil).Reconcile))
+}
+
+// GetDiscrepancies mocks base method
+func (m *MockReconciliation) GetDiscrepancies(filters jewerly.PaymentDiscrepancyFilters) ([]jewerly.PaymentDiscrepancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscrepancies", filters)
+	ret0, _ := ret[0].([]jewerly.PaymentDiscrepancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiscrepancies indicates an expected call of GetDiscrepancies
+func (mr *MockReconciliationMockRecorder) GetDiscrepancies(filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscrepancies", reflect.TypeOf((*MockReconciliation)(nil).GetDiscrepancies), filters)
+}
+
+// MockPromo is a mock of Promo interface
+type MockPromo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromoMockRecorder
+}
+
+// MockPromoMockRecorder is the mock recorder for MockPromo
+type MockPromoMockRecorder struct {
+	mock *MockPromo
+}
+
+// NewMockPromo creates a new mock instance
+func NewMockPromo(ctrl *gomock.Controller) *MockPromo {
+	mock := &MockPromo{ctrl: ctrl}
+	mock.recorder = &MockPromoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPromo) EXPECT() *MockPromoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockPromo) Create(inp jewerly.CreatePromoCodeInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", inp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockPromoMockRecorder) Create(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromo)(nil).Create), inp)
+}
+
+// GetAll mocks base method
+func (m *MockPromo) GetAll() ([]jewerly.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]jewerly.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll
+func (mr *MockPromoMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPromo)(nil).GetAll))
+}
+
+// GetById mocks base method
+func (m *MockPromo) GetById(id int) (jewerly.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", id)
+	ret0, _ := ret[0].(jewerly.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById
+func (mr *MockPromoMockRecorder) GetById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPromo)(nil).GetById), id)
+}
+
+// Update mocks base method
+func (m *MockPromo) Update(id int, inp jewerly.UpdatePromoCodeInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", id, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockPromoMockRecorder) Update(id, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPromo)(nil).Update), id, inp)
+}
+
+// Delete mocks base method
+func (m *MockPromo) Delete(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockPromoMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPromo)(nil).Delete), id)
+}
+
+// Apply mocks base method
+func (m *MockPromo) Apply(code, email string, items []jewerly.OrderItem, products []jewerly.ProductResponse) (jewerly.OrderDiscount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", code, email, items, products)
+	ret0, _ := ret[0].(jewerly.OrderDiscount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply
+func (mr *MockPromoMockRecorder) Apply(code, email, items, products interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockPromo)(nil).Apply), code, email, items, products)
+}
+
+// MockCurrency is a mock of Currency interface
+type MockCurrency struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyMockRecorder
+}
+
+// MockCurrencyMockRecorder is the mock recorder for MockCurrency
+type MockCurrencyMockRecorder struct {
+	mock *MockCurrency
+}
+
+// NewMockCurrency creates a new mock instance
+func NewMockCurrency(ctrl *gomock.Controller) *MockCurrency {
+	mock := &MockCurrency{ctrl: ctrl}
+	mock.recorder = &MockCurrencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCurrency) EXPECT() *MockCurrencyMockRecorder {
+	return m.recorder
+}
+
+// GetRates mocks base method
+func (m *MockCurrency) GetRates() ([]jewerly.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRates")
+	ret0, _ := ret[0].([]jewerly.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRates indicates an expected call of GetRates
+func (mr *MockCurrencyMockRecorder) GetRates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockCurrency)(nil).GetRates))
+}
+
+// GetRate mocks base method
+func (m *MockCurrency) GetRate(currency string) (jewerly.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRate", currency)
+	ret0, _ := ret[0].(jewerly.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRate indicates an expected call of GetRate
+func (mr *MockCurrencyMockRecorder) GetRate(currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRate", reflect.TypeOf((*MockCurrency)(nil).GetRate), currency)
+}
+
+// SetRate mocks base method
+func (m *MockCurrency) SetRate(currency string, rate float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRate", currency, rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRate indicates an expected call of SetRate
+func (mr *MockCurrencyMockRecorder) SetRate(currency, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRate", reflect.TypeOf((*MockCurrency)(nil).SetRate), currency, rate)
+}
+
+// ImportRates mocks base method
+func (m *MockCurrency) ImportRates(file io.Reader) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportRates", file)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportRates indicates an expected call of ImportRates
+func (mr *MockCurrencyMockRecorder) ImportRates(file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportRates", reflect.TypeOf((*MockCurrency)(nil).ImportRates), file)
+}
+
+// MockShipping is a mock of Shipping interface
+type MockShipping struct {
+	ctrl     *gomock.Controller
+	recorder *MockShippingMockRecorder
+}
+
+// MockShippingMockRecorder is the mock recorder for MockShipping
+type MockShippingMockRecorder struct {
+	mock *MockShipping
+}
+
+// NewMockShipping creates a new mock instance
+func NewMockShipping(ctrl *gomock.Controller) *MockShipping {
+	mock := &MockShipping{ctrl: ctrl}
+	mock.recorder = &MockShippingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockShipping) EXPECT() *MockShippingMockRecorder {
+	return m.recorder
+}
+
+// CreateZone mocks base method
+func (m *MockShipping) CreateZone(inp jewerly.CreateShippingZoneInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateZone", inp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateZone indicates an expected call of CreateZone
+func (mr *MockShippingMockRecorder) CreateZone(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateZone", reflect.TypeOf((*MockShipping)(nil).CreateZone), inp)
+}
+
+// GetZones mocks base method
+func (m *MockShipping) GetZones() ([]jewerly.ShippingZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetZones")
+	ret0, _ := ret[0].([]jewerly.ShippingZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetZones indicates an expected call of GetZones
+func (mr *MockShippingMockRecorder) GetZones() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetZones", reflect.TypeOf((*MockShipping)(nil).GetZones))
+}
+
+// UpdateZone mocks base method
+func (m *MockShipping) UpdateZone(id int, inp jewerly.UpdateShippingZoneInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateZone", id, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateZone indicates an expected call of UpdateZone
+func (mr *MockShippingMockRecorder) UpdateZone(id, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateZone", reflect.TypeOf((*MockShipping)(nil).UpdateZone), id, inp)
+}
+
+// DeleteZone mocks base method
+func (m *MockShipping) DeleteZone(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteZone", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteZone indicates an expected call of DeleteZone
+func (mr *MockShippingMockRecorder) DeleteZone(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteZone", reflect.TypeOf((*MockShipping)(nil).DeleteZone), id)
+}
+
+// CreateMethod mocks base method
+func (m *MockShipping) CreateMethod(inp jewerly.CreateShippingMethodInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMethod", inp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMethod indicates an expected call of CreateMethod
+func (mr *MockShippingMockRecorder) CreateMethod(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMethod", reflect.TypeOf((*MockShipping)(nil).CreateMethod), inp)
+}
+
+// UpdateMethod mocks base method
+func (m *MockShipping) UpdateMethod(id int, inp jewerly.UpdateShippingMethodInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMethod", id, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMethod indicates an expected call of UpdateMethod
+func (mr *MockShippingMockRecorder) UpdateMethod(id, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMethod", reflect.TypeOf((*MockShipping)(nil).UpdateMethod), id, inp)
+}
+
+// DeleteMethod mocks base method
+func (m *MockShipping) DeleteMethod(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMethod", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMethod indicates an expected call of DeleteMethod
+func (mr *MockShippingMockRecorder) DeleteMethod(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMethod", reflect.TypeOf((*MockShipping)(nil).DeleteMethod), id)
+}
+
+// GetOptions mocks base method
+func (m *MockShipping) GetOptions(country string, weight int, orderSum jewerly.Money) ([]jewerly.ShippingOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptions", country, weight, orderSum)
+	ret0, _ := ret[0].([]jewerly.ShippingOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptions indicates an expected call of GetOptions
+func (mr *MockShippingMockRecorder) GetOptions(country, weight, orderSum interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptions", reflect.TypeOf((*MockShipping)(nil).GetOptions), country, weight, orderSum)
+}
+
+// Calculate mocks base method
+func (m *MockShipping) Calculate(country string, methodId, weight int, orderSum jewerly.Money) (jewerly.OrderShipping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Calculate", country, methodId, weight, orderSum)
+	ret0, _ := ret[0].(jewerly.OrderShipping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Calculate indicates an expected call of Calculate
+func (mr *MockShippingMockRecorder) Calculate(country, methodId, weight, orderSum interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Calculate", reflect.TypeOf((*MockShipping)(nil).Calculate), country, methodId, weight, orderSum)
+}
+
+// MockTax is a mock of Tax interface
+type MockTax struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxMockRecorder
+}
+
+// MockTaxMockRecorder is the mock recorder for MockTax
+type MockTaxMockRecorder struct {
+	mock *MockTax
+}
+
+// NewMockTax creates a new mock instance
+func NewMockTax(ctrl *gomock.Controller) *MockTax {
+	mock := &MockTax{ctrl: ctrl}
+	mock.recorder = &MockTaxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTax) EXPECT() *MockTaxMockRecorder {
+	return m.recorder
+}
+
+// GetRates mocks base method
+func (m *MockTax) GetRates() ([]jewerly.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRates")
+	ret0, _ := ret[0].([]jewerly.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRates indicates an expected call of GetRates
+func (mr *MockTaxMockRecorder) GetRates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockTax)(nil).GetRates))
+}
+
+// SetRate mocks base method
+func (m *MockTax) SetRate(country string, inp jewerly.SetTaxRateInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRate", country, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRate indicates an expected call of SetRate
+func (mr *MockTaxMockRecorder) SetRate(country, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRate", reflect.TypeOf((*MockTax)(nil).SetRate), country, inp)
+}
+
+// DeleteRate mocks base method
+func (m *MockTax) DeleteRate(country string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRate", country)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRate indicates an expected call of DeleteRate
+func (mr *MockTaxMockRecorder) DeleteRate(country interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRate", reflect.TypeOf((*MockTax)(nil).DeleteRate), country)
+}
+
+// Calculate mocks base method
+func (m *MockTax) Calculate(country string, amount jewerly.Money) (jewerly.OrderTax, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Calculate", country, amount)
+	ret0, _ := ret[0].(jewerly.OrderTax)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Calculate indicates an expected call of Calculate
+func (mr *MockTaxMockRecorder) Calculate(country, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Calculate", reflect.TypeOf((*MockTax)(nil).Calculate), country, amount)
+}
+
+// MockInvoice is a mock of Invoice interface
+type MockInvoice struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceMockRecorder
+}
+
+// MockInvoiceMockRecorder is the mock recorder for MockInvoice
+type MockInvoiceMockRecorder struct {
+	mock *MockInvoice
+}
+
+// NewMockInvoice creates a new mock instance
+func NewMockInvoice(ctrl *gomock.Controller) *MockInvoice {
+	mock := &MockInvoice{ctrl: ctrl}
+	mock.recorder = &MockInvoiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockInvoice) EXPECT() *MockInvoiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockInvoice) Create(orderId int) (jewerly.Invoice, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", orderId)
+	ret0, _ := ret[0].(jewerly.Invoice)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create
+func (mr *MockInvoiceMockRecorder) Create(orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInvoice)(nil).Create), orderId)
+}
+
+// GetByOrderId mocks base method
+func (m *MockInvoice) GetByOrderId(orderId int) (jewerly.Invoice, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrderId", orderId)
+	ret0, _ := ret[0].(jewerly.Invoice)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByOrderId indicates an expected call of GetByOrderId
+func (mr *MockInvoiceMockRecorder) GetByOrderId(orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrderId", reflect.TypeOf((*MockInvoice)(nil).GetByOrderId), orderId)
+}
+
+// MockSettings is a mock of Settings interface
+type MockSettings struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettingsMockRecorder
+}
+
+// MockSettingsMockRecorder is the mock recorder for MockSettings
+type MockSettingsMockRecorder struct {
+	mock *MockSettings
+}
+
+// NewMockSettings creates a new mock instance
+func NewMockSettings(ctrl *gomock.Controller) *MockSettings {
+	mock := &MockSettings{ctrl: ctrl}
+	mock.recorder = &MockSettingsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSettings) EXPECT() *MockSettingsMockRecorder {
+	return m.recorder
+}
+
+// GetSettings mocks base method
+func (m *MockSettings) GetSettings() (jewerly.Settings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings")
+	ret0, _ := ret[0].(jewerly.Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings
+func (mr *MockSettingsMockRecorder) GetSettings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockSettings)(nil).GetSettings))
+}
+
+// GetImages mocks base method
+func (m *MockSettings) GetImages() ([]jewerly.HomepageImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImages")
+	ret0, _ := ret[0].([]jewerly.HomepageImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImages indicates an expected call of GetImages
+func (mr *MockSettingsMockRecorder) GetImages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImages", reflect.TypeOf((*MockSettings)(nil).GetImages))
+}
+
+// CreateImage mocks base method
+func (m *MockSettings) CreateImage(imageID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImage", imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateImage indicates an expected call of CreateImage
+func (mr *MockSettingsMockRecorder) CreateImage(imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImage", reflect.TypeOf((*MockSettings)(nil).CreateImage), imageID)
+}
+
+// UpdateImage mocks base method
+func (m *MockSettings) UpdateImage(id, imageID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateImage", id, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateImage indicates an expected call of UpdateImage
+func (mr *MockSettingsMockRecorder) UpdateImage(id, imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImage", reflect.TypeOf((*MockSettings)(nil).UpdateImage), id, imageID)
+}
+
+// GetTextBlocks mocks base method
+func (m *MockSettings) GetTextBlocks() ([]jewerly.TextBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTextBlocks")
+	ret0, _ := ret[0].([]jewerly.TextBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTextBlocks indicates an expected call of GetTextBlocks
+func (mr *MockSettingsMockRecorder) GetTextBlocks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTextBlocks", reflect.TypeOf((*MockSettings)(nil).GetTextBlocks))
+}
+
+// GetTextBlockById mocks base method
+func (m *MockSettings) GetTextBlockById(id int) (jewerly.TextBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTextBlockById", id)
+	ret0, _ := ret[0].(jewerly.TextBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTextBlockById indicates an expected call of GetTextBlockById
+func (mr *MockSettingsMockRecorder) GetTextBlockById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTextBlockById", reflect.TypeOf((*MockSettings)(nil).GetTextBlockById), id)
+}
+
+// CreateTextBlock mocks base method
+func (m *MockSettings) CreateTextBlock(block jewerly.TextBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTextBlock", block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTextBlock indicates an expected call of CreateTextBlock
+func (mr *MockSettingsMockRecorder) CreateTextBlock(block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTextBlock", reflect.TypeOf((*MockSettings)(nil).CreateTextBlock), block)
+}
+
+// UpdateTextBlock mocks base method
+func (m *MockSettings) UpdateTextBlock(id int, block jewerly.UpdateTextBlockInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTextBlock", id, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTextBlock indicates an expected call of UpdateTextBlock
+func (mr *MockSettingsMockRecorder) UpdateTextBlock(id, block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTextBlock", reflect.TypeOf((*MockSettings)(nil).UpdateTextBlock), id, block)
 }
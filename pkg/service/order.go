@@ -1,53 +1,146 @@
 package service
 
 import (
+	"crypto/subtle"
 	"errors"
 	"fmt"
+	"github.com/dgrijalva/jwt-go"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	jewerly "github.com/zhashkevych/jewelry-shop-backend"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/payment"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository"
+	"gopkg.in/guregu/null.v3"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
 const (
-	defaultCurrency = "USD"
-	timeFomrat      = "2006-01-02 15:04:05"
+	timeFomrat = "2006-01-02 15:04:05"
+
+	orderTokenAudience = "order"
 )
 
 var paymentStatuses = map[string]string{
 	"sale-complete":          jewerly.TransactionStatusPaid,
 	"sale-authorized":        jewerly.TransactionStatusAuthorized,
+	"sale-void":              jewerly.TransactionStatusVoided,
 	"refund":                 jewerly.TransactionStatusRefunded,
+	"partial-refund":         jewerly.TransactionStatusPartiallyRefunded,
 	"sale-failure":           jewerly.TransactionStatusFailed,
 	"sale-chargeback":        jewerly.TransactionStatusChargeback,
 	"sale-chargeback-refund": jewerly.TransactionStatusReverted,
 }
 
+// order is considered paid once any of these callbacks is received
+var paidNotifyTypes = []string{"sale-complete", "sale-authorized"}
+
+// refund callbacks confirm refunds made by the admin, their status is saved when the refund is completed
+var refundNotifyTypes = []string{"refund", "partial-refund"}
+
+// payment of the order can be refunded only in these statuses
+var refundableStatuses = []string{jewerly.OrderStatusPaid, jewerly.OrderStatusPacked, jewerly.OrderStatusShipped, jewerly.OrderStatusDelivered}
+
+type OrderDeps struct {
+	MinimalOrderSum jewerly.Money
+	SigningKey      []byte
+
+	// LookupURL is the storefront order status page, lookup token is passed to it as query parameter.
+	LookupURL string
+
+	// LookupTokenTTL limits how long the link from the confirmation email shows the order,
+	// after that the customer looks it up with the order id and email.
+	LookupTokenTTL time.Duration
+
+	// ReservationTTL is how long stock is reserved for unpaid order before it's cancelled.
+	ReservationTTL time.Duration
+
+	// CallbackToken is the secret added to the payment callback URL, callbacks without it are rejected.
+	CallbackToken string
+
+	// AuthorizationTTL is how long authorized payment waits for the capture before it's voided,
+	// it should be shorter than the provider keeps the authorization.
+	AuthorizationTTL time.Duration
+}
+
 type OrderService struct {
 	repo            repository.Order
+	discrepancyRepo repository.Reconciliation
 	paymentProvider payment.Provider
 	emailService    Email
-	minimalOrderSum float32
+	promoService    Promo
+	currencyService Currency
+	shippingService Shipping
+	taxService      Tax
+	invoiceService  Invoice
+	OrderDeps
 }
 
-func NewOrderService(repo repository.Order, paymentProvider payment.Provider, emailService Email, minimalOrderSum float32) *OrderService {
-	return &OrderService{repo: repo, paymentProvider: paymentProvider, emailService: emailService, minimalOrderSum: minimalOrderSum}
+func NewOrderService(repo repository.Order, discrepancyRepo repository.Reconciliation, paymentProvider payment.Provider,
+	emailService Email, promoService Promo, currencyService Currency, shippingService Shipping, taxService Tax, invoiceService Invoice,
+	deps OrderDeps) *OrderService {
+	return &OrderService{repo: repo, discrepancyRepo: discrepancyRepo, paymentProvider: paymentProvider, emailService: emailService, promoService: promoService,
+		currencyService: currencyService, shippingService: shippingService, taxService: taxService, invoiceService: invoiceService,
+		OrderDeps: deps}
 }
 
+// Create calculates order sum in the base currency and charges the customer in the order currency,
+// exchange rate is saved with the order so its totals can be reproduced later.
+// Shipping cost is added to the total after the minimal order sum is checked,
+// tax is calculated from the amount in the order currency, so net and tax sum up to the charged total exactly.
+// Payment for made to order products is only authorized, it's captured once the admin confirms the order.
 func (s *OrderService) Create(input jewerly.CreateOrderInput) (string, error) {
+	rate, err := s.currencyService.GetRate(input.Currency)
+	if err != nil {
+		return "", err
+	}
+
+	input.Currency = rate.Currency
+	input.ExchangeRate = rate.Rate
+
 	totalCost, products, err := s.getOrderTotalCost(input.Items)
 	if err != nil {
 		logrus.Errorf("failed to get total order cost: %s", err.Error())
 		return "", err
 	}
 
-	if totalCost < s.minimalOrderSum {
+	if input.PromoCode != "" {
+		discount, err := s.promoService.Apply(input.PromoCode, input.Email, input.Items, products)
+		if err != nil {
+			return "", err
+		}
+
+		input.Discount = discount
+		totalCost -= discount.Amount
+	}
+
+	if totalCost < s.MinimalOrderSum {
 		return "", jewerly.ErrOrderSumLow
 	}
 
-	input.TotalCost = totalCost
+	shipping, err := s.shippingService.Calculate(input.Country, input.ShippingMethodId, getOrderWeight(input.Items, products), totalCost)
+	if err != nil {
+		return "", err
+	}
+
+	if input.Discount.FreeShipping {
+		shipping.Cost = 0
+	}
+
+	input.Shipping = shipping
+	input.Shipping.Cost = rate.Convert(shipping.Cost)
+	input.Discount.Amount = rate.Convert(input.Discount.Amount)
+
+	tax, err := s.taxService.Calculate(input.Country, rate.Convert(totalCost)+input.Shipping.Cost)
+	if err != nil {
+		logrus.Errorf("failed to calculate order tax: %s", err.Error())
+		return "", err
+	}
+
+	input.Tax = tax
+	input.TotalCost = tax.Gross
 
 	transactionId, err := s.generateTransactionId()
 	if err != nil {
@@ -65,16 +158,22 @@ func (s *OrderService) Create(input jewerly.CreateOrderInput) (string, error) {
 
 	// generate form with transaction id
 	url, err := s.paymentProvider.GenerateSale(payment.GenerateSaleInput{
-		Price:         int(input.TotalCost * 100),
+		Price:         input.TotalCost,
 		ProductName:   fmt.Sprintf("Order #%d", orderId),
 		TransactionID: input.TransactionID,
-		Currency:      defaultCurrency, // todo: implement currency input
+		Currency:      input.Currency,
+		AuthorizeOnly: hasMadeToOrder(products),
 	})
 	if err != nil {
 		logrus.Errorf("failed to generate sale form: %s", err.Error())
 		return "", err
 	}
 
+	lookupURL, err := s.getLookupURL(orderId)
+	if err != nil {
+		logrus.Errorf("failed to generate order lookup url: %s", err.Error())
+	}
+
 	// send order email to support
 	go s.sendOrderEmails(jewerly.OrderInfoEmailInput{
 		OrderId:           orderId,
@@ -84,26 +183,208 @@ func (s *OrderService) Create(input jewerly.CreateOrderInput) (string, error) {
 		Address:           input.Address,
 		PostalCode:        input.PostalCode,
 		Email:             input.Email,
-		TotalCost:         fmt.Sprintf("%.2f", input.TotalCost),
+		TotalCost:         input.TotalCost,
+		Currency:          input.Currency,
+		PromoCode:         input.Discount.Code,
+		Discount:          input.Discount.Amount,
+		FreeShipping:      input.Discount.FreeShipping,
+		ShippingMethod:    input.Shipping.Method,
+		ShippingCost:      input.Shipping.Cost,
+		NetAmount:         input.Tax.Net,
+		TaxAmount:         input.Tax.Tax,
+		TaxRate:           input.Tax.Rate,
+		TaxInclusive:      input.Tax.Inclusive,
 		TransactionId:     transactionId,
 		OrderedAt:         time.Now(),
 		TransactionStatus: jewerly.TransactionStatusCreated,
-		Products:          createOrderProductsList(input.Items, products),
+		Products:          createOrderProductsList(input.Items, products, rate),
+		LookupURL:         lookupURL,
 	})
 
 	url = urlWithParameters(url, input)
 
+	// payment url is used later to remind the customer about unpaid order
+	if err := s.repo.SetPaymentURL(transactionId, url); err != nil {
+		logrus.Errorf("failed to save payment url: %s", err.Error())
+	}
+
 	return url, nil
 }
 
-func (s *OrderService) ProcessCallback(inp jewerly.TransactionCallbackInput) {
-	err := s.repo.CreateTransaction(inp.TransactionID, inp.BuyerCardMask, inp.NotifyType)
+// GetShippingOptions returns shipping methods available for the items with costs in the currency.
+func (s *OrderService) GetShippingOptions(inp jewerly.ShippingQuoteInput, currency string) ([]jewerly.ShippingOption, error) {
+	rate, err := s.currencyService.GetRate(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	totalCost, products, err := s.getOrderTotalCost(inp.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := s.shippingService.GetOptions(inp.Country, getOrderWeight(inp.Items, products), totalCost)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range options {
+		options[i].Cost = rate.Convert(options[i].Cost)
+		options[i].Currency = rate.Currency
+	}
+
+	return options, nil
+}
+
+// ParseCallback reads the notification in the format of the configured payment provider.
+func (s *OrderService) ParseCallback(r *http.Request) (jewerly.TransactionCallbackInput, error) {
+	return s.paymentProvider.ParseCallback(r)
+}
+
+// ProcessCallback saves transaction status from the payment provider callback, callbacks without the secret token are rejected.
+// Repeated deliveries of the same notification are accepted but processed only once.
+func (s *OrderService) ProcessCallback(inp jewerly.TransactionCallbackInput) error {
+	if s.CallbackToken == "" || subtle.ConstantTimeCompare([]byte(inp.Token), []byte(s.CallbackToken)) != 1 {
+		return jewerly.ErrInvalidCallbackToken
+	}
+
+	err := s.ApplyTransaction(inp)
+	if err == jewerly.ErrDuplicateCallback {
+		logrus.Infof("transactionId: %s, duplicate %s callback is skipped", inp.TransactionID, inp.NotifyType)
+		return nil
+	}
+
+	return err
+}
+
+// ApplyTransaction saves transaction status reported by the payment provider, updates the order and notifies the customer.
+// Statuses for unknown transactions or with the payment amount different from the order total are rejected,
+// ErrDuplicateCallback is returned if the status is already saved. Payment of the cancelled order is reported
+// as a discrepancy for the admin instead of notifying the customer.
+func (s *OrderService) ApplyTransaction(inp jewerly.TransactionCallbackInput) error {
+	if _, err := uuid.Parse(inp.TransactionID); err != nil {
+		return jewerly.ErrTransactionNotFound
+	}
+
+	orderId, err := s.repo.GetOrderId(inp.TransactionID)
+	if err != nil {
+		return err
+	}
+
+	if isRefundNotifyType(inp.NotifyType) {
+		return s.applyRefundTransaction(orderId, inp)
+	}
+
+	if isPaidNotifyType(inp.NotifyType) {
+		if err := s.checkPaymentAmount(orderId, inp); err != nil {
+			return err
+		}
+	}
+
+	err = s.repo.CreateTransaction(inp.TransactionID, inp.EventId, inp.BuyerCardMask, inp.NotifyType)
+	if err == jewerly.ErrDuplicateCallback {
+		return err
+	}
+
 	if err != nil {
 		logrus.Errorf("failed to create transaction on callback: %s", err.Error())
-		return
+		return err
 	}
 
-	go s.sendPaymentEmail(inp)
+	// sale id is required to refund the payment later
+	if isPaidNotifyType(inp.NotifyType) && inp.SaleId != "" {
+		if err := s.repo.SetSaleId(inp.TransactionID, inp.SaleId); err != nil {
+			logrus.Errorf("transactionId: %s, failed to save sale id: %s", inp.TransactionID, err.Error())
+		}
+	}
+
+	err = s.syncOrderStatus(orderId, inp)
+	if err == jewerly.ErrOrderStatusTransition && isPaidNotifyType(inp.NotifyType) && s.isOrderCancelled(orderId) {
+		// stock of the cancelled order could be sold already, so the customer isn't told it's paid
+		// and the admin decides to refund or restore the order
+		s.reportCancelledOrderPayment(orderId, inp)
+		return nil
+	}
+
+	go s.sendPaymentEmail(orderId, inp)
+
+	return nil
+}
+
+// applyRefundTransaction matches the refund callback with the order refund by the provider refund id, that is
+// the callback event id. Refund made in the provider dashboard isn't saved with the order, it's reported to the admin.
+func (s *OrderService) applyRefundTransaction(orderId int, inp jewerly.TransactionCallbackInput) error {
+	order, err := s.repo.GetById(orderId)
+	if err != nil {
+		return err
+	}
+
+	for _, refund := range order.Refunds {
+		if inp.EventId != "" && refund.ProviderRefundId.String == inp.EventId {
+			return jewerly.ErrDuplicateCallback
+		}
+	}
+
+	// provider refund id is saved when the refund is completed, the provider retries the callback after that
+	for _, refund := range order.Refunds {
+		if refund.Status == jewerly.RefundStatusPending {
+			return jewerly.ErrRefundConflict
+		}
+	}
+
+	logrus.Warnf("transactionId: %s, %s %s of order %d doesn't match any refund", inp.TransactionID, inp.NotifyType, inp.EventId, orderId)
+
+	return s.discrepancyRepo.CreateDiscrepancy(jewerly.PaymentDiscrepancy{
+		OrderId:        orderId,
+		TransactionId:  inp.TransactionID,
+		LocalStatus:    order.Status,
+		ProviderStatus: inp.NotifyType,
+		Kind:           jewerly.DiscrepancyUnmatchedRefund,
+		Details: fmt.Sprintf("refund %s of %s %s is made in the provider, but isn't saved with the order", inp.EventId,
+			jewerly.Money(inp.Price), inp.Currency),
+	})
+}
+
+func (s *OrderService) isOrderCancelled(orderId int) bool {
+	order, err := s.repo.GetById(orderId)
+	if err != nil {
+		logrus.Errorf("failed to get order %d: %s", orderId, err.Error())
+		return false
+	}
+
+	return order.Status == jewerly.OrderStatusCancelled
+}
+
+func (s *OrderService) reportCancelledOrderPayment(orderId int, inp jewerly.TransactionCallbackInput) {
+	logrus.Warnf("transactionId: %s, payment %s is received for cancelled order %d", inp.TransactionID, inp.NotifyType, orderId)
+
+	err := s.discrepancyRepo.CreateDiscrepancy(jewerly.PaymentDiscrepancy{
+		OrderId:        orderId,
+		TransactionId:  inp.TransactionID,
+		LocalStatus:    jewerly.OrderStatusCancelled,
+		ProviderStatus: inp.NotifyType,
+		Kind:           jewerly.DiscrepancyCancelledOrderPaid,
+		Details:        "payment is received for the cancelled order",
+	})
+	if err != nil {
+		logrus.Errorf("failed to report payment of cancelled order %d: %s", orderId, err.Error())
+	}
+}
+
+// checkPaymentAmount compares charged price and currency with the order total, which is in the order currency.
+func (s *OrderService) checkPaymentAmount(orderId int, inp jewerly.TransactionCallbackInput) error {
+	order, err := s.repo.GetById(orderId)
+	if err != nil {
+		return err
+	}
+
+	if int64(inp.Price) != order.TotalCost.MinorUnits() || !strings.EqualFold(inp.Currency, order.Currency) {
+		logrus.Errorf("transactionId: %s, paid %d %s for order %d with total %s %s", inp.TransactionID, inp.Price, inp.Currency,
+			orderId, order.TotalCost, order.Currency)
+		return jewerly.ErrPaymentAmountMismatch
+	}
+
+	return nil
 }
 
 func (s *OrderService) GetAll(input jewerly.GetAllOrdersFilters) (jewerly.OrderList, error) {
@@ -114,25 +395,459 @@ func (s *OrderService) GetById(id int) (jewerly.Order, error) {
 	return s.repo.GetById(id)
 }
 
-func (s *OrderService) getOrderTotalCost(orderItems []jewerly.OrderItem) (float32, []jewerly.ProductResponse, error) {
+// Lookup returns order to a customer identified either by order email or by the token from the confirmation email.
+func (s *OrderService) Lookup(inp jewerly.OrderLookupInput) (jewerly.CustomerOrder, error) {
+	orderId := inp.OrderId
+	if inp.Token != "" {
+		id, err := s.parseLookupToken(inp.Token)
+		if err != nil {
+			return jewerly.CustomerOrder{}, jewerly.ErrInvalidOrderToken
+		}
+		orderId = id
+	}
+
+	order, err := s.repo.GetById(orderId)
+	if err != nil {
+		return jewerly.CustomerOrder{}, err
+	}
+
+	// don't reveal whether the order exists when email doesn't match
+	if inp.Token == "" && !strings.EqualFold(order.Email, strings.TrimSpace(inp.Email)) {
+		return jewerly.CustomerOrder{}, jewerly.ErrOrderNotFound
+	}
+
+	items, err := s.repo.GetItemsDetails([]int{orderId}, inp.Language)
+	if err != nil {
+		return jewerly.CustomerOrder{}, err
+	}
+
+	return newCustomerOrder(order, items[orderId]), nil
+}
+
+func (s *OrderService) GetUserOrders(userId int64, filters jewerly.GetAllOrdersFilters, language string) (jewerly.CustomerOrderList, error) {
+	filters.UserId = null.IntFrom(userId)
+
+	orders, err := s.repo.GetAll(filters)
+	if err != nil {
+		return jewerly.CustomerOrderList{}, err
+	}
+
+	list := jewerly.CustomerOrderList{
+		Data:       make([]jewerly.CustomerOrder, len(orders.Data)),
+		Total:      orders.Total,
+		NextCursor: orders.NextCursor,
+	}
+
+	if len(orders.Data) == 0 {
+		return list, nil
+	}
+
+	ids := make([]int, len(orders.Data))
+	for i := range orders.Data {
+		ids[i] = orders.Data[i].Id
+	}
+
+	items, err := s.repo.GetItemsDetails(ids, language)
+	if err != nil {
+		return jewerly.CustomerOrderList{}, err
+	}
+
+	for i, order := range orders.Data {
+		list.Data[i] = newCustomerOrder(order, items[order.Id])
+	}
+
+	return list, nil
+}
+
+func (s *OrderService) UpdateStatus(id int, status, changedBy string) error {
+	// authorized payment is captured or voided with the provider, changing the status alone doesn't move the money
+	if status == jewerly.OrderStatusPaid || status == jewerly.OrderStatusCancelled {
+		order, err := s.repo.GetById(id)
+		if err != nil {
+			return err
+		}
+
+		if order.Status == jewerly.OrderStatusAuthorized {
+			return jewerly.ErrPaymentAuthorized
+		}
+	}
+
+	return s.repo.UpdateStatus(id, status, changedBy)
+}
+
+func (s *OrderService) Ship(id int, inp jewerly.ShipOrderInput, changedBy string) error {
+	if err := s.repo.SetShipment(id, inp, changedBy); err != nil {
+		return err
+	}
+
+	go s.sendShippingEmail(id)
+
+	return nil
+}
+
+// Refund returns the amount in the order currency to the customer through the payment provider,
+// the rest of the paid amount is refunded when the amount isn't passed. Returned items are put back in stock,
+// full refund of the order that isn't shipped yet restocks all its items.
+func (s *OrderService) Refund(orderId int, inp jewerly.RefundOrderInput, createdBy string) (int, error) {
+	order, err := s.repo.GetById(orderId)
+	if err != nil {
+		return 0, err
+	}
+
+	if !isRefundableStatus(order.Status) {
+		return 0, jewerly.ErrRefundNotAvailable
+	}
+
+	orderPayment, err := s.repo.GetPayment(orderId)
+	if err != nil {
+		return 0, err
+	}
+
+	if !orderPayment.SaleId.Valid {
+		return 0, jewerly.ErrRefundNotAvailable
+	}
+
+	var refunded jewerly.Money
+	for _, refund := range order.Refunds {
+		refunded += refund.Amount
+	}
+
+	remaining := order.TotalCost - refunded
+	amount := remaining
+	if inp.Amount.Valid {
+		amount = inp.Amount.Money
+	}
+
+	if amount <= 0 || amount > remaining {
+		return 0, jewerly.ErrRefundAmountExceeded
+	}
+
+	returnable := getReturnableItems(order)
+	if err := checkRefundItems(inp.Items, returnable); err != nil {
+		return 0, err
+	}
+
+	refund := jewerly.Refund{
+		OrderId:   orderId,
+		Amount:    amount,
+		Reason:    inp.Reason,
+		Full:      amount == remaining,
+		CreatedBy: createdBy,
+		Items:     inp.Items,
+	}
+
+	if refund.Full && len(refund.Items) == 0 && (order.Status == jewerly.OrderStatusPaid || order.Status == jewerly.OrderStatusPacked) {
+		refund.Items = returnable
+	}
+
+	// refund is reserved first, so the concurrent one is rejected instead of exceeding the paid amount
+	refund.Id, err = s.repo.ReserveRefund(refund, refunded)
+	if err != nil {
+		return 0, err
+	}
+
+	providerRefundId, err := s.paymentProvider.Refund(payment.RefundInput{SaleId: orderPayment.SaleId.String, Amount: amount})
+	if err != nil {
+		logrus.Errorf("failed to refund order %d payment: %s", orderId, err.Error())
+
+		if err := s.repo.CancelRefund(refund.Id); err != nil {
+			logrus.Errorf("failed to cancel pending refund %d of order %d: %s", refund.Id, orderId, err.Error())
+		}
+
+		return 0, err
+	}
+
+	refund.Status = jewerly.RefundStatusCompleted
+	refund.ProviderRefundId = null.NewString(providerRefundId, providerRefundId != "")
+	if err := s.repo.CompleteRefund(refund, orderPayment.TransactionId); err != nil {
+		// the money is already returned, so the admin completes the pending refund with CompleteRefund
+		logrus.Errorf("order %d refund %d of %s %s is made with provider refund id %s, but not completed: %s", orderId, refund.Id,
+			amount, order.Currency, providerRefundId, err.Error())
+		return 0, jewerly.ErrRefundNotCompleted
+	}
+
+	go s.sendRefundEmail(order, refund, refunded+amount)
+
+	return refund.Id, nil
+}
+
+// CompleteRefund saves the pending refund, which is made in the payment provider, but wasn't completed because of an error.
+// The admin checks the refund in the provider first, the refund can't be cancelled once it's completed.
+func (s *OrderService) CompleteRefund(orderId, refundId int, inp jewerly.CompleteRefundInput) error {
+	order, err := s.repo.GetById(orderId)
+	if err != nil {
+		return err
+	}
+
+	var refund jewerly.Refund
+	var refunded jewerly.Money
+	for _, orderRefund := range order.Refunds {
+		if orderRefund.Id == refundId && orderRefund.Status == jewerly.RefundStatusPending {
+			refund = orderRefund
+		}
+		refunded += orderRefund.Amount
+	}
+
+	if refund.Id == 0 {
+		return jewerly.ErrRefundNotFound
+	}
+
+	orderPayment, err := s.repo.GetPayment(orderId)
+	if err != nil {
+		return err
+	}
+
+	refund.Status = jewerly.RefundStatusCompleted
+	refund.ProviderRefundId = null.NewString(inp.ProviderRefundId, inp.ProviderRefundId != "")
+	if err := s.repo.CompleteRefund(refund, orderPayment.TransactionId); err != nil {
+		return err
+	}
+
+	go s.sendRefundEmail(order, refund, refunded)
+
+	return nil
+}
+
+// Capture charges the authorized payment of the order, so it can be produced and shipped.
+func (s *OrderService) Capture(orderId int, changedBy string) error {
+	order, orderPayment, err := s.getAuthorizedPayment(orderId)
+	if err != nil {
+		return err
+	}
+
+	eventId, err := s.paymentProvider.Capture(payment.CaptureInput{SaleId: orderPayment.SaleId.String, Amount: order.TotalCost})
+	if err != nil {
+		logrus.Errorf("failed to capture order %d payment: %s", orderId, err.Error())
+		return err
+	}
+
+	inp := jewerly.TransactionCallbackInput{
+		NotifyType:    "sale-complete",
+		TransactionID: orderPayment.TransactionId,
+		EventId:       eventId,
+		BuyerCardMask: getCardMask(order.Transactions),
+		BuyerName:     fmt.Sprintf("%s %s", order.FirstName, order.LastName),
+		BuyerEmail:    order.Email,
+		Price:         int(order.TotalCost.MinorUnits()),
+		Currency:      order.Currency,
+	}
+
+	// capture event id is saved, so the provider callback of the capture is skipped as a duplicate
+	captured, err := s.repo.CompleteAuthorization(orderId, inp.TransactionID, inp.EventId, inp.BuyerCardMask, inp.NotifyType,
+		jewerly.OrderStatusPaid, changedBy)
+	if err != nil {
+		// the money is already charged, so the order has to be updated manually
+		logrus.Errorf("order %d payment of %s %s is captured, but not saved: %s", orderId, order.TotalCost, order.Currency, err.Error())
+		return err
+	}
+
+	// payment email is sent on the provider callback if it was processed first
+	if captured {
+		go s.sendPaymentEmail(orderId, inp)
+	}
+
+	return nil
+}
+
+// Void releases the amount held on the customer card and cancels the order, reserved stock is released.
+func (s *OrderService) Void(orderId int, changedBy string) error {
+	_, orderPayment, err := s.getAuthorizedPayment(orderId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.paymentProvider.Void(orderPayment.SaleId.String); err != nil {
+		logrus.Errorf("failed to void order %d payment: %s", orderId, err.Error())
+		return err
+	}
+
+	_, err = s.repo.CompleteAuthorization(orderId, orderPayment.TransactionId, "", "", "sale-void", jewerly.OrderStatusCancelled, changedBy)
+	if err != nil {
+		logrus.Errorf("order %d payment is voided, but not saved: %s", orderId, err.Error())
+	}
+
+	return err
+}
+
+func (s *OrderService) getAuthorizedPayment(orderId int) (jewerly.Order, jewerly.OrderPayment, error) {
+	order, err := s.repo.GetById(orderId)
+	if err != nil {
+		return order, jewerly.OrderPayment{}, err
+	}
+
+	if order.Status != jewerly.OrderStatusAuthorized {
+		return order, jewerly.OrderPayment{}, jewerly.ErrOrderNotAuthorized
+	}
+
+	orderPayment, err := s.repo.GetPayment(orderId)
+	if err != nil {
+		return order, orderPayment, err
+	}
+
+	if !orderPayment.SaleId.Valid {
+		return order, orderPayment, jewerly.ErrOrderNotAuthorized
+	}
+
+	return order, orderPayment, nil
+}
+
+// VoidStaleAuthorizations voids payments which weren't captured in time, before the provider expires them.
+func (s *OrderService) VoidStaleAuthorizations() error {
+	ids, err := s.repo.GetStaleAuthorizedOrderIds(time.Now().Add(-s.AuthorizationTTL))
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := s.Void(id, jewerly.StatusChangedByTimeout); err != nil {
+			logrus.Errorf("failed to void stale authorization of order %d: %s", id, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// syncOrderStatus moves the order to paid or authorized status after successful payment callback,
+// or cancels it releasing reserved stock if authorization was voided. The error is logged and returned for the caller to react.
+func (s *OrderService) syncOrderStatus(orderId int, inp jewerly.TransactionCallbackInput) error {
+	status, err := getPaymentStatus(inp.NotifyType)
+	if err != nil {
+		return nil
+	}
+
+	switch status {
+	// failed payment can be retried on the same sale, so the order keeps its stock
+	// until it's paid or cancelled by CancelExpiredOrders
+	case jewerly.TransactionStatusVoided:
+		if err = s.repo.CancelUnpaid(orderId, jewerly.StatusChangedByPayment); err != nil {
+			logrus.Errorf("failed to cancel order %d after voided payment: %s", orderId, err.Error())
+		}
+	case jewerly.TransactionStatusAuthorized:
+		if err = s.repo.UpdateStatus(orderId, jewerly.OrderStatusAuthorized, jewerly.StatusChangedByPayment); err != nil {
+			logrus.Errorf("failed to set order %d authorized: %s", orderId, err.Error())
+		}
+	case jewerly.TransactionStatusPaid:
+		if err = s.repo.UpdateStatus(orderId, jewerly.OrderStatusPaid, jewerly.StatusChangedByPayment); err != nil {
+			logrus.Errorf("failed to set order %d paid: %s", orderId, err.Error())
+		}
+	}
+
+	return err
+}
+
+// CancelExpiredOrders cancels orders which weren't paid during the reservation time, so their stock can be sold again.
+func (s *OrderService) CancelExpiredOrders() error {
+	ids, err := s.repo.GetUnpaidOrderIds(time.Now().Add(-s.ReservationTTL), paidNotifyTypes)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := s.repo.CancelUnpaid(id, jewerly.StatusChangedByTimeout); err != nil {
+			logrus.Errorf("failed to cancel expired order %d: %s", id, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// getOrderTotalCost calculates the order sum with variant price deltas, product with variants can be ordered only with one selected.
+func (s *OrderService) getOrderTotalCost(orderItems []jewerly.OrderItem) (jewerly.Money, []jewerly.ProductResponse, error) {
 	products, err := s.repo.GetOrderProducts(orderItems)
 	if err != nil {
 		return 0, products, err
 	}
 
-	productsPriceList := make(map[int]float32)
+	productsList := make(map[int]jewerly.ProductResponse)
 	for _, product := range products {
-		productsPriceList[product.Id] = product.Price
+		productsList[product.Id] = product
 	}
 
-	var totalCost float32
-	for _, item := range orderItems {
-		totalCost += productsPriceList[item.ProductId] * float32(item.Quantity)
+	// stock is checked on reservation, made to order products can be ordered when they are out of stock
+	var totalCost jewerly.Money
+	for i, item := range orderItems {
+		product, ok := productsList[item.ProductId]
+		if !ok {
+			return 0, products, jewerly.ErrProductNotFound
+		}
+
+		price, err := getOrderItemPrice(item, product)
+		if err != nil {
+			return 0, products, err
+		}
+
+		// price is saved with the item, so the invoice shows what the customer paid
+		orderItems[i].Price = price
+		orderItems[i].MadeToOrder = product.MadeToOrder
+		totalCost += price.Mul(item.Quantity)
 	}
 
 	return totalCost, products, nil
 }
 
+// getOrderWeight returns weight of the ordered items in grams, variants weigh the same as the product.
+func getOrderWeight(orderItems []jewerly.OrderItem, products []jewerly.ProductResponse) int {
+	weights := make(map[int]int, len(products))
+	for _, product := range products {
+		weights[product.Id] = product.Weight
+	}
+
+	var weight int
+	for _, item := range orderItems {
+		weight += weights[item.ProductId] * item.Quantity
+	}
+
+	return weight
+}
+
+func getOrderItemPrice(item jewerly.OrderItem, product jewerly.ProductResponse) (jewerly.Money, error) {
+	if !item.VariantId.Valid {
+		if len(product.Variants) > 0 {
+			return 0, jewerly.ErrVariantRequired
+		}
+
+		return product.CurrentPrice(), nil
+	}
+
+	variant, ok := product.FindVariant(item.VariantId.Int64)
+	if !ok {
+		return 0, jewerly.ErrVariantNotFound
+	}
+
+	return variant.Price, nil
+}
+
+func (s *OrderService) getLookupURL(orderId int) (string, error) {
+	// link without expiration would expose the customer address forever if it leaks
+	if s.LookupTokenTTL <= 0 {
+		return "", errors.New("order lookup token ttl isn't set")
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.StandardClaims{
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(s.LookupTokenTTL).Unix(),
+		Subject:   strconv.Itoa(orderId),
+		Audience:  orderTokenAudience,
+	})
+
+	signedToken, err := token.SignedString(s.SigningKey)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s?token=%s", s.LookupURL, signedToken), nil
+}
+
+func (s *OrderService) parseLookupToken(token string) (int, error) {
+	claims, err := parseToken(token, s.SigningKey, orderTokenAudience)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(claims.Subject)
+}
+
 func (s *OrderService) generateTransactionId() (string, error) {
 	transactionId, err := uuid.NewUUID()
 	if err != nil {
@@ -152,7 +867,7 @@ func (s *OrderService) sendOrderEmails(inp jewerly.OrderInfoEmailInput) {
 	}
 }
 
-func (s *OrderService) sendPaymentEmail(inp jewerly.TransactionCallbackInput) {
+func (s *OrderService) sendPaymentEmail(orderId int, inp jewerly.TransactionCallbackInput) {
 	status, err := getPaymentStatus(inp.NotifyType)
 	if err != nil {
 		logrus.Errorf("transactionId: %s, err: %s", inp.TransactionID, err.Error())
@@ -164,12 +879,6 @@ func (s *OrderService) sendPaymentEmail(inp jewerly.TransactionCallbackInput) {
 		return
 	}
 
-	orderId, err := s.repo.GetOrderId(inp.TransactionID)
-	if err != nil {
-		logrus.Errorf("failed to get order by transaction id: %s", err.Error())
-		return
-	}
-
 	emailInput := jewerly.PaymentInfoEmailInput{
 		TransactionId: inp.TransactionID,
 		OrderId:       orderId,
@@ -177,10 +886,29 @@ func (s *OrderService) sendPaymentEmail(inp jewerly.TransactionCallbackInput) {
 		CardBrand:     inp.CardBrand,
 		BuyerName:     inp.BuyerName,
 		BuyerEmail:    inp.BuyerEmail,
-		Price:         float32(inp.Price) / 100,
+		Price:         jewerly.Money(inp.Price),
+		Currency:      inp.Currency,
 		Status:        status,
 	}
 
+	// tax breakdown is taken from the order, callback has only the charged price
+	if order, err := s.repo.GetById(orderId); err == nil {
+		emailInput.NetAmount = order.NetAmount
+		emailInput.TaxAmount = order.TaxAmount
+		emailInput.TaxRate = order.TaxRate
+		emailInput.TaxInclusive = order.TaxInclusive
+	} else {
+		logrus.Errorf("failed to get order %d for payment email: %s", orderId, err.Error())
+	}
+
+	// customer still gets the payment email when invoice fails
+	if invoice, file, err := s.invoiceService.Create(orderId); err == nil {
+		emailInput.InvoiceNumber = invoice.DisplayNumber
+		emailInput.InvoiceFile = file
+	} else {
+		logrus.Errorf("failed to create invoice for order %d: %s", orderId, err.Error())
+	}
+
 	if err := s.emailService.SendPaymentInfoSupport(emailInput); err != nil {
 		logrus.Errorf("failed to send payment info support email: %s", err.Error())
 	}
@@ -190,24 +918,214 @@ func (s *OrderService) sendPaymentEmail(inp jewerly.TransactionCallbackInput) {
 	}
 }
 
-func createOrderProductsList(orderItems []jewerly.OrderItem, products []jewerly.ProductResponse) []jewerly.ProductInfo {
-	quantityList := make(map[int]int)
-	for i := range orderItems {
-		quantityList[orderItems[i].ProductId] = orderItems[i].Quantity
+func (s *OrderService) sendShippingEmail(orderId int) {
+	order, err := s.repo.GetById(orderId)
+	if err != nil {
+		logrus.Errorf("failed to get order %d for shipping email: %s", orderId, err.Error())
+		return
+	}
+
+	err = s.emailService.SendShippingInfoCustomer(jewerly.ShippingInfoEmailInput{
+		OrderId:        order.Id,
+		FirstName:      order.FirstName,
+		LastName:       order.LastName,
+		Email:          order.Email,
+		Country:        order.Country,
+		Address:        order.Address,
+		PostalCode:     order.PostalCode,
+		Carrier:        order.Carrier.String,
+		TrackingNumber: order.TrackingNumber.String,
+		TrackingURL:    order.TrackingURL.String,
+	})
+	if err != nil {
+		logrus.Errorf("failed to send shipping info customer email: %s", err.Error())
+	}
+}
+
+func (s *OrderService) sendRefundEmail(order jewerly.Order, refund jewerly.Refund, refunded jewerly.Money) {
+	emailInput := jewerly.RefundEmailInput{
+		OrderId:   order.Id,
+		FirstName: order.FirstName,
+		Email:     order.Email,
+		Amount:    refund.Amount,
+		Refunded:  refunded,
+		TotalCost: order.TotalCost,
+		Currency:  order.Currency,
+		Reason:    refund.Reason,
+		Full:      refund.Full,
 	}
 
-	items := make([]jewerly.ProductInfo, len(products))
-	for i := range products {
-		items[i].Id = products[i].Id
-		items[i].Title = products[i].Title
-		items[i].Price = products[i].Price
-		items[i].Quantity = quantityList[products[i].Id]
+	if len(refund.Items) > 0 {
+		items, err := s.repo.GetItemsDetails([]int{order.Id}, jewerly.English)
+		if err != nil {
+			logrus.Errorf("failed to get order %d items for refund email: %s", order.Id, err.Error())
+		} else {
+			emailInput.Items = createRefundItemsList(refund.Items, items[order.Id])
+		}
+	}
 
-		if len(products[i].Images) > 0 {
-			items[i].ImageURL = products[i].Images[0].URL
+	if err := s.emailService.SendRefundInfoCustomer(emailInput); err != nil {
+		logrus.Errorf("failed to send refund info customer email: %s", err.Error())
+	}
+}
+
+// orderItemKey identifies an order line, the same product can be ordered in different variants.
+type orderItemKey struct {
+	productId int
+	variantId int64
+}
+
+func newOrderItemKey(item jewerly.OrderItem) orderItemKey {
+	return orderItemKey{productId: item.ProductId, variantId: item.VariantId.Int64}
+}
+
+// getReturnableItems returns ordered items without the ones returned with previous refunds.
+func getReturnableItems(order jewerly.Order) []jewerly.OrderItem {
+	returned := make(map[orderItemKey]int)
+	for _, refund := range order.Refunds {
+		for _, item := range refund.Items {
+			returned[newOrderItemKey(item)] += item.Quantity
 		}
 	}
 
+	items := make([]jewerly.OrderItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		key := newOrderItemKey(item)
+
+		quantity := item.Quantity - returned[key]
+		if quantity <= 0 {
+			returned[key] -= item.Quantity
+			continue
+		}
+
+		returned[key] = 0
+		item.Quantity = quantity
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func checkRefundItems(items, returnable []jewerly.OrderItem) error {
+	available := make(map[orderItemKey]int, len(returnable))
+	for _, item := range returnable {
+		available[newOrderItemKey(item)] += item.Quantity
+	}
+
+	for _, item := range items {
+		key := newOrderItemKey(item)
+		if available[key] < item.Quantity {
+			return jewerly.ErrInvalidRefundItems
+		}
+
+		available[key] -= item.Quantity
+	}
+
+	return nil
+}
+
+func createRefundItemsList(refundItems []jewerly.OrderItem, orderItems []jewerly.CustomerOrderItem) []jewerly.ProductInfo {
+	details := make(map[orderItemKey]jewerly.CustomerOrderItem, len(orderItems))
+	for _, item := range orderItems {
+		details[orderItemKey{productId: item.ProductId, variantId: item.VariantId.Int64}] = item
+	}
+
+	items := make([]jewerly.ProductInfo, 0, len(refundItems))
+	for _, item := range refundItems {
+		info := jewerly.ProductInfo{Id: item.ProductId, Quantity: item.Quantity}
+
+		if detail, ok := details[newOrderItemKey(item)]; ok {
+			info.Title = detail.Title
+			if detail.VariantOption.Valid {
+				info.Variant = fmt.Sprintf("%s: %s", detail.VariantOption.String, detail.VariantValue.String)
+			}
+		}
+
+		items = append(items, info)
+	}
+
+	return items
+}
+
+func newCustomerOrder(order jewerly.Order, items []jewerly.CustomerOrderItem) jewerly.CustomerOrder {
+	transactionStatus := jewerly.TransactionStatusCreated
+	if len(order.Transactions) > 0 {
+		// transactions are sorted by creation time, the last one is the actual payment state
+		if status, err := getPaymentStatus(order.Transactions[len(order.Transactions)-1].Status); err == nil {
+			transactionStatus = status
+		}
+	}
+
+	// item prices are stored in the base currency, the order rate shows them the way they were ordered
+	rate := jewerly.ExchangeRate{Currency: order.Currency, Rate: order.ExchangeRate}
+	for i := range items {
+		items[i].Price = rate.Convert(items[i].Price)
+	}
+
+	return jewerly.CustomerOrder{
+		Id:                order.Id,
+		OrderedAt:         order.OrderedAt,
+		FirstName:         order.FirstName,
+		LastName:          order.LastName,
+		Country:           order.Country,
+		Address:           order.Address,
+		PostalCode:        order.PostalCode,
+		TotalCost:         order.TotalCost,
+		PromoCode:         order.PromoCode,
+		Discount:          order.Discount,
+		FreeShipping:      order.FreeShipping,
+		Currency:          order.Currency,
+		ShippingMethod:    order.ShippingMethod,
+		ShippingCost:      order.ShippingCost,
+		NetAmount:         order.NetAmount,
+		TaxAmount:         order.TaxAmount,
+		TaxRate:           order.TaxRate,
+		TaxInclusive:      order.TaxInclusive,
+		Status:            order.Status,
+		StatusUpdatedAt:   order.StatusUpdatedAt,
+		TransactionStatus: transactionStatus,
+		Items:             items,
+		Carrier:           order.Carrier,
+		TrackingNumber:    order.TrackingNumber,
+		TrackingURL:       order.TrackingURL,
+		ShippedAt:         order.ShippedAt,
+	}
+}
+
+func createOrderProductsList(orderItems []jewerly.OrderItem, products []jewerly.ProductResponse, rate jewerly.ExchangeRate) []jewerly.ProductInfo {
+	productsList := make(map[int]jewerly.ProductResponse)
+	for _, product := range products {
+		productsList[product.Id] = product
+	}
+
+	items := make([]jewerly.ProductInfo, 0, len(orderItems))
+	for _, item := range orderItems {
+		product, ok := productsList[item.ProductId]
+		if !ok {
+			continue
+		}
+
+		info := jewerly.ProductInfo{
+			Id:       product.Id,
+			Title:    product.Title,
+			Price:    product.CurrentPrice(),
+			Quantity: item.Quantity,
+		}
+
+		if variant, ok := product.FindVariant(item.VariantId.Int64); item.VariantId.Valid && ok {
+			info.Variant = fmt.Sprintf("%s: %s", variant.Option, variant.Value)
+			info.Price = variant.Price
+		}
+
+		info.Price = rate.Convert(info.Price)
+
+		if len(product.Images) > 0 {
+			info.ImageURL = product.Images[0].URL
+		}
+
+		items = append(items, info)
+	}
+
 	return items
 }
 
@@ -224,3 +1142,54 @@ func getPaymentStatus(notifyType string) (string, error) {
 
 	return status, nil
 }
+
+func isPaidNotifyType(notifyType string) bool {
+	for _, paidType := range paidNotifyTypes {
+		if notifyType == paidType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isRefundNotifyType(notifyType string) bool {
+	for _, refundType := range refundNotifyTypes {
+		if notifyType == refundType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasMadeToOrder(products []jewerly.ProductResponse) bool {
+	for _, product := range products {
+		if product.MadeToOrder {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getCardMask returns the card mask from the latest transaction status that has it.
+func getCardMask(transactions []jewerly.Transaction) string {
+	for i := len(transactions) - 1; i >= 0; i-- {
+		if transactions[i].CardMask.String != "" {
+			return transactions[i].CardMask.String
+		}
+	}
+
+	return ""
+}
+
+func isRefundableStatus(status string) bool {
+	for _, refundableStatus := range refundableStatuses {
+		if status == refundableStatus {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,55 +2,192 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"github.com/hashicorp/go-uuid"
 	"github.com/sirupsen/logrus"
 	jewerly "github.com/zhashkevych/jewelry-shop-backend"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/storage"
+	"gopkg.in/guregu/null.v3"
 	"io"
 )
 
 type ProductService struct {
-	repo        repository.Product
-	fileStorage storage.Storage
+	repo            repository.Product
+	fileStorage     storage.Storage
+	currencyService Currency
 }
 
-func NewProductService(repo repository.Product, fileStorage storage.Storage) *ProductService {
-	return &ProductService{repo: repo, fileStorage: fileStorage}
+func NewProductService(repo repository.Product, fileStorage storage.Storage, currencyService Currency) *ProductService {
+	return &ProductService{repo: repo, fileStorage: fileStorage, currencyService: currencyService}
 }
 
 func (s *ProductService) Create(product jewerly.CreateProductInput) error {
+	if !product.Stock.Valid {
+		product.Stock = null.IntFrom(1)
+	}
+
 	return s.repo.Create(product)
 }
 
 func (s *ProductService) GetAll(filters jewerly.GetAllProductsFilters) (jewerly.ProductsList, error) {
+	rate, err := s.currencyService.GetRate(filters.Currency)
+	if err != nil {
+		return jewerly.ProductsList{}, err
+	}
+
+	// prices are stored in the base currency
+	if filters.MinPrice.Valid {
+		filters.MinPrice.Float64 /= rate.Rate
+	}
+
+	if filters.MaxPrice.Valid {
+		filters.MaxPrice.Float64 /= rate.Rate
+	}
+
 	productList, err := s.repo.GetAll(filters)
 	if err != nil {
 		return productList, err
 	}
 
-	for i, product := range productList.Products {
-		images, err := s.repo.GetProductImages(product.Id)
-		if err != nil {
-			logrus.Errorf("failed to get images for product id %d: %s", product.Id, err.Error())
-			continue
-		}
+	s.setProductsImages(productList.Products)
+	s.setProductsVariants(productList.Products)
+	convertProductsPrices(productList.Products, rate)
 
-		productList.Products[i].Images = images
+	return productList, nil
+}
+
+func (s *ProductService) Search(filters jewerly.SearchProductsFilters) (jewerly.ProductsList, error) {
+	rate, err := s.currencyService.GetRate(filters.Currency)
+	if err != nil {
+		return jewerly.ProductsList{}, err
+	}
+
+	productList, err := s.repo.Search(filters)
+	if err != nil {
+		return productList, err
 	}
 
+	s.setProductsImages(productList.Products)
+	s.setProductsVariants(productList.Products)
+	convertProductsPrices(productList.Products, rate)
+
 	return productList, nil
 }
 
+// convertProductsPrices sets products and their variants prices in the rate currency.
+func convertProductsPrices(products []jewerly.ProductResponse, rate jewerly.ExchangeRate) {
+	for i := range products {
+		products[i].Currency = rate.Currency
+		products[i].Price = rate.Convert(products[i].Price)
+
+		if products[i].SalePrice.Valid {
+			products[i].SalePrice.Money = rate.Convert(products[i].SalePrice.Money)
+		}
+
+		for j := range products[i].Variants {
+			products[i].Variants[j].Price = rate.Convert(products[i].Variants[j].Price)
+			products[i].Variants[j].PriceDelta = rate.Convert(products[i].Variants[j].PriceDelta)
+		}
+	}
+}
+
+func (s *ProductService) setProductsImages(products []jewerly.ProductResponse) {
+	ids := make([]int, len(products))
+	for i := range products {
+		ids[i] = products[i].Id
+	}
+
+	images, err := s.repo.GetProductsImages(ids)
+	if err != nil {
+		logrus.Errorf("failed to get images for products: %s", err.Error())
+		return
+	}
+
+	for i := range products {
+		products[i].Images = images[products[i].Id]
+	}
+}
+
+func (s *ProductService) setProductsVariants(products []jewerly.ProductResponse) {
+	ids := make([]int, len(products))
+	for i := range products {
+		ids[i] = products[i].Id
+	}
+
+	variants, err := s.repo.GetProductsVariants(ids)
+	if err != nil {
+		logrus.Errorf("failed to get variants for products: %s", err.Error())
+		return
+	}
+
+	for i := range products {
+		products[i].Variants = variants[products[i].Id]
+	}
+}
+
 func (s *ProductService) Update(id int, inp jewerly.UpdateProductInput) error {
+	// stock of the product with variants is the sum of variants stock, direct edit would be overwritten
+	if inp.Stock.Valid || inp.InStock.Valid {
+		variants, err := s.repo.GetProductsVariants([]int{id})
+		if err != nil {
+			return err
+		}
+
+		if len(variants[id]) > 0 {
+			return jewerly.ErrStockFromVariants
+		}
+	}
+
+	if err := s.validateSalePrice(id, inp); err != nil {
+		return err
+	}
+
 	return s.repo.Update(id, inp)
 }
 
+// validateSalePrice checks the sale price against the stored price when only one of them is updated,
+// input validation compares them when both are passed.
+func (s *ProductService) validateSalePrice(id int, inp jewerly.UpdateProductInput) error {
+	if inp.RemoveSale || inp.Price.Valid == inp.SalePrice.Valid {
+		return nil
+	}
+
+	product, err := s.repo.GetById(id, jewerly.English)
+	if err == sql.ErrNoRows {
+		return jewerly.ErrProductNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	price, salePrice := product.Price, product.SalePrice
+	if inp.Price.Valid {
+		price = inp.Price.Money
+	}
+
+	if inp.SalePrice.Valid {
+		salePrice = inp.SalePrice
+	}
+
+	if salePrice.Valid && salePrice.Money >= price {
+		return jewerly.ErrInvalidSale
+	}
+
+	return nil
+}
+
 func (s *ProductService) Delete(id int) error {
 	return s.repo.Delete(id)
 }
 
-func (s *ProductService) GetById(id int, language string) (jewerly.ProductResponse, error) {
+func (s *ProductService) GetById(id int, language, currency string) (jewerly.ProductResponse, error) {
+	rate, err := s.currencyService.GetRate(currency)
+	if err != nil {
+		return jewerly.ProductResponse{}, err
+	}
+
 	product, err := s.repo.GetById(id, language)
 	if err != nil {
 		return product, err
@@ -64,7 +201,34 @@ func (s *ProductService) GetById(id int, language string) (jewerly.ProductRespon
 
 	product.Images = images
 
-	return product, nil
+	variants, err := s.repo.GetProductsVariants([]int{product.Id})
+	if err != nil {
+		logrus.Errorf("failed to get variants for product id %d: %s", product.Id, err.Error())
+		return product, err
+	}
+
+	product.Variants = variants[product.Id]
+
+	products := []jewerly.ProductResponse{product}
+	convertProductsPrices(products, rate)
+
+	return products[0], nil
+}
+
+func (s *ProductService) CreateVariant(productId int, inp jewerly.CreateVariantInput) (int, error) {
+	return s.repo.CreateVariant(productId, inp)
+}
+
+func (s *ProductService) UpdateVariant(productId, variantId int, inp jewerly.UpdateVariantInput) error {
+	return s.repo.UpdateVariant(productId, variantId, inp)
+}
+
+func (s *ProductService) DeleteVariant(productId, variantId int) error {
+	return s.repo.DeleteVariant(productId, variantId)
+}
+
+func (s *ProductService) SetCategorySale(inp jewerly.BulkSaleInput) (int, error) {
+	return s.repo.SetCategorySale(inp)
 }
 
 func (s *ProductService) UploadImage(ctx context.Context, file io.Reader, size int64, contentType string) (int, error) {
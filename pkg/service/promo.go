@@ -0,0 +1,143 @@
+package service
+
+import (
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository"
+	"strings"
+	"time"
+)
+
+type PromoService struct {
+	repo repository.Promo
+}
+
+func NewPromoService(repo repository.Promo) *PromoService {
+	return &PromoService{repo: repo}
+}
+
+func (s *PromoService) Create(inp jewerly.CreatePromoCodeInput) (int, error) {
+	inp.Code = jewerly.NormalizePromoCode(inp.Code)
+
+	return s.repo.Create(inp)
+}
+
+func (s *PromoService) GetAll() ([]jewerly.PromoCode, error) {
+	return s.repo.GetAll()
+}
+
+func (s *PromoService) GetById(id int) (jewerly.PromoCode, error) {
+	return s.repo.GetById(id)
+}
+
+func (s *PromoService) Update(id int, inp jewerly.UpdatePromoCodeInput) error {
+	if inp.Percent.Valid || inp.Amount.Valid {
+		promo, err := s.repo.GetById(id)
+		if err != nil {
+			return err
+		}
+
+		if (inp.Percent.Valid && promo.Type != jewerly.PromoTypePercentage) || (inp.Amount.Valid && promo.Type != jewerly.PromoTypeFixed) {
+			return jewerly.ErrPromoValueMismatch
+		}
+	}
+
+	return s.repo.Update(id, inp)
+}
+
+func (s *PromoService) Delete(id int) error {
+	return s.repo.Delete(id)
+}
+
+// Apply checks that the promo code can be used by the customer for the ordered items and calculates the discount.
+// Only items from the promo code categories are discounted, minimal order sum is checked against the whole order.
+func (s *PromoService) Apply(code, email string, items []jewerly.OrderItem, products []jewerly.ProductResponse) (jewerly.OrderDiscount, error) {
+	promo, err := s.repo.GetByCode(jewerly.NormalizePromoCode(code))
+	if err == jewerly.ErrPromoCodeNotFound {
+		return jewerly.OrderDiscount{}, jewerly.ErrInvalidPromoCode
+	}
+	if err != nil {
+		return jewerly.OrderDiscount{}, err
+	}
+
+	if !promo.IsValidAt(time.Now()) {
+		return jewerly.OrderDiscount{}, jewerly.ErrInvalidPromoCode
+	}
+
+	if err := s.checkUsageLimits(promo, email); err != nil {
+		return jewerly.OrderDiscount{}, err
+	}
+
+	productsList := make(map[int]jewerly.ProductResponse)
+	for _, product := range products {
+		productsList[product.Id] = product
+	}
+
+	var orderSum, eligibleSum jewerly.Money
+	for _, item := range items {
+		product := productsList[item.ProductId]
+
+		price, err := getOrderItemPrice(item, product)
+		if err != nil {
+			return jewerly.OrderDiscount{}, err
+		}
+
+		orderSum += price.Mul(item.Quantity)
+		if promo.AppliesTo(product.CategoryId) {
+			eligibleSum += price.Mul(item.Quantity)
+		}
+	}
+
+	if eligibleSum == 0 {
+		return jewerly.OrderDiscount{}, jewerly.ErrPromoCodeNotApplicable
+	}
+
+	if orderSum < promo.MinOrderSum {
+		return jewerly.OrderDiscount{}, jewerly.ErrPromoCodeOrderSumLow
+	}
+
+	return jewerly.OrderDiscount{
+		PromoCodeId:  promo.Id,
+		Code:         promo.Code,
+		Amount:       getDiscountAmount(promo, eligibleSum),
+		FreeShipping: promo.Type == jewerly.PromoTypeFreeShipping,
+	}, nil
+}
+
+func (s *PromoService) checkUsageLimits(promo jewerly.PromoCode, email string) error {
+	if !promo.UsageLimit.Valid && !promo.PerCustomerLimit.Valid {
+		return nil
+	}
+
+	total, customer, err := s.repo.CountUsages(promo.Id, strings.ToLower(email))
+	if err != nil {
+		return err
+	}
+
+	if promo.UsageLimit.Valid && int64(total) >= promo.UsageLimit.Int64 {
+		return jewerly.ErrPromoCodeUsageLimit
+	}
+
+	if promo.PerCustomerLimit.Valid && int64(customer) >= promo.PerCustomerLimit.Int64 {
+		return jewerly.ErrPromoCodeUsageLimit
+	}
+
+	return nil
+}
+
+// getDiscountAmount never discounts more than the sum of discounted items, percentage discount is rounded to cents.
+func getDiscountAmount(promo jewerly.PromoCode, eligibleSum jewerly.Money) jewerly.Money {
+	var amount jewerly.Money
+
+	switch promo.Type {
+	case jewerly.PromoTypePercentage:
+		amount = eligibleSum.Percent(float64(promo.Percent))
+	case jewerly.PromoTypeFixed:
+		amount = promo.Amount
+	}
+
+	if amount > eligibleSum {
+		amount = eligibleSum
+	}
+
+	return amount
+}
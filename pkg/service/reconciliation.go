@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/payment"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository"
+	"time"
+)
+
+// transactions with any of these statuses aren't queried from the provider anymore,
+// failed sale isn't final as the buyer can retry the payment. Authorized sale waits for the admin, it's captured
+// or voided by the api (or voided after the authorization ttl), which saves the status without the callback.
+var finalNotifyTypes = []string{"sale-complete", "sale-authorized", "sale-void"}
+
+type ReconciliationDeps struct {
+	// MinAge gives the provider time to deliver the callback before the transaction is queried.
+	MinAge time.Duration
+
+	// MaxAge limits how long transactions are queried, sales of abandoned orders never get the final status.
+	MaxAge time.Duration
+}
+
+type ReconciliationService struct {
+	repo            repository.Reconciliation
+	paymentProvider payment.Provider
+	orderService    Order
+	ReconciliationDeps
+}
+
+func NewReconciliationService(repo repository.Reconciliation, paymentProvider payment.Provider, orderService Order,
+	deps ReconciliationDeps) *ReconciliationService {
+	return &ReconciliationService{repo: repo, paymentProvider: paymentProvider, orderService: orderService, ReconciliationDeps: deps}
+}
+
+// Reconcile queries the provider for transactions without the final status and saves statuses which callbacks were lost,
+// the order is updated and the customer is notified the same way as on the callback. Every found difference is reported,
+// the ones that can't be fixed automatically are left for the admin.
+func (s *ReconciliationService) Reconcile() error {
+	now := time.Now()
+
+	transactions, err := s.repo.GetPendingTransactions(now.Add(-s.MaxAge), now.Add(-s.MinAge), finalNotifyTypes)
+	if err != nil {
+		return err
+	}
+
+	for _, transaction := range transactions {
+		if err := s.reconcile(transaction); err != nil {
+			logrus.Errorf("failed to reconcile transaction %s of order %d: %s", transaction.TransactionId, transaction.OrderId, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (s *ReconciliationService) GetDiscrepancies(filters jewerly.PaymentDiscrepancyFilters) ([]jewerly.PaymentDiscrepancy, error) {
+	return s.repo.GetDiscrepancies(filters)
+}
+
+func (s *ReconciliationService) reconcile(transaction jewerly.PendingTransaction) error {
+	inp, err := s.paymentProvider.GetSale(transaction.TransactionId)
+	if err == payment.ErrSalePending {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if inp.NotifyType == transaction.LastStatus {
+		return nil
+	}
+
+	discrepancy := jewerly.PaymentDiscrepancy{
+		OrderId:        transaction.OrderId,
+		TransactionId:  transaction.TransactionId,
+		LocalStatus:    transaction.LastStatus,
+		ProviderStatus: inp.NotifyType,
+	}
+
+	// e.g. the sale is refunded or charged back in the provider dashboard
+	if inp.NotifyType == "" {
+		discrepancy.Kind = jewerly.DiscrepancyUnsupportedStatus
+		discrepancy.ProviderStatus = inp.SaleStatus
+		discrepancy.Details = "provider sale status can't be saved automatically"
+
+		return s.repo.CreateDiscrepancy(discrepancy)
+	}
+
+	inp.TransactionID = transaction.TransactionId
+
+	err = s.orderService.ApplyTransaction(inp)
+	switch err {
+	case nil:
+		discrepancy.Kind = jewerly.DiscrepancyMissingStatus
+		discrepancy.Details = "status callback was lost, the status is saved by reconciliation"
+		// payment of the cancelled order is reported by ApplyTransaction as for the callback
+		discrepancy.Resolved = true
+	case jewerly.ErrDuplicateCallback:
+		// callback is delivered while the provider was queried
+		return nil
+	case jewerly.ErrPaymentAmountMismatch:
+		discrepancy.Kind = jewerly.DiscrepancyAmountMismatch
+		discrepancy.Details = fmt.Sprintf("provider charged %s %s", jewerly.Money(inp.Price), inp.Currency)
+	default:
+		return err
+	}
+
+	logrus.Warnf("transactionId: %s, order %d payment discrepancy %s, provider status %s", transaction.TransactionId,
+		transaction.OrderId, discrepancy.Kind, inp.NotifyType)
+
+	return s.repo.CreateDiscrepancy(discrepancy)
+}
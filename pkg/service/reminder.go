@@ -0,0 +1,144 @@
+package service
+
+import (
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository"
+	"time"
+)
+
+const unsubscribeTokenAudience = "unsubscribe"
+
+type ReminderDeps struct {
+	SigningKey []byte
+
+	// CartURL is the storefront cart page, customer's cart is loaded there after sign in.
+	CartURL        string
+	UnsubscribeURL string
+
+	CartDelay  time.Duration
+	OrderDelay time.Duration
+
+	// MaxAge limits how old carts & orders are reminded about, so customers don't get emails about
+	// their month old carts after the feature is enabled.
+	MaxAge time.Duration
+}
+
+type ReminderService struct {
+	repo         repository.Reminder
+	emailService Email
+	ReminderDeps
+}
+
+func NewReminderService(repo repository.Reminder, emailService Email, deps ReminderDeps) *ReminderService {
+	return &ReminderService{repo: repo, emailService: emailService, ReminderDeps: deps}
+}
+
+// SendReminders emails customers who left their cart or didn't pay for the order.
+func (s *ReminderService) SendReminders() error {
+	now := time.Now()
+
+	if err := s.sendCartReminders(now); err != nil {
+		return err
+	}
+
+	return s.sendPaymentReminders(now)
+}
+
+func (s *ReminderService) Unsubscribe(token string) error {
+	claims, err := parseToken(token, s.SigningKey, unsubscribeTokenAudience)
+	if err != nil || claims.Subject == "" {
+		return jewerly.ErrInvalidUnsubscribeToken
+	}
+
+	return s.repo.OptOut(normalizeEmail(claims.Subject))
+}
+
+func (s *ReminderService) sendCartReminders(now time.Time) error {
+	carts, err := s.repo.GetAbandonedCarts(now.Add(-s.MaxAge), now.Add(-s.CartDelay))
+	if err != nil {
+		return err
+	}
+
+	for _, cart := range carts {
+		// reminder is marked before sending, so a failed email isn't sent again on the next run
+		ok, err := s.repo.MarkCartReminded(cart.CartId)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		inp, err := s.newReminderEmailInput(cart.FirstName, cart.Email)
+		if err != nil {
+			return err
+		}
+		inp.CheckoutURL = s.CartURL
+
+		if err := s.emailService.SendCartReminder(inp); err != nil {
+			logrus.Errorf("failed to send cart %d reminder: %s", cart.CartId, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (s *ReminderService) sendPaymentReminders(now time.Time) error {
+	orders, err := s.repo.GetUnpaidOrders(now.Add(-s.MaxAge), now.Add(-s.OrderDelay), paidNotifyTypes)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		ok, err := s.repo.MarkOrderReminded(order.OrderId)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		inp, err := s.newReminderEmailInput(order.FirstName, order.Email)
+		if err != nil {
+			return err
+		}
+		inp.OrderId = order.OrderId
+		inp.TotalCost = order.TotalCost
+		inp.Currency = order.Currency
+		inp.CheckoutURL = order.PaymentURL
+
+		if err := s.emailService.SendPaymentReminder(inp); err != nil {
+			logrus.Errorf("failed to send order %d payment reminder: %s", order.OrderId, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (s *ReminderService) newReminderEmailInput(firstName, email string) (jewerly.ReminderEmailInput, error) {
+	unsubscribeURL, err := s.getUnsubscribeURL(email)
+
+	return jewerly.ReminderEmailInput{
+		FirstName:      firstName,
+		Email:          email,
+		UnsubscribeURL: unsubscribeURL,
+	}, err
+}
+
+func (s *ReminderService) getUnsubscribeURL(email string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.StandardClaims{
+		IssuedAt: time.Now().Unix(),
+		Subject:  normalizeEmail(email),
+		Audience: unsubscribeTokenAudience,
+	})
+
+	signedToken, err := token.SignedString(s.SigningKey)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s?token=%s", s.UnsubscribeURL, signedToken), nil
+}
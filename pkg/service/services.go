@@ -4,41 +4,81 @@ import (
 	"context"
 	jewerly "github.com/zhashkevych/jewelry-shop-backend"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/email"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/invoice"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/payment"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository"
 	"github.com/zhashkevych/jewelry-shop-backend/pkg/storage"
+	"gopkg.in/guregu/null.v3"
 	"io"
+	"net/http"
+	"time"
 )
 
 //go:generate mockgen -source=services.go -destination=mocks/mock.go
 
 // Authorization
 type SignUpInput struct {
-	FirstName string
-	LastName  string
-	Email     string
-	Password  string
+	FirstName string `json:"first_name" binding:"required"`
+	LastName  string `json:"last_name" binding:"required"`
+	Email     string `json:"email" binding:"required,email"`
+	Password  string `json:"password" binding:"required,min=8"`
 }
 
 type Admin interface {
 	SignIn(login, password string) (string, error)
-	ParseToken(token string) error
+	ParseToken(token string) (string, error)
+}
+
+type User interface {
+	SignUp(inp SignUpInput) error
+	SignIn(email, password string) (string, error)
+	ParseToken(token string) (int64, error)
+	VerifyEmail(token string) error
+	ForgotPassword(email string) error
+	ResetPassword(token, password string) error
 }
 
 type Product interface {
 	Create(jewerly.CreateProductInput) error
 	GetAll(jewerly.GetAllProductsFilters) (jewerly.ProductsList, error)
-	GetById(id int, language string) (jewerly.ProductResponse, error)
+	Search(jewerly.SearchProductsFilters) (jewerly.ProductsList, error)
+	GetById(id int, language, currency string) (jewerly.ProductResponse, error)
 	Update(id int, inp jewerly.UpdateProductInput) error
 	Delete(id int) error
 	UploadImage(ctx context.Context, file io.Reader, size int64, contentType string) (int, error)
+	CreateVariant(productId int, inp jewerly.CreateVariantInput) (int, error)
+	UpdateVariant(productId, variantId int, inp jewerly.UpdateVariantInput) error
+	DeleteVariant(productId, variantId int) error
+	SetCategorySale(inp jewerly.BulkSaleInput) (int, error)
 }
 
 type Order interface {
 	Create(jewerly.CreateOrderInput) (string, error)
-	ProcessCallback(jewerly.TransactionCallbackInput)
+	ParseCallback(r *http.Request) (jewerly.TransactionCallbackInput, error)
+	ProcessCallback(jewerly.TransactionCallbackInput) error
+	ApplyTransaction(jewerly.TransactionCallbackInput) error
 	GetAll(jewerly.GetAllOrdersFilters) (jewerly.OrderList, error)
 	GetById(id int) (jewerly.Order, error)
+	UpdateStatus(id int, status, changedBy string) error
+	Ship(id int, inp jewerly.ShipOrderInput, changedBy string) error
+	Refund(id int, inp jewerly.RefundOrderInput, createdBy string) (int, error)
+	CompleteRefund(id, refundId int, inp jewerly.CompleteRefundInput) error
+	Capture(id int, changedBy string) error
+	Void(id int, changedBy string) error
+	Lookup(inp jewerly.OrderLookupInput) (jewerly.CustomerOrder, error)
+	GetUserOrders(userId int64, filters jewerly.GetAllOrdersFilters, language string) (jewerly.CustomerOrderList, error)
+	CancelExpiredOrders() error
+	VoidStaleAuthorizations() error
+	GetShippingOptions(inp jewerly.ShippingQuoteInput, currency string) ([]jewerly.ShippingOption, error)
+}
+
+type Cart interface {
+	Get(identity jewerly.CartIdentity, language string) (jewerly.Cart, error)
+	AddItem(identity jewerly.CartIdentity, inp jewerly.AddCartItemInput, language string) (jewerly.Cart, error)
+	UpdateItem(identity jewerly.CartIdentity, productId int, variantId null.Int, inp jewerly.UpdateCartItemInput, language string) (jewerly.Cart, error)
+	DeleteItem(identity jewerly.CartIdentity, productId int, variantId null.Int, language string) (jewerly.Cart, error)
+	Clear(identity jewerly.CartIdentity) error
+	Checkout(identity jewerly.CartIdentity, inp jewerly.CheckoutInput) (string, error)
 }
 
 type Email interface {
@@ -46,6 +86,62 @@ type Email interface {
 	SendOrderInfoCustomer(inp jewerly.OrderInfoEmailInput) error
 	SendPaymentInfoSupport(inp jewerly.PaymentInfoEmailInput) error
 	SendPaymentInfoCustomer(inp jewerly.PaymentInfoEmailInput) error
+	SendShippingInfoCustomer(inp jewerly.ShippingInfoEmailInput) error
+	SendRefundInfoCustomer(inp jewerly.RefundEmailInput) error
+	SendEmailVerification(inp jewerly.UserTokenEmailInput) error
+	SendPasswordReset(inp jewerly.UserTokenEmailInput) error
+	SendCartReminder(inp jewerly.ReminderEmailInput) error
+	SendPaymentReminder(inp jewerly.ReminderEmailInput) error
+}
+
+type Reminder interface {
+	SendReminders() error
+	Unsubscribe(token string) error
+}
+
+type Reconciliation interface {
+	Reconcile() error
+	GetDiscrepancies(filters jewerly.PaymentDiscrepancyFilters) ([]jewerly.PaymentDiscrepancy, error)
+}
+
+type Promo interface {
+	Create(inp jewerly.CreatePromoCodeInput) (int, error)
+	GetAll() ([]jewerly.PromoCode, error)
+	GetById(id int) (jewerly.PromoCode, error)
+	Update(id int, inp jewerly.UpdatePromoCodeInput) error
+	Delete(id int) error
+	Apply(code, email string, items []jewerly.OrderItem, products []jewerly.ProductResponse) (jewerly.OrderDiscount, error)
+}
+
+type Currency interface {
+	GetRates() ([]jewerly.ExchangeRate, error)
+	GetRate(currency string) (jewerly.ExchangeRate, error)
+	SetRate(currency string, rate float64) error
+	ImportRates(file io.Reader) (int, error)
+}
+
+type Shipping interface {
+	CreateZone(inp jewerly.CreateShippingZoneInput) (int, error)
+	GetZones() ([]jewerly.ShippingZone, error)
+	UpdateZone(id int, inp jewerly.UpdateShippingZoneInput) error
+	DeleteZone(id int) error
+	CreateMethod(inp jewerly.CreateShippingMethodInput) (int, error)
+	UpdateMethod(id int, inp jewerly.UpdateShippingMethodInput) error
+	DeleteMethod(id int) error
+	GetOptions(country string, weight int, orderSum jewerly.Money) ([]jewerly.ShippingOption, error)
+	Calculate(country string, methodId, weight int, orderSum jewerly.Money) (jewerly.OrderShipping, error)
+}
+
+type Tax interface {
+	GetRates() ([]jewerly.TaxRate, error)
+	SetRate(country string, inp jewerly.SetTaxRateInput) error
+	DeleteRate(country string) error
+	Calculate(country string, amount jewerly.Money) (jewerly.OrderTax, error)
+}
+
+type Invoice interface {
+	Create(orderId int) (jewerly.Invoice, []byte, error)
+	GetByOrderId(orderId int) (jewerly.Invoice, []byte, error)
 }
 
 type Settings interface {
@@ -87,14 +183,64 @@ type Dependencies struct {
 	PaymentInfoCustomerTemplate string
 	PaymentInfoCustomerSubject  string
 
-	MinimalOrderSum float32
+	ShippingInfoCustomerTemplate string
+	ShippingInfoCustomerSubject  string
+
+	RefundInfoCustomerTemplate string
+	RefundInfoCustomerSubject  string
+
+	EmailVerificationTemplate string
+	EmailVerificationSubject  string
+
+	PasswordResetTemplate string
+	PasswordResetSubject  string
+
+	CartReminderTemplate string
+	CartReminderSubject  string
+
+	PaymentReminderTemplate string
+	PaymentReminderSubject  string
+
+	MinimalOrderSum jewerly.Money
+	OrderLookupURL  string
+
+	OrderLookupTokenTTL time.Duration
+
+	StockReservationTTL time.Duration
+
+	PaymentCallbackToken    string
+	PaymentAuthorizationTTL time.Duration
+
+	ReconciliationMinAge time.Duration
+	ReconciliationMaxAge time.Duration
+
+	EmailVerificationURL string
+	ResetPasswordURL     string
+
+	CartURL            string
+	UnsubscribeURL     string
+	CartReminderDelay  time.Duration
+	OrderReminderDelay time.Duration
+	ReminderMaxAge     time.Duration
+
+	InvoiceNumberPrefix string
+	InvoiceSeller       invoice.Seller
 }
 
 type Services struct {
 	Admin
+	User
 	Product
 	Order
+	Cart
 	Email
+	Reminder
+	Reconciliation
+	Promo
+	Currency
+	Shipping
+	Tax
+	Invoice
 	Settings
 }
 
@@ -116,13 +262,81 @@ func NewServices(deps Dependencies) *Services {
 
 		PaymentInfoCustomerTemplate: deps.PaymentInfoCustomerTemplate,
 		PaymentInfoCustomerSubject:  deps.PaymentInfoCustomerSubject,
+
+		ShippingInfoCustomerTemplate: deps.ShippingInfoCustomerTemplate,
+		ShippingInfoCustomerSubject:  deps.ShippingInfoCustomerSubject,
+
+		RefundInfoCustomerTemplate: deps.RefundInfoCustomerTemplate,
+		RefundInfoCustomerSubject:  deps.RefundInfoCustomerSubject,
+
+		EmailVerificationTemplate: deps.EmailVerificationTemplate,
+		EmailVerificationSubject:  deps.EmailVerificationSubject,
+
+		PasswordResetTemplate: deps.PasswordResetTemplate,
+		PasswordResetSubject:  deps.PasswordResetSubject,
+
+		CartReminderTemplate: deps.CartReminderTemplate,
+		CartReminderSubject:  deps.CartReminderSubject,
+
+		PaymentReminderTemplate: deps.PaymentReminderTemplate,
+		PaymentReminderSubject:  deps.PaymentReminderSubject,
+	})
+
+	promoService := NewPromoService(deps.Repos.Promo)
+	currencyService := NewCurrencyService(deps.Repos.Currency)
+	shippingService := NewShippingService(deps.Repos.Shipping)
+	taxService := NewTaxService(deps.Repos.Tax)
+	invoiceService := NewInvoiceService(deps.Repos.Invoice, deps.Repos.Order, deps.FileStorage, InvoiceDeps{
+		NumberPrefix: deps.InvoiceNumberPrefix,
+		Seller:       deps.InvoiceSeller,
+	})
+
+	orderService := NewOrderService(deps.Repos.Order, deps.Repos.Reconciliation, deps.PaymentProvider, emailService, promoService,
+		currencyService, shippingService, taxService, invoiceService, OrderDeps{
+			MinimalOrderSum: deps.MinimalOrderSum,
+			SigningKey:      deps.SigningKey,
+			LookupURL:       deps.OrderLookupURL,
+			LookupTokenTTL:  deps.OrderLookupTokenTTL,
+			ReservationTTL:  deps.StockReservationTTL,
+			CallbackToken:   deps.PaymentCallbackToken,
+
+			AuthorizationTTL: deps.PaymentAuthorizationTTL,
+		})
+
+	userService := NewUserService(deps.Repos.User, emailService, UserDeps{
+		SigningKey:       deps.SigningKey,
+		VerificationURL:  deps.EmailVerificationURL,
+		ResetPasswordURL: deps.ResetPasswordURL,
+	})
+
+	reminderService := NewReminderService(deps.Repos.Reminder, emailService, ReminderDeps{
+		SigningKey:     deps.SigningKey,
+		CartURL:        deps.CartURL,
+		UnsubscribeURL: deps.UnsubscribeURL,
+		CartDelay:      deps.CartReminderDelay,
+		OrderDelay:     deps.OrderReminderDelay,
+		MaxAge:         deps.ReminderMaxAge,
+	})
+
+	reconciliationService := NewReconciliationService(deps.Repos.Reconciliation, deps.PaymentProvider, orderService, ReconciliationDeps{
+		MinAge: deps.ReconciliationMinAge,
+		MaxAge: deps.ReconciliationMaxAge,
 	})
 
 	return &Services{
-		Admin:    NewAdminService(deps.Repos.Admin, deps.HashSalt, deps.SigningKey),
-		Product:  NewProductService(deps.Repos.Product, deps.FileStorage),
-		Order:    NewOrderService(deps.Repos.Order, deps.PaymentProvider, emailService, deps.MinimalOrderSum),
-		Email:    emailService,
-		Settings: NewSettingsService(deps.Repos.Settings),
+		Admin:          NewAdminService(deps.Repos.Admin, deps.HashSalt, deps.SigningKey),
+		User:           userService,
+		Product:        NewProductService(deps.Repos.Product, deps.FileStorage, currencyService),
+		Order:          orderService,
+		Cart:           NewCartService(deps.Repos.Cart, orderService),
+		Email:          emailService,
+		Reminder:       reminderService,
+		Reconciliation: reconciliationService,
+		Promo:          promoService,
+		Currency:       currencyService,
+		Shipping:       shippingService,
+		Tax:            taxService,
+		Invoice:        invoiceService,
+		Settings:       NewSettingsService(deps.Repos.Settings),
 	}
 }
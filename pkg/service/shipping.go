@@ -0,0 +1,105 @@
+package service
+
+import (
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository"
+)
+
+type ShippingService struct {
+	repo repository.Shipping
+}
+
+func NewShippingService(repo repository.Shipping) *ShippingService {
+	return &ShippingService{repo: repo}
+}
+
+func (s *ShippingService) CreateZone(inp jewerly.CreateShippingZoneInput) (int, error) {
+	inp.Countries = jewerly.NormalizeCountries(inp.Countries)
+
+	return s.repo.CreateZone(inp)
+}
+
+func (s *ShippingService) GetZones() ([]jewerly.ShippingZone, error) {
+	return s.repo.GetZones()
+}
+
+func (s *ShippingService) UpdateZone(id int, inp jewerly.UpdateShippingZoneInput) error {
+	if inp.Countries != nil {
+		countries := jewerly.NormalizeCountries(*inp.Countries)
+		inp.Countries = &countries
+	}
+
+	return s.repo.UpdateZone(id, inp)
+}
+
+func (s *ShippingService) DeleteZone(id int) error {
+	return s.repo.DeleteZone(id)
+}
+
+func (s *ShippingService) CreateMethod(inp jewerly.CreateShippingMethodInput) (int, error) {
+	return s.repo.CreateMethod(inp)
+}
+
+func (s *ShippingService) UpdateMethod(id int, inp jewerly.UpdateShippingMethodInput) error {
+	return s.repo.UpdateMethod(id, inp)
+}
+
+func (s *ShippingService) DeleteMethod(id int) error {
+	return s.repo.DeleteMethod(id)
+}
+
+// GetOptions returns methods that can deliver the order to the country, costs are in the base currency.
+func (s *ShippingService) GetOptions(country string, weight int, orderSum jewerly.Money) ([]jewerly.ShippingOption, error) {
+	methods, err := s.repo.GetCountryMethods(jewerly.NormalizeCountry(country))
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]jewerly.ShippingOption, 0, len(methods))
+	for _, method := range methods {
+		cost, ok := method.Cost(weight, orderSum)
+		if !ok {
+			continue
+		}
+
+		options = append(options, jewerly.ShippingOption{
+			Id:       method.Id,
+			Name:     method.Name,
+			Type:     method.Type,
+			Cost:     cost,
+			Currency: jewerly.BaseCurrency,
+		})
+	}
+
+	return options, nil
+}
+
+// Calculate checks that the chosen method is available for the order and calculates its cost in the base currency.
+// Method is required once any shipping zone is configured, before that orders are placed without shipping.
+func (s *ShippingService) Calculate(country string, methodId, weight int, orderSum jewerly.Money) (jewerly.OrderShipping, error) {
+	if methodId == 0 {
+		zones, err := s.repo.GetZones()
+		if err != nil {
+			return jewerly.OrderShipping{}, err
+		}
+
+		if len(zones) == 0 {
+			return jewerly.OrderShipping{}, nil
+		}
+
+		return jewerly.OrderShipping{}, jewerly.ErrShippingMethodRequired
+	}
+
+	options, err := s.GetOptions(country, weight, orderSum)
+	if err != nil {
+		return jewerly.OrderShipping{}, err
+	}
+
+	for _, option := range options {
+		if option.Id == methodId {
+			return jewerly.OrderShipping{MethodId: option.Id, Method: option.Name, Cost: option.Cost}, nil
+		}
+	}
+
+	return jewerly.OrderShipping{}, jewerly.ErrShippingNotAvailable
+}
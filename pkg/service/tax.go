@@ -0,0 +1,44 @@
+package service
+
+import (
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository"
+)
+
+type TaxService struct {
+	repo repository.Tax
+}
+
+func NewTaxService(repo repository.Tax) *TaxService {
+	return &TaxService{repo: repo}
+}
+
+func (s *TaxService) GetRates() ([]jewerly.TaxRate, error) {
+	return s.repo.GetRates()
+}
+
+func (s *TaxService) SetRate(country string, inp jewerly.SetTaxRateInput) error {
+	rate := jewerly.TaxRate{Country: jewerly.NormalizeCountry(country), Rate: inp.Rate, Inclusive: inp.Inclusive}
+	if err := rate.Validate(); err != nil {
+		return err
+	}
+
+	return s.repo.SetRate(rate)
+}
+
+func (s *TaxService) DeleteRate(country string) error {
+	return s.repo.DeleteRate(jewerly.NormalizeCountry(country))
+}
+
+// Calculate returns tax breakdown of the amount for the destination country, countries without tax rate aren't taxed.
+func (s *TaxService) Calculate(country string, amount jewerly.Money) (jewerly.OrderTax, error) {
+	rate, err := s.repo.GetRate(jewerly.NormalizeCountry(country))
+	if err == jewerly.ErrTaxRateNotFound {
+		return jewerly.TaxRate{}.Apply(amount), nil
+	}
+	if err != nil {
+		return jewerly.OrderTax{}, err
+	}
+
+	return rate.Apply(amount), nil
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/sirupsen/logrus"
+	jewerly "github.com/zhashkevych/jewelry-shop-backend"
+	"github.com/zhashkevych/jewelry-shop-backend/pkg/repository"
+	"golang.org/x/crypto/bcrypt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	customerTokenTTL      = 30 * 24 * time.Hour
+	customerTokenAudience = "customer"
+
+	emailVerificationTokenTTL = 48 * time.Hour
+	passwordResetTokenTTL     = time.Hour
+)
+
+type UserDeps struct {
+	SigningKey []byte
+
+	// Storefront pages, email tokens are passed to them as query parameter.
+	VerificationURL  string
+	ResetPasswordURL string
+}
+
+type UserService struct {
+	repo         repository.User
+	emailService Email
+	UserDeps
+}
+
+func NewUserService(repo repository.User, emailService Email, deps UserDeps) *UserService {
+	return &UserService{repo: repo, emailService: emailService, UserDeps: deps}
+}
+
+func (s *UserService) SignUp(inp SignUpInput) error {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(inp.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	user := jewerly.User{
+		Email:        normalizeEmail(inp.Email),
+		PasswordHash: string(passwordHash),
+		FirstName:    inp.FirstName,
+		LastName:     inp.LastName,
+	}
+
+	user.Id, err = s.repo.Create(user)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.createToken(user.Id, jewerly.TokenPurposeEmailVerification, emailVerificationTokenTTL)
+	if err != nil {
+		logrus.Errorf("failed to create email verification token: %s", err.Error())
+		return nil
+	}
+
+	go func() {
+		err := s.emailService.SendEmailVerification(jewerly.UserTokenEmailInput{
+			FirstName: user.FirstName,
+			Email:     user.Email,
+			URL:       fmt.Sprintf("%s?token=%s", s.VerificationURL, token),
+		})
+		if err != nil {
+			logrus.Errorf("failed to send email verification email: %s", err.Error())
+		}
+	}()
+
+	return nil
+}
+
+func (s *UserService) SignIn(email, password string) (string, error) {
+	user, err := s.repo.GetByEmail(normalizeEmail(email))
+	if err != nil {
+		return "", err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return "", jewerly.ErrUserNotFound
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.StandardClaims{
+		ExpiresAt: time.Now().Add(customerTokenTTL).Unix(),
+		IssuedAt:  time.Now().Unix(),
+		Subject:   strconv.FormatInt(user.Id, 10),
+		Audience:  customerTokenAudience,
+	})
+
+	return token.SignedString(s.SigningKey)
+}
+
+// ParseToken returns id of the customer the token was issued for.
+func (s *UserService) ParseToken(token string) (int64, error) {
+	claims, err := parseToken(token, s.SigningKey, customerTokenAudience)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(claims.Subject, 10, 64)
+}
+
+func (s *UserService) VerifyEmail(token string) error {
+	return s.repo.VerifyEmail(hashToken(token))
+}
+
+// ForgotPassword sends password reset link, unknown emails are silently ignored to not reveal registered customers.
+func (s *UserService) ForgotPassword(email string) error {
+	user, err := s.repo.GetByEmail(normalizeEmail(email))
+	if err == jewerly.ErrUserNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := s.createToken(user.Id, jewerly.TokenPurposePasswordReset, passwordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := s.emailService.SendPasswordReset(jewerly.UserTokenEmailInput{
+			FirstName: user.FirstName,
+			Email:     user.Email,
+			URL:       fmt.Sprintf("%s?token=%s", s.ResetPasswordURL, token),
+		})
+		if err != nil {
+			logrus.Errorf("failed to send password reset email: %s", err.Error())
+		}
+	}()
+
+	return nil
+}
+
+func (s *UserService) ResetPassword(token, password string) error {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.ResetPassword(hashToken(token), string(passwordHash))
+}
+
+// createToken returns random token to be sent to the customer, only the token hash is stored.
+func (s *UserService) createToken(userId int64, purpose string, ttl time.Duration) (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	token := hex.EncodeToString(bytes)
+
+	err := s.repo.CreateToken(jewerly.UserToken{
+		UserId:    userId,
+		Hash:      hashToken(token),
+		Purpose:   purpose,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+
+	return token, err
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
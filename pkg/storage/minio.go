@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/minio/minio-go"
 	"github.com/sirupsen/logrus"
+	"io/ioutil"
 	"strings"
 	"time"
 )
@@ -33,8 +34,11 @@ func NewFileStorage(client *minio.Client, bucket, endpoint, env string) *FileSto
 // todo: image compression
 func (fs *FileStorage) Upload(ctx context.Context, input UploadInput) (string, error) {
 	opts := minio.PutObjectOptions{
-		ContentType:  input.ContentType,
-		UserMetadata: map[string]string{"x-amz-acl": "public-read"},
+		ContentType: input.ContentType,
+	}
+
+	if !input.Private {
+		opts.UserMetadata = map[string]string{"x-amz-acl": "public-read"}
 	}
 
 	ctx, clFn := context.WithTimeout(ctx, timeout)
@@ -50,6 +54,20 @@ func (fs *FileStorage) Upload(ctx context.Context, input UploadInput) (string, e
 	return fs.generateFileURL(input.Name), nil
 }
 
+func (fs *FileStorage) Download(ctx context.Context, name string) ([]byte, error) {
+	ctx, clFn := context.WithTimeout(ctx, timeout)
+	defer clFn()
+
+	obj, err := fs.client.GetObjectWithContext(ctx, fs.bucket, name, minio.GetObjectOptions{})
+	if err != nil {
+		logrus.Errorf("error occured while downloading file from bucket: %s", err.Error())
+		return nil, err
+	}
+	defer obj.Close()
+
+	return ioutil.ReadAll(obj)
+}
+
 func (fs *FileStorage) generateFileURL(fileName string) string {
 	// DigitalOcean Spaces link format
 	if fs.env == envProd {
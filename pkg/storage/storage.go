@@ -10,8 +10,12 @@ type UploadInput struct {
 	Name        string
 	Size        int64
 	ContentType string
+
+	// Private files aren't publicly readable by URL and can be only downloaded with storage credentials.
+	Private bool
 }
 
 type Storage interface {
 	Upload(ctx context.Context, input UploadInput) (string, error)
+	Download(ctx context.Context, name string) ([]byte, error)
 }
@@ -5,18 +5,54 @@ import (
 	"gopkg.in/guregu/null.v3"
 )
 
+var (
+	ErrInvalidCursor  = errors.New("invalid cursor")
+	ErrNegativeStock  = errors.New("stock can't be negative")
+	ErrNegativeWeight = errors.New("weight can't be negative")
+	ErrInvalidSale    = errors.New("sale price should be positive and lower than price, sale should end after it starts")
+)
+
 // Inputs
 type CreateProductInput struct {
 	Titles       MultiLanguageInput `json:"titles" binding:"required"`
 	Descriptions MultiLanguageInput `json:"descriptions" binding:"required"`
 	Material     MultiLanguageInput `json:"materials" binding:"required"`
-	Price        float32            `json:"price" binding:"required,min=0"`
+	Price        Money              `json:"price" binding:"required,min=0"`
 	Code         string             `json:"code" binding:"required"`
 	ImageIds     []int              `json:"image_ids" binding:"required,min=1"`
 	CategoryId   Category           `json:"category_id" binding:"required"`
+
+	// Stock is 1 if not set, most of the pieces are one-of-a-kind.
+	Stock null.Int `json:"stock"`
+
+	// Weight in grams is used to calculate shipping cost.
+	Weight int `json:"weight"`
+
+	// MadeToOrder pieces are produced after they are ordered, payment for them is captured once production is confirmed.
+	MadeToOrder bool `json:"made_to_order"`
+
+	SalePrice    NullMoney `json:"sale_price"`
+	SaleStartsAt null.Time `json:"sale_starts_at"`
+	SaleEndsAt   null.Time `json:"sale_ends_at"`
 }
 
 func (i CreateProductInput) Validate() error {
+	if i.Stock.Valid && i.Stock.Int64 < 0 {
+		return ErrNegativeStock
+	}
+
+	if i.Weight < 0 {
+		return ErrNegativeWeight
+	}
+
+	if i.SalePrice.Valid && i.SalePrice.Money >= i.Price {
+		return ErrInvalidSale
+	}
+
+	if err := validateSale(i.SalePrice, i.SaleStartsAt, i.SaleEndsAt); err != nil {
+		return err
+	}
+
 	return i.CategoryId.Validate()
 }
 
@@ -24,10 +60,23 @@ type UpdateProductInput struct {
 	Titles       *MultiLanguageInput `json:"titles"`
 	Descriptions *MultiLanguageInput `json:"descriptions"`
 	Material     *MultiLanguageInput `json:"materials"`
-	Price        null.Float          `json:"price"`
+	Price        NullMoney           `json:"price"`
 	Code         null.String         `json:"code"`
 	CategoryId   *Category           `json:"category_id"`
-	InStock      null.Bool           `json:"in_stock"`
+	Stock        null.Int            `json:"stock"`
+	Weight       null.Int            `json:"weight"`
+
+	// InStock is kept for backward compatibility, it sets stock to 0 or at least 1.
+	InStock null.Bool `json:"in_stock"`
+
+	MadeToOrder null.Bool `json:"made_to_order"`
+
+	SalePrice    NullMoney `json:"sale_price"`
+	SaleStartsAt null.Time `json:"sale_starts_at"`
+	SaleEndsAt   null.Time `json:"sale_ends_at"`
+
+	// RemoveSale clears sale price and period, other sale fields are ignored.
+	RemoveSale bool `json:"remove_sale"`
 }
 
 func (i UpdateProductInput) Validate() error {
@@ -35,10 +84,26 @@ func (i UpdateProductInput) Validate() error {
 		return errors.New("empty update product input")
 	}
 
-	if i.Price.Valid &&  i.Price.Float64 <= 0 {
+	if i.Price.Valid &&  i.Price.Money <= 0 {
 		return errors.New("price can't be negative or zero")
 	}
 
+	if i.Stock.Valid && i.Stock.Int64 < 0 {
+		return ErrNegativeStock
+	}
+
+	if i.Weight.Valid && i.Weight.Int64 < 0 {
+		return ErrNegativeWeight
+	}
+
+	if i.SalePrice.Valid && i.Price.Valid && i.SalePrice.Money >= i.Price.Money {
+		return ErrInvalidSale
+	}
+
+	if err := validateSale(i.SalePrice, i.SaleStartsAt, i.SaleEndsAt); err != nil {
+		return err
+	}
+
 	if i.CategoryId != nil {
 		return i.CategoryId.Validate()
 	}
@@ -46,6 +111,39 @@ func (i UpdateProductInput) Validate() error {
 	return nil
 }
 
+// BulkSaleInput sets sale price of every product in the category to the price with discount,
+// zero discount removes the sale from the category products.
+type BulkSaleInput struct {
+	CategoryId      Category  `json:"category_id" binding:"required"`
+	DiscountPercent float32   `json:"discount_percent"`
+	StartsAt        null.Time `json:"starts_at"`
+	EndsAt          null.Time `json:"ends_at"`
+}
+
+func (i BulkSaleInput) Validate() error {
+	if i.DiscountPercent < 0 || i.DiscountPercent >= 100 {
+		return errors.New("discount percent should be between 0 and 100")
+	}
+
+	if err := validateSale(NullMoney{}, i.StartsAt, i.EndsAt); err != nil {
+		return err
+	}
+
+	return i.CategoryId.Validate()
+}
+
+func validateSale(salePrice NullMoney, startsAt, endsAt null.Time) error {
+	if salePrice.Valid && salePrice.Money <= 0 {
+		return ErrInvalidSale
+	}
+
+	if startsAt.Valid && endsAt.Valid && !endsAt.Time.After(startsAt.Time) {
+		return ErrInvalidSale
+	}
+
+	return nil
+}
+
 type MultiLanguageInput struct {
 	English   string `json:"english" binding:"required"`
 	Russian   string `json:"russian" binding:"required"`
@@ -53,10 +151,27 @@ type MultiLanguageInput struct {
 }
 
 type GetAllProductsFilters struct {
-	Language   string
-	Offset     int
-	Limit      int
-	CategoryId null.Int
+	Language    string
+	Offset      int
+	Limit       int
+	CategoryIds []int
+	MinPrice    null.Float
+	MaxPrice    null.Float
+	InStock     null.Bool
+	Material    null.String
+	Sort        string
+	Cursor      string
+
+	// Currency of the returned prices, price filters are set in it too.
+	Currency string
+}
+
+type SearchProductsFilters struct {
+	Query    string
+	Language string
+	Currency string
+	Offset   int
+	Limit    int
 }
 
 // Responses
@@ -65,11 +180,34 @@ type ProductResponse struct {
 	Title       string      `json:"title" db:"title"`
 	Description string      `json:"description" db:"description"`
 	Material    string      `json:"material" db:"material"`
-	Price       float32     `json:"price" db:"price"`
+	Price       Money       `json:"price" db:"price"`
 	Code        null.String `json:"code" db:"code"`
 	Images      []Image     `json:"images"`
 	CategoryId  Category    `json:"category_id" db:"category_id"`
 	InStock     bool        `json:"in_stock" db:"in_stock"`
+	Stock       int         `json:"stock" db:"stock"`
+	Weight      int         `json:"weight" db:"weight"`
+
+	MadeToOrder bool `json:"made_to_order" db:"made_to_order"`
+
+	// Price is the regular price, SalePrice replaces it while OnSale, so it can be shown as strikethrough.
+	SalePrice    NullMoney `json:"sale_price" db:"sale_price"`
+	SaleStartsAt null.Time `json:"sale_starts_at" db:"sale_starts_at"`
+	SaleEndsAt   null.Time `json:"sale_ends_at" db:"sale_ends_at"`
+	OnSale       bool      `json:"on_sale" db:"on_sale"`
+	Currency     string    `json:"currency"`
+
+	// Stock of the product with variants is the sum of variants stock.
+	Variants []ProductVariant `json:"variants"`
+}
+
+// CurrentPrice is the price the product is sold for, variant prices already include the sale.
+func (p ProductResponse) CurrentPrice() Money {
+	if p.OnSale && p.SalePrice.Valid {
+		return p.SalePrice.Money
+	}
+
+	return p.Price
 }
 
 type Image struct {
@@ -78,9 +216,11 @@ type Image struct {
 	AltText null.String `json:"alt_text" db:"alt_text"`
 }
 
+// Total isn't calculated for pages requested with a cursor.
 type ProductsList struct {
-	Products []ProductResponse `json:"data"`
-	Total    int               `json:"total"`
+	Products   []ProductResponse `json:"data"`
+	Total      int               `json:"total"`
+	NextCursor string            `json:"next_cursor,omitempty"`
 }
 
 // Categories
@@ -107,6 +247,11 @@ const (
 	English    = "english"
 	Ukraininan = "ukrainian"
 	Russian    = "russian"
+
+	SortPriceAsc  = "price_asc"
+	SortPriceDesc = "price_desc"
+	SortNewest    = "newest"
+	SortPopular   = "popular"
 )
 
 var (
@@ -119,6 +264,13 @@ var (
 		CategorySets:      true,
 	}
 
+	ProductSorts = map[string]bool{
+		SortPriceAsc:  true,
+		SortPriceDesc: true,
+		SortNewest:    true,
+		SortPopular:   true,
+	}
+
 	languageQueries = map[string]string{
 		"en":        English,
 		"ru":        Russian,
@@ -0,0 +1,211 @@
+package jewerly
+
+import (
+	"errors"
+	"gopkg.in/guregu/null.v3"
+	"strings"
+	"time"
+)
+
+const (
+	PromoTypePercentage   = "percentage"
+	PromoTypeFixed        = "fixed"
+	PromoTypeFreeShipping = "free_shipping"
+)
+
+var (
+	ErrPromoCodeNotFound      = errors.New("promo code not found")
+	ErrPromoCodeAlreadyExists = errors.New("promo code already exists")
+	ErrInvalidPromoCode       = errors.New("promo code is invalid or expired")
+	ErrPromoCodeUsageLimit    = errors.New("promo code usage limit is reached")
+	ErrPromoCodeOrderSumLow   = errors.New("order sum is too low for the promo code")
+	ErrPromoCodeNotApplicable = errors.New("promo code can't be applied to the ordered products")
+	ErrInvalidPromoType       = errors.New("invalid promo code type")
+	ErrPromoValueMismatch     = errors.New("percent can be set only for percentage codes and amount for fixed ones")
+)
+
+var promoTypes = map[string]bool{
+	PromoTypePercentage:   true,
+	PromoTypeFixed:        true,
+	PromoTypeFreeShipping: true,
+}
+
+// PromoCode is a discount code managed by admin.
+// Percent is used by percentage codes, Amount by fixed codes, both are zero for free shipping.
+// Empty CategoryIds means the code applies to products of all categories.
+type PromoCode struct {
+	Id               int        `json:"id" db:"id"`
+	Code             string     `json:"code" db:"code"`
+	Type             string     `json:"type" db:"type"`
+	Percent          float32    `json:"percent" db:"percent"`
+	Amount           Money      `json:"amount" db:"amount"`
+	MinOrderSum      Money      `json:"min_order_sum" db:"min_order_sum"`
+	CategoryIds      []Category `json:"category_ids" db:"-"`
+	ValidFrom        null.Time  `json:"valid_from" db:"valid_from"`
+	ValidTo          null.Time  `json:"valid_to" db:"valid_to"`
+	UsageLimit       null.Int   `json:"usage_limit" db:"usage_limit"`
+	PerCustomerLimit null.Int   `json:"per_customer_limit" db:"per_customer_limit"`
+	Active           bool       `json:"active" db:"active"`
+	UsedCount        int        `json:"used_count" db:"used_count"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsValidAt checks that the code is active and t is inside of its validity window.
+func (p PromoCode) IsValidAt(t time.Time) bool {
+	if !p.Active {
+		return false
+	}
+
+	if p.ValidFrom.Valid && t.Before(p.ValidFrom.Time) {
+		return false
+	}
+
+	if p.ValidTo.Valid && t.After(p.ValidTo.Time) {
+		return false
+	}
+
+	return true
+}
+
+// AppliesTo checks whether products of the category are discounted by the code.
+func (p PromoCode) AppliesTo(category Category) bool {
+	if len(p.CategoryIds) == 0 {
+		return true
+	}
+
+	for _, id := range p.CategoryIds {
+		if id == category {
+			return true
+		}
+	}
+
+	return false
+}
+
+type CreatePromoCodeInput struct {
+	Code             string     `json:"code" binding:"required"`
+	Type             string     `json:"type" binding:"required"`
+	Percent          float32    `json:"percent"`
+	Amount           Money      `json:"amount"`
+	MinOrderSum      Money      `json:"min_order_sum"`
+	CategoryIds      []Category `json:"category_ids"`
+	ValidFrom        null.Time  `json:"valid_from"`
+	ValidTo          null.Time  `json:"valid_to"`
+	UsageLimit       null.Int   `json:"usage_limit"`
+	PerCustomerLimit null.Int   `json:"per_customer_limit"`
+}
+
+func (i CreatePromoCodeInput) Validate() error {
+	if !promoTypes[i.Type] {
+		return ErrInvalidPromoType
+	}
+
+	if err := validatePromoValue(i.Type, i.Percent, i.Amount); err != nil {
+		return err
+	}
+
+	if i.MinOrderSum < 0 {
+		return errors.New("min order sum can't be negative")
+	}
+
+	if i.ValidFrom.Valid && i.ValidTo.Valid && i.ValidTo.Time.Before(i.ValidFrom.Time) {
+		return errors.New("valid_to can't be before valid_from")
+	}
+
+	if err := validatePromoLimits(i.UsageLimit, i.PerCustomerLimit); err != nil {
+		return err
+	}
+
+	return validateCategories(i.CategoryIds)
+}
+
+// UpdatePromoCodeInput can't change code and type of the promo code, new code should be created instead.
+type UpdatePromoCodeInput struct {
+	Percent          null.Float  `json:"percent"`
+	Amount           NullMoney   `json:"amount"`
+	MinOrderSum      NullMoney   `json:"min_order_sum"`
+	CategoryIds      *[]Category `json:"category_ids"`
+	ValidFrom        null.Time   `json:"valid_from"`
+	ValidTo          null.Time   `json:"valid_to"`
+	UsageLimit       null.Int    `json:"usage_limit"`
+	PerCustomerLimit null.Int    `json:"per_customer_limit"`
+	Active           null.Bool   `json:"active"`
+}
+
+func (i UpdatePromoCodeInput) Validate() error {
+	if (UpdatePromoCodeInput{}) == i {
+		return errors.New("empty update promo code input")
+	}
+
+	if i.Percent.Valid && (i.Percent.Float64 <= 0 || i.Percent.Float64 > 100) {
+		return errors.New("percentage should be between 0 and 100")
+	}
+
+	if i.Amount.Valid && i.Amount.Money <= 0 {
+		return errors.New("promo code amount should be positive")
+	}
+
+	if i.MinOrderSum.Valid && i.MinOrderSum.Money < 0 {
+		return errors.New("min order sum can't be negative")
+	}
+
+	if err := validatePromoLimits(i.UsageLimit, i.PerCustomerLimit); err != nil {
+		return err
+	}
+
+	if i.CategoryIds != nil {
+		return validateCategories(*i.CategoryIds)
+	}
+
+	return nil
+}
+
+func validatePromoValue(promoType string, percent float32, amount Money) error {
+	switch promoType {
+	case PromoTypePercentage:
+		if percent <= 0 || percent > 100 {
+			return errors.New("percentage should be between 0 and 100")
+		}
+	case PromoTypeFixed:
+		if amount <= 0 {
+			return errors.New("promo code amount should be positive")
+		}
+	}
+
+	if (promoType != PromoTypePercentage && percent != 0) || (promoType != PromoTypeFixed && amount != 0) {
+		return ErrPromoValueMismatch
+	}
+
+	return nil
+}
+
+func validatePromoLimits(usageLimit, perCustomerLimit null.Int) error {
+	if (usageLimit.Valid && usageLimit.Int64 < 1) || (perCustomerLimit.Valid && perCustomerLimit.Int64 < 1) {
+		return errors.New("promo code limits should be positive")
+	}
+
+	return nil
+}
+
+func validateCategories(categories []Category) error {
+	for _, category := range categories {
+		if err := category.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NormalizePromoCode makes codes case insensitive.
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// OrderDiscount is a result of applying the promo code to the order.
+type OrderDiscount struct {
+	PromoCodeId  int
+	Code         string
+	Amount       Money
+	FreeShipping bool
+}
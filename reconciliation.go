@@ -0,0 +1,42 @@
+package jewerly
+
+import (
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+// Discrepancy kinds found by the payment reconciliation or on the callback, only missing statuses are resolved automatically.
+const (
+	DiscrepancyMissingStatus      = "missing_status"
+	DiscrepancyAmountMismatch     = "amount_mismatch"
+	DiscrepancyCancelledOrderPaid = "cancelled_order_paid"
+	DiscrepancyUnsupportedStatus  = "unsupported_status"
+	DiscrepancyUnmatchedRefund    = "unmatched_refund"
+)
+
+// PendingTransaction is a payment that didn't get a final status from the provider callbacks.
+type PendingTransaction struct {
+	TransactionId string `db:"uuid"`
+	OrderId       int    `db:"order_id"`
+	OrderStatus   string `db:"order_status"`
+	LastStatus    string `db:"last_status"`
+}
+
+// PaymentDiscrepancy is a difference between the sale status in the payment provider and the saved one.
+type PaymentDiscrepancy struct {
+	Id             int       `json:"id" db:"id"`
+	OrderId        int       `json:"order_id" db:"order_id"`
+	TransactionId  string    `json:"transaction_id" db:"uuid"`
+	Kind           string    `json:"kind" db:"kind"`
+	LocalStatus    string    `json:"local_status" db:"local_status"`
+	ProviderStatus string    `json:"provider_status" db:"provider_status"`
+	Details        string    `json:"details" db:"details"`
+	Resolved       bool      `json:"resolved" db:"resolved"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+type PaymentDiscrepancyFilters struct {
+	Offset   int
+	Limit    int
+	Resolved null.Bool
+}
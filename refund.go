@@ -0,0 +1,71 @@
+package jewerly
+
+import (
+	"errors"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusCompleted = "completed"
+)
+
+var (
+	ErrRefundNotAvailable   = errors.New("order payment can't be refunded")
+	ErrRefundConflict       = errors.New("order is being refunded by another request, try again")
+	ErrRefundNotFound       = errors.New("pending refund not found")
+	ErrRefundNotCompleted   = errors.New("refund is made in the payment provider, but isn't saved, complete the pending refund")
+	ErrRefundAmountExceeded = errors.New("refund amount exceeds the paid amount that isn't refunded yet")
+	ErrInvalidRefundAmount  = errors.New("refund amount should be positive")
+	ErrInvalidRefundItems   = errors.New("refunded items should be ordered and not refunded before")
+)
+
+// RefundOrderInput refunds the rest of the paid amount when Amount isn't passed, amount is in the order currency.
+// Items are returned to stock, all not refunded items are restocked on full refund of the order that isn't shipped yet.
+type RefundOrderInput struct {
+	Amount NullMoney   `json:"amount"`
+	Reason string      `json:"reason" binding:"required"`
+	Items  []OrderItem `json:"items"`
+}
+
+func (i RefundOrderInput) Validate() error {
+	if i.Amount.Valid && i.Amount.Money <= 0 {
+		return ErrInvalidRefundAmount
+	}
+
+	for _, item := range i.Items {
+		if err := item.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CompleteRefundInput has the refund id from the payment provider, refund callback isn't matched with the refund without it.
+type CompleteRefundInput struct {
+	ProviderRefundId string `json:"provider_refund_id"`
+}
+
+// Refund of the full remaining amount moves the order to refunded status.
+// Pending refund is reserved while the payment provider is called, its amount and items can't be refunded again.
+// ProviderRefundId is saved on completion, the provider refund callback is matched with the refund by it.
+type Refund struct {
+	Id               int         `json:"id" db:"id"`
+	OrderId          int         `json:"order_id" db:"order_id"`
+	Amount           Money       `json:"amount" db:"amount"`
+	Reason           string      `json:"reason" db:"reason"`
+	Full             bool        `json:"full" db:"full_refund"`
+	Status           string      `json:"status" db:"status"`
+	ProviderRefundId null.String `json:"provider_refund_id" db:"provider_refund_id"`
+	CreatedBy        string      `json:"created_by" db:"created_by"`
+	CreatedAt        time.Time   `json:"created_at" db:"created_at"`
+	Items            []OrderItem `json:"items"`
+}
+
+// OrderPayment is the order transaction, SaleId is the provider sale id, that is required for refunds.
+type OrderPayment struct {
+	TransactionId string      `db:"uuid"`
+	SaleId        null.String `db:"sale_id"`
+}
@@ -0,0 +1,26 @@
+package jewerly
+
+import "errors"
+
+var ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe token")
+
+// CartReminder is a customer's cart that was left with items and not checked out.
+type CartReminder struct {
+	CartId    int    `db:"id"`
+	FirstName string `db:"first_name"`
+	Email     string `db:"email"`
+}
+
+// OrderReminder is an order that was created but never paid.
+type OrderReminder struct {
+	OrderId    int    `db:"id"`
+	FirstName  string `db:"first_name"`
+	Email      string `db:"email"`
+	TotalCost  Money  `db:"total_cost"`
+	Currency   string `db:"currency"`
+	PaymentURL string `db:"payment_url"`
+}
+
+type UnsubscribeInput struct {
+	Token string `json:"token" binding:"required"`
+}
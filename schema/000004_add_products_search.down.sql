@@ -0,0 +1,11 @@
+DROP INDEX titles_english_search_idx;
+DROP INDEX titles_russian_search_idx;
+DROP INDEX titles_ukrainian_search_idx;
+
+DROP INDEX descriptions_english_search_idx;
+DROP INDEX descriptions_russian_search_idx;
+DROP INDEX descriptions_ukrainian_search_idx;
+
+DROP INDEX materials_english_search_idx;
+DROP INDEX materials_russian_search_idx;
+DROP INDEX materials_ukrainian_search_idx;
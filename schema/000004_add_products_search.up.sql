@@ -0,0 +1,11 @@
+CREATE INDEX titles_english_search_idx ON titles USING GIN (to_tsvector('english', english));
+CREATE INDEX titles_russian_search_idx ON titles USING GIN (to_tsvector('russian', russian));
+CREATE INDEX titles_ukrainian_search_idx ON titles USING GIN (to_tsvector('simple', ukrainian));
+
+CREATE INDEX descriptions_english_search_idx ON descriptions USING GIN (to_tsvector('english', english));
+CREATE INDEX descriptions_russian_search_idx ON descriptions USING GIN (to_tsvector('russian', russian));
+CREATE INDEX descriptions_ukrainian_search_idx ON descriptions USING GIN (to_tsvector('simple', ukrainian));
+
+CREATE INDEX materials_english_search_idx ON materials USING GIN (to_tsvector('english', english));
+CREATE INDEX materials_russian_search_idx ON materials USING GIN (to_tsvector('russian', russian));
+CREATE INDEX materials_ukrainian_search_idx ON materials USING GIN (to_tsvector('simple', ukrainian));
@@ -0,0 +1,5 @@
+DROP INDEX order_items_product_id_idx;
+DROP INDEX products_created_at_idx;
+DROP INDEX products_price_idx;
+
+ALTER TABLE products DROP COLUMN created_at;
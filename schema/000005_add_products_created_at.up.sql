@@ -0,0 +1,5 @@
+ALTER TABLE products ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+CREATE INDEX products_price_idx ON products (price);
+CREATE INDEX products_created_at_idx ON products (created_at);
+CREATE INDEX order_items_product_id_idx ON order_items (product_id);
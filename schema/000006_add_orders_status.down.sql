@@ -0,0 +1,5 @@
+DROP TABLE order_status_history;
+
+ALTER TABLE orders DROP COLUMN status_updated_by;
+ALTER TABLE orders DROP COLUMN status_updated_at;
+ALTER TABLE orders DROP COLUMN status;
@@ -0,0 +1,22 @@
+ALTER TABLE orders ADD COLUMN status varchar(255) NOT NULL DEFAULT 'new';
+ALTER TABLE orders ADD COLUMN status_updated_at TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE orders ADD COLUMN status_updated_by varchar(255);
+
+CREATE TABLE order_status_history
+(
+    "id"         serial                                       NOT NULL UNIQUE,
+    "order_id"   int REFERENCES orders (id) ON DELETE CASCADE NOT NULL,
+    "status"     varchar(255)                                 NOT NULL,
+    "changed_by" varchar(255)                                 NOT NULL,
+    "created_at" timestamp                                    NOT NULL DEFAULT NOW()
+);
+
+-- orders that already received a successful payment callback
+UPDATE orders o
+SET status            = 'paid',
+    status_updated_by = 'payment'
+WHERE EXISTS(SELECT 1
+             FROM transactions t
+                      JOIN transactions_history th ON th.uuid = t.uuid
+             WHERE t.order_id = o.id
+               AND th.status = 'sale-complete');
@@ -0,0 +1,4 @@
+ALTER TABLE orders DROP COLUMN shipped_at;
+ALTER TABLE orders DROP COLUMN tracking_url;
+ALTER TABLE orders DROP COLUMN tracking_number;
+ALTER TABLE orders DROP COLUMN carrier;
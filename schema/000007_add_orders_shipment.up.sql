@@ -0,0 +1,4 @@
+ALTER TABLE orders ADD COLUMN carrier varchar(255);
+ALTER TABLE orders ADD COLUMN tracking_number varchar(255);
+ALTER TABLE orders ADD COLUMN tracking_url varchar(1024);
+ALTER TABLE orders ADD COLUMN shipped_at TIMESTAMP;
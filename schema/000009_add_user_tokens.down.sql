@@ -0,0 +1,3 @@
+DROP TABLE user_tokens;
+
+ALTER TABLE users DROP COLUMN email_verified;
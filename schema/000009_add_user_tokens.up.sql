@@ -0,0 +1,12 @@
+ALTER TABLE users ADD COLUMN email_verified bool NOT NULL DEFAULT false;
+
+CREATE TABLE user_tokens
+(
+    "id"         serial                                      NOT NULL UNIQUE,
+    "user_id"    int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    "token_hash" varchar(64)                                 NOT NULL UNIQUE,
+    "purpose"    varchar(255)                                NOT NULL,
+    "expires_at" timestamp                                   NOT NULL,
+    "used_at"    timestamp,
+    "created_at" timestamp                                   NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,17 @@
+CREATE TABLE carts
+(
+    "id"         serial                                      NOT NULL UNIQUE,
+    "token"      varchar(64)                                 NOT NULL UNIQUE,
+    "user_id"    int REFERENCES users (id) ON DELETE CASCADE UNIQUE,
+    "created_at" timestamp                                   NOT NULL DEFAULT NOW(),
+    "updated_at" timestamp                                   NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE cart_items
+(
+    "id"         serial                                         NOT NULL UNIQUE,
+    "cart_id"    int REFERENCES carts (id) ON DELETE CASCADE    NOT NULL,
+    "product_id" int REFERENCES products (id) ON DELETE CASCADE NOT NULL,
+    "quantity"   int                                            NOT NULL,
+    UNIQUE (cart_id, product_id)
+);
@@ -0,0 +1,5 @@
+DROP TABLE email_opt_outs;
+
+ALTER TABLE transactions DROP COLUMN payment_url;
+ALTER TABLE orders DROP COLUMN payment_reminder_sent_at;
+ALTER TABLE carts DROP COLUMN reminder_sent_at;
@@ -0,0 +1,9 @@
+ALTER TABLE carts ADD COLUMN reminder_sent_at timestamp;
+ALTER TABLE orders ADD COLUMN payment_reminder_sent_at timestamp;
+ALTER TABLE transactions ADD COLUMN payment_url varchar(1024);
+
+CREATE TABLE email_opt_outs
+(
+    "email"      varchar(255) NOT NULL UNIQUE,
+    "created_at" timestamp    NOT NULL DEFAULT NOW()
+);
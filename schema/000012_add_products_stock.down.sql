@@ -0,0 +1,6 @@
+ALTER TABLE products DROP COLUMN in_stock;
+ALTER TABLE products ADD COLUMN in_stock bool DEFAULT true;
+
+UPDATE products SET in_stock = stock > 0;
+
+ALTER TABLE products DROP COLUMN stock;
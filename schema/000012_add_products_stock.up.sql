@@ -0,0 +1,6 @@
+ALTER TABLE products ADD COLUMN stock int NOT NULL DEFAULT 0 CHECK (stock >= 0);
+
+UPDATE products SET stock = 1 WHERE in_stock;
+
+ALTER TABLE products DROP COLUMN in_stock;
+ALTER TABLE products ADD COLUMN in_stock bool GENERATED ALWAYS AS (stock > 0) STORED;
@@ -0,0 +1,8 @@
+DELETE FROM cart_items WHERE variant_id IS NOT NULL;
+DROP INDEX cart_items_cart_product_variant_idx;
+ALTER TABLE cart_items DROP COLUMN variant_id;
+ALTER TABLE cart_items ADD UNIQUE (cart_id, product_id);
+
+ALTER TABLE order_items DROP COLUMN variant_id;
+
+DROP TABLE product_variants;
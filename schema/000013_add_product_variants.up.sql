@@ -0,0 +1,20 @@
+CREATE TABLE product_variants
+(
+    "id"           serial                                         NOT NULL UNIQUE,
+    "product_id"   int REFERENCES products (id) ON DELETE CASCADE NOT NULL,
+    "sku"          varchar(255)                                   NOT NULL UNIQUE,
+    "option_type"  varchar(32)                                    NOT NULL,
+    "option_value" varchar(255)                                   NOT NULL,
+    "price_delta"  DECIMAL(10, 2)                                 NOT NULL DEFAULT 0,
+    "stock"        int                                            NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    "in_stock"     bool GENERATED ALWAYS AS (stock > 0) STORED,
+    UNIQUE (id, product_id),
+    UNIQUE (product_id, option_type, option_value)
+);
+
+ALTER TABLE order_items ADD COLUMN variant_id int REFERENCES product_variants (id) ON DELETE SET NULL;
+
+ALTER TABLE cart_items ADD COLUMN variant_id int;
+ALTER TABLE cart_items ADD CONSTRAINT cart_items_variant_fkey FOREIGN KEY (variant_id, product_id) REFERENCES product_variants (id, product_id) ON DELETE CASCADE;
+ALTER TABLE cart_items DROP CONSTRAINT cart_items_cart_id_product_id_key;
+CREATE UNIQUE INDEX cart_items_cart_product_variant_idx ON cart_items (cart_id, product_id, COALESCE(variant_id, 0));
@@ -0,0 +1,6 @@
+ALTER TABLE orders DROP COLUMN free_shipping;
+ALTER TABLE orders DROP COLUMN discount;
+ALTER TABLE orders DROP COLUMN promo_code;
+
+DROP TABLE promo_code_usages;
+DROP TABLE promo_codes;
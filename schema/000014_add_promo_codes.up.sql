@@ -0,0 +1,30 @@
+CREATE TABLE promo_codes
+(
+    "id"                 serial         NOT NULL UNIQUE,
+    "code"               varchar(64)    NOT NULL UNIQUE,
+    "type"               varchar(32)    NOT NULL,
+    "value"              DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    "min_order_sum"      DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    "category_ids"       int[]          NOT NULL DEFAULT '{}',
+    "valid_from"         timestamp,
+    "valid_to"           timestamp,
+    "usage_limit"        int,
+    "per_customer_limit" int,
+    "active"             bool           NOT NULL DEFAULT true,
+    "created_at"         timestamp      NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE promo_code_usages
+(
+    "id"            serial       NOT NULL UNIQUE,
+    "promo_code_id" int REFERENCES promo_codes (id) ON DELETE CASCADE NOT NULL,
+    "order_id"      int REFERENCES orders (id) ON DELETE CASCADE     NOT NULL UNIQUE,
+    "email"         varchar(255) NOT NULL,
+    "created_at"    timestamp    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX promo_code_usages_promo_code_email_idx ON promo_code_usages (promo_code_id, email);
+
+ALTER TABLE orders ADD COLUMN promo_code varchar(64);
+ALTER TABLE orders ADD COLUMN discount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN free_shipping bool NOT NULL DEFAULT false;
@@ -0,0 +1,4 @@
+ALTER TABLE products DROP CONSTRAINT products_sale_period_check;
+ALTER TABLE products DROP COLUMN sale_ends_at;
+ALTER TABLE products DROP COLUMN sale_starts_at;
+ALTER TABLE products DROP COLUMN sale_price;
@@ -0,0 +1,4 @@
+ALTER TABLE products ADD COLUMN sale_price DECIMAL(10, 2) CHECK (sale_price > 0);
+ALTER TABLE products ADD COLUMN sale_starts_at timestamp;
+ALTER TABLE products ADD COLUMN sale_ends_at timestamp;
+ALTER TABLE products ADD CONSTRAINT products_sale_period_check CHECK (sale_ends_at > sale_starts_at);
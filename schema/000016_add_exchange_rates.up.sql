@@ -0,0 +1,11 @@
+CREATE TABLE exchange_rates
+(
+    "currency"   varchar(3)     NOT NULL UNIQUE,
+    "rate"       DECIMAL(14, 6) NOT NULL CHECK (rate > 0),
+    "updated_at" timestamp      NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO exchange_rates (currency, rate) VALUES ('USD', 1);
+
+ALTER TABLE orders ADD COLUMN currency varchar(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE orders ADD COLUMN exchange_rate DECIMAL(14, 6) NOT NULL DEFAULT 1;
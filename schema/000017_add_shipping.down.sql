@@ -0,0 +1,9 @@
+ALTER TABLE orders DROP COLUMN shipping_cost;
+ALTER TABLE orders DROP COLUMN shipping_method;
+ALTER TABLE orders DROP COLUMN shipping_method_id;
+
+ALTER TABLE products DROP COLUMN weight;
+
+DROP TABLE shipping_rates;
+DROP TABLE shipping_methods;
+DROP TABLE shipping_zones;
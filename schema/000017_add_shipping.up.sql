@@ -0,0 +1,31 @@
+CREATE TABLE shipping_zones
+(
+    "id"        serial       NOT NULL UNIQUE,
+    "name"      varchar(255) NOT NULL,
+    "countries" varchar(2)[] NOT NULL DEFAULT '{}'
+);
+
+CREATE TABLE shipping_methods
+(
+    "id"        serial       NOT NULL UNIQUE,
+    "zone_id"   int REFERENCES shipping_zones (id) ON DELETE CASCADE NOT NULL,
+    "name"      varchar(255) NOT NULL,
+    "type"      varchar(32)  NOT NULL,
+    "free_from" DECIMAL(10, 2),
+    "active"    bool         NOT NULL DEFAULT true
+);
+
+CREATE TABLE shipping_rates
+(
+    "id"            serial         NOT NULL UNIQUE,
+    "method_id"     int REFERENCES shipping_methods (id) ON DELETE CASCADE NOT NULL,
+    "min_weight"    int            NOT NULL DEFAULT 0 CHECK (min_weight >= 0),
+    "min_order_sum" DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (min_order_sum >= 0),
+    "cost"          DECIMAL(10, 2) NOT NULL CHECK (cost >= 0)
+);
+
+ALTER TABLE products ADD COLUMN weight int NOT NULL DEFAULT 0 CHECK (weight >= 0);
+
+ALTER TABLE orders ADD COLUMN shipping_method_id int REFERENCES shipping_methods (id) ON DELETE SET NULL;
+ALTER TABLE orders ADD COLUMN shipping_method varchar(255);
+ALTER TABLE orders ADD COLUMN shipping_cost DECIMAL(10, 2) NOT NULL DEFAULT 0;
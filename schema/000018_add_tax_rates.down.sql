@@ -0,0 +1,6 @@
+ALTER TABLE orders DROP COLUMN tax_inclusive;
+ALTER TABLE orders DROP COLUMN tax_rate;
+ALTER TABLE orders DROP COLUMN tax_amount;
+ALTER TABLE orders DROP COLUMN net_amount;
+
+DROP TABLE tax_rates;
@@ -0,0 +1,14 @@
+CREATE TABLE tax_rates
+(
+    "country"    varchar(2)    NOT NULL UNIQUE,
+    "rate"       DECIMAL(5, 2) NOT NULL CHECK (rate >= 0 AND rate <= 100),
+    "inclusive"  bool          NOT NULL DEFAULT true,
+    "updated_at" timestamp     NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE orders ADD COLUMN net_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN tax_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN tax_rate DECIMAL(5, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN tax_inclusive bool NOT NULL DEFAULT true;
+
+UPDATE orders SET net_amount = total_cost;
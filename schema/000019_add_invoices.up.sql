@@ -0,0 +1,10 @@
+CREATE TABLE invoices
+(
+    "id"         serial PRIMARY KEY,
+    "order_id"   int          NOT NULL UNIQUE REFERENCES orders (id) ON DELETE RESTRICT,
+    "number"     int          NOT NULL UNIQUE,
+    "file_name"  varchar(255) NOT NULL,
+    "created_at" timestamp    NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE order_items ADD COLUMN price DECIMAL(10, 2);
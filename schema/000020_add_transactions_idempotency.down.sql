@@ -0,0 +1,2 @@
+DROP INDEX transactions_uuid_idx;
+DROP INDEX transactions_history_uuid_status_idx;
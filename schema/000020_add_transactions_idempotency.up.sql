@@ -0,0 +1,5 @@
+DELETE FROM transactions_history th USING transactions_history duplicate
+WHERE th.uuid = duplicate.uuid AND th.status = duplicate.status AND th.id > duplicate.id;
+
+CREATE UNIQUE INDEX transactions_history_uuid_status_idx ON transactions_history (uuid, status);
+CREATE UNIQUE INDEX transactions_uuid_idx ON transactions (uuid);
@@ -0,0 +1,10 @@
+DELETE FROM transactions_history WHERE refund_id IS NOT NULL;
+DROP INDEX transactions_history_uuid_status_idx;
+CREATE UNIQUE INDEX transactions_history_uuid_status_idx ON transactions_history (uuid, status);
+
+ALTER TABLE transactions_history DROP COLUMN refund_id;
+
+DROP TABLE refund_items;
+DROP TABLE refunds;
+
+ALTER TABLE transactions DROP COLUMN sale_id;
@@ -0,0 +1,27 @@
+ALTER TABLE transactions ADD COLUMN sale_id varchar(255);
+
+CREATE TABLE refunds
+(
+    "id"          serial PRIMARY KEY,
+    "order_id"    int            NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    "amount"      DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    "reason"      text           NOT NULL,
+    "full_refund" boolean        NOT NULL DEFAULT false,
+    "created_by"  varchar(255)   NOT NULL,
+    "created_at"  timestamp      NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE refund_items
+(
+    "id"         serial PRIMARY KEY,
+    "refund_id"  int NOT NULL REFERENCES refunds (id) ON DELETE CASCADE,
+    "product_id" int NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    "variant_id" int REFERENCES product_variants (id) ON DELETE SET NULL,
+    "quantity"   int NOT NULL CHECK (quantity > 0)
+);
+
+ALTER TABLE transactions_history ADD COLUMN refund_id int REFERENCES refunds (id);
+
+-- an order can be partially refunded several times, callbacks are still processed once
+DROP INDEX transactions_history_uuid_status_idx;
+CREATE UNIQUE INDEX transactions_history_uuid_status_idx ON transactions_history (uuid, status) WHERE refund_id IS NULL;
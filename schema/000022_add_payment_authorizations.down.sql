@@ -0,0 +1,3 @@
+UPDATE orders SET status = 'new' WHERE status = 'authorized';
+
+ALTER TABLE products DROP COLUMN made_to_order;
@@ -0,0 +1,13 @@
+ALTER TABLE products ADD COLUMN made_to_order boolean NOT NULL DEFAULT false;
+
+-- orders with authorized payment that wasn't captured yet
+UPDATE orders o
+SET status            = 'authorized',
+    status_updated_at = NOW(),
+    status_updated_by = 'payment'
+WHERE o.status = 'new'
+  AND EXISTS(SELECT 1
+             FROM transactions t
+                      JOIN transactions_history th ON th.uuid = t.uuid
+             WHERE t.order_id = o.id
+               AND th.status = 'sale-authorized');
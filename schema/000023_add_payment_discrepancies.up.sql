@@ -0,0 +1,15 @@
+CREATE TABLE payment_discrepancies
+(
+    "id"              serial PRIMARY KEY,
+    "order_id"        int          NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    "uuid"            uuid         NOT NULL,
+    "kind"            varchar(255) NOT NULL,
+    "local_status"    varchar(255) NOT NULL,
+    "provider_status" varchar(255) NOT NULL,
+    "details"         text         NOT NULL DEFAULT '',
+    "resolved"        boolean      NOT NULL DEFAULT false,
+    "created_at"      timestamp    NOT NULL DEFAULT NOW()
+);
+
+-- reconciliation runs periodically, the same discrepancy is reported once
+CREATE UNIQUE INDEX payment_discrepancies_uuid_kind_status_idx ON payment_discrepancies (uuid, kind, provider_status);
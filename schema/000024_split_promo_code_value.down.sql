@@ -0,0 +1,7 @@
+ALTER TABLE promo_codes ADD COLUMN value DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+UPDATE promo_codes SET value = percent WHERE type = 'percentage';
+UPDATE promo_codes SET value = amount WHERE type = 'fixed';
+
+ALTER TABLE promo_codes DROP COLUMN percent;
+ALTER TABLE promo_codes DROP COLUMN amount;
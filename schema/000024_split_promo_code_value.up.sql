@@ -0,0 +1,8 @@
+-- fixed amount is money and is kept apart from the percentage
+ALTER TABLE promo_codes ADD COLUMN percent DECIMAL(5, 2) NOT NULL DEFAULT 0;
+ALTER TABLE promo_codes ADD COLUMN amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+UPDATE promo_codes SET percent = value WHERE type = 'percentage';
+UPDATE promo_codes SET amount = value WHERE type = 'fixed';
+
+ALTER TABLE promo_codes DROP COLUMN value;
@@ -0,0 +1,9 @@
+DELETE FROM transactions_history th USING transactions_history duplicate
+WHERE th.uuid = duplicate.uuid AND th.status = duplicate.status AND th.refund_id IS NULL AND duplicate.refund_id IS NULL
+  AND th.id > duplicate.id;
+
+DROP INDEX transactions_history_uuid_status_idx;
+CREATE UNIQUE INDEX transactions_history_uuid_status_idx ON transactions_history (uuid, status) WHERE refund_id IS NULL;
+
+DROP INDEX transactions_history_event_id_idx;
+ALTER TABLE transactions_history DROP COLUMN event_id;
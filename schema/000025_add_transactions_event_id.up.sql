@@ -0,0 +1,8 @@
+-- provider event id identifies the callback, partial refund status can be received several times for the sale
+ALTER TABLE transactions_history ADD COLUMN event_id varchar(255);
+
+CREATE UNIQUE INDEX transactions_history_event_id_idx ON transactions_history (event_id);
+
+DROP INDEX transactions_history_uuid_status_idx;
+CREATE UNIQUE INDEX transactions_history_uuid_status_idx ON transactions_history (uuid, status)
+    WHERE refund_id IS NULL AND status <> 'partial-refund';
@@ -0,0 +1,2 @@
+DELETE FROM refunds WHERE status = 'pending';
+ALTER TABLE refunds DROP COLUMN status;
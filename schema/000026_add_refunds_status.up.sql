@@ -0,0 +1,2 @@
+-- refund is reserved before the payment provider is called, so concurrent refunds can't exceed the paid amount
+ALTER TABLE refunds ADD COLUMN status varchar(32) NOT NULL DEFAULT 'completed';
@@ -0,0 +1,5 @@
+DELETE FROM transactions_history th USING transactions_history duplicate
+WHERE th.event_id = duplicate.event_id AND th.id > duplicate.id;
+
+DROP INDEX transactions_history_event_id_idx;
+CREATE UNIQUE INDEX transactions_history_event_id_idx ON transactions_history (event_id);
@@ -0,0 +1,3 @@
+-- PayMe sends the same payme_transaction_id with every notify type of the sale, so the event is identified with the status
+DROP INDEX transactions_history_event_id_idx;
+CREATE UNIQUE INDEX transactions_history_event_id_idx ON transactions_history (event_id, status);
@@ -0,0 +1 @@
+ALTER TABLE refunds DROP COLUMN provider_refund_id;
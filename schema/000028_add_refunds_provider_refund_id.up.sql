@@ -0,0 +1,2 @@
+-- refund callback is matched with the refund made by the admin by the provider refund id
+ALTER TABLE refunds ADD COLUMN provider_refund_id varchar(255);
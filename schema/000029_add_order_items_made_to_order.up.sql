@@ -0,0 +1,2 @@
+-- made to order items don't reserve stock, so it isn't released when the order is cancelled
+ALTER TABLE order_items ADD COLUMN made_to_order boolean NOT NULL DEFAULT false;
@@ -0,0 +1,243 @@
+package jewerly
+
+import (
+	"errors"
+	"gopkg.in/guregu/null.v3"
+	"strings"
+)
+
+const (
+	ShippingTypeStandard = "standard"
+	ShippingTypeExpress  = "express"
+	ShippingTypePickup   = "pickup"
+)
+
+var (
+	ErrShippingZoneNotFound     = errors.New("shipping zone not found")
+	ErrShippingMethodNotFound   = errors.New("shipping method not found")
+	ErrShippingMethodRequired   = errors.New("shipping method is required")
+	ErrShippingNotAvailable     = errors.New("shipping method isn't available for the country or order")
+	ErrInvalidShippingType      = errors.New("invalid shipping method type")
+	ErrInvalidShippingRates     = errors.New("shipping method should have at least one rate, rate values can't be negative")
+	ErrInvalidShippingCountries = errors.New("countries should be 2-letter ISO codes")
+)
+
+var shippingTypes = map[string]bool{
+	ShippingTypeStandard: true,
+	ShippingTypeExpress:  true,
+	ShippingTypePickup:   true,
+}
+
+// ShippingZone groups countries with the same shipping methods and rates.
+// Zone without countries is used for the countries that aren't listed in other zones.
+type ShippingZone struct {
+	Id        int              `json:"id" db:"id"`
+	Name      string           `json:"name" db:"name"`
+	Countries []string         `json:"countries" db:"-"`
+	Methods   []ShippingMethod `json:"methods"`
+}
+
+// ShippingMethod costs are set in the base currency, shipping is free for orders from FreeFrom sum.
+type ShippingMethod struct {
+	Id       int            `json:"id" db:"id"`
+	ZoneId   int            `json:"zone_id" db:"zone_id"`
+	Name     string         `json:"name" db:"name"`
+	Type     string         `json:"type" db:"type"`
+	FreeFrom NullMoney      `json:"free_from" db:"free_from"`
+	Active   bool           `json:"active" db:"active"`
+	Rates    []ShippingRate `json:"rates"`
+}
+
+// ShippingRate is applied to orders with weight (in grams) and sum not less than the minimal ones,
+// rates with zero minimums are flat.
+type ShippingRate struct {
+	MinWeight   int   `json:"min_weight" db:"min_weight"`
+	MinOrderSum Money `json:"min_order_sum" db:"min_order_sum"`
+	Cost        Money `json:"cost" db:"cost"`
+}
+
+// Cost returns shipping cost of the order, the rate with the highest matching minimums is used.
+// False is returned when none of the rates can be applied to the order.
+func (m ShippingMethod) Cost(weight int, orderSum Money) (Money, bool) {
+	if m.FreeFrom.Valid && orderSum >= m.FreeFrom.Money {
+		return 0, true
+	}
+
+	var (
+		rate  ShippingRate
+		found bool
+	)
+
+	for _, r := range m.Rates {
+		if weight < r.MinWeight || orderSum < r.MinOrderSum {
+			continue
+		}
+
+		if !found || r.MinWeight > rate.MinWeight || (r.MinWeight == rate.MinWeight && r.MinOrderSum > rate.MinOrderSum) {
+			rate = r
+			found = true
+		}
+	}
+
+	return rate.Cost, found
+}
+
+type CreateShippingZoneInput struct {
+	Name      string   `json:"name" binding:"required"`
+	Countries []string `json:"countries"`
+}
+
+func (i CreateShippingZoneInput) Validate() error {
+	return validateCountries(i.Countries)
+}
+
+type UpdateShippingZoneInput struct {
+	Name      null.String `json:"name"`
+	Countries *[]string   `json:"countries"`
+}
+
+func (i UpdateShippingZoneInput) Validate() error {
+	if !i.Name.Valid && i.Countries == nil {
+		return errors.New("empty update shipping zone input")
+	}
+
+	if i.Name.Valid && i.Name.String == "" {
+		return errors.New("shipping zone name can't be empty")
+	}
+
+	if i.Countries != nil {
+		return validateCountries(*i.Countries)
+	}
+
+	return nil
+}
+
+type CreateShippingMethodInput struct {
+	ZoneId   int            `json:"zone_id" binding:"required"`
+	Name     string         `json:"name" binding:"required"`
+	Type     string         `json:"type" binding:"required"`
+	FreeFrom NullMoney      `json:"free_from"`
+	Rates    []ShippingRate `json:"rates" binding:"required"`
+}
+
+func (i CreateShippingMethodInput) Validate() error {
+	if !shippingTypes[i.Type] {
+		return ErrInvalidShippingType
+	}
+
+	if i.FreeFrom.Valid && i.FreeFrom.Money < 0 {
+		return errors.New("free shipping sum can't be negative")
+	}
+
+	return validateShippingRates(i.Rates)
+}
+
+// UpdateShippingMethodInput replaces all rates of the method when Rates are passed.
+type UpdateShippingMethodInput struct {
+	Name     null.String     `json:"name"`
+	Type     null.String     `json:"type"`
+	FreeFrom NullMoney       `json:"free_from"`
+	Active   null.Bool       `json:"active"`
+	Rates    *[]ShippingRate `json:"rates"`
+
+	// RemoveFreeFrom disables free shipping for the method, FreeFrom is ignored.
+	RemoveFreeFrom bool `json:"remove_free_from"`
+}
+
+func (i UpdateShippingMethodInput) Validate() error {
+	if (UpdateShippingMethodInput{}) == i {
+		return errors.New("empty update shipping method input")
+	}
+
+	if i.Name.Valid && i.Name.String == "" {
+		return errors.New("shipping method name can't be empty")
+	}
+
+	if i.Type.Valid && !shippingTypes[i.Type.String] {
+		return ErrInvalidShippingType
+	}
+
+	if i.FreeFrom.Valid && i.FreeFrom.Money < 0 {
+		return errors.New("free shipping sum can't be negative")
+	}
+
+	if i.Rates != nil {
+		return validateShippingRates(*i.Rates)
+	}
+
+	return nil
+}
+
+func validateShippingRates(rates []ShippingRate) error {
+	if len(rates) == 0 {
+		return ErrInvalidShippingRates
+	}
+
+	for _, rate := range rates {
+		if rate.MinWeight < 0 || rate.MinOrderSum < 0 || rate.Cost < 0 {
+			return ErrInvalidShippingRates
+		}
+	}
+
+	return nil
+}
+
+func validateCountries(countries []string) error {
+	for _, country := range countries {
+		code := NormalizeCountry(country)
+		if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+			return ErrInvalidShippingCountries
+		}
+	}
+
+	return nil
+}
+
+// NormalizeCountry makes country codes case insensitive.
+func NormalizeCountry(country string) string {
+	return strings.ToUpper(strings.TrimSpace(country))
+}
+
+func NormalizeCountries(countries []string) []string {
+	normalized := make([]string, len(countries))
+	for i := range countries {
+		normalized[i] = NormalizeCountry(countries[i])
+	}
+
+	return normalized
+}
+
+type ShippingQuoteInput struct {
+	Country string      `json:"country" binding:"required"`
+	Items   []OrderItem `json:"items" binding:"required"`
+}
+
+func (i ShippingQuoteInput) Validate() error {
+	if len(i.Items) < 1 {
+		return errors.New("order should have at least 1 item")
+	}
+
+	for _, item := range i.Items {
+		if err := item.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ShippingOption is a shipping method available for the order with its cost in the requested currency.
+type ShippingOption struct {
+	Id       int    `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Cost     Money  `json:"cost"`
+	Currency string `json:"currency"`
+}
+
+// OrderShipping is the shipping method chosen for the order, cost is in the base currency until the order is created.
+type OrderShipping struct {
+	MethodId int
+	Method   string
+	Cost     Money
+}
@@ -0,0 +1,45 @@
+package jewerly
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShippingMethod_Cost(t *testing.T) {
+	method := ShippingMethod{
+		FreeFrom: NullMoneyFrom(50000),
+		Rates: []ShippingRate{
+			{MinWeight: 1000, Cost: 3000},
+			{Cost: 1500},
+			{MinWeight: 1000, MinOrderSum: 20000, Cost: 2000},
+		},
+	}
+
+	testTable := []struct {
+		name     string
+		method   ShippingMethod
+		weight   int
+		orderSum Money
+		want     Money
+		wantOk   bool
+	}{
+		{name: "Flat", method: method, weight: 300, orderSum: 10000, want: 1500, wantOk: true},
+		{name: "By Weight", method: method, weight: 1200, orderSum: 10000, want: 3000, wantOk: true},
+		{name: "By Weight And Sum", method: method, weight: 1200, orderSum: 20000, want: 2000, wantOk: true},
+		{name: "Free", method: method, weight: 5000, orderSum: 50000, want: 0, wantOk: true},
+		{
+			name:     "No Rate",
+			method:   ShippingMethod{Rates: []ShippingRate{{MinWeight: 2000, Cost: 5000}}},
+			weight:   1000,
+			orderSum: 10000,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, ok := testCase.method.Cost(testCase.weight, testCase.orderSum)
+			assert.Equal(t, testCase.wantOk, ok)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package jewerly
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	ErrTaxRateNotFound = errors.New("tax rate for the country is not set")
+	ErrInvalidTaxRate  = errors.New("tax rate should be between 0 and 100 percent")
+)
+
+// TaxRate is VAT percent of the destination country.
+// Inclusive rates are already included in product prices, so the tax is extracted from the order sum,
+// otherwise it's added on top of it.
+type TaxRate struct {
+	Country   string    `json:"country" db:"country"`
+	Rate      float64   `json:"rate" db:"rate"`
+	Inclusive bool      `json:"inclusive" db:"inclusive"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (r TaxRate) Validate() error {
+	if err := validateCountries([]string{r.Country}); err != nil {
+		return err
+	}
+
+	if r.Rate < 0 || r.Rate > 100 {
+		return ErrInvalidTaxRate
+	}
+
+	return nil
+}
+
+// Apply calculates tax breakdown of the amount, tax is rounded to cents so net and tax always sum up to gross.
+func (r TaxRate) Apply(amount Money) OrderTax {
+	tax := OrderTax{Rate: r.Rate, Inclusive: r.Inclusive}
+
+	if r.Inclusive {
+		tax.Gross = amount
+		tax.Net = Money(math.Round(float64(amount) / (1 + r.Rate/100)))
+		tax.Tax = tax.Gross - tax.Net
+	} else {
+		tax.Net = amount
+		tax.Tax = amount.Percent(r.Rate)
+		tax.Gross = tax.Net + tax.Tax
+	}
+
+	return tax
+}
+
+type SetTaxRateInput struct {
+	Rate      float64 `json:"rate"`
+	Inclusive bool    `json:"inclusive"`
+}
+
+// OrderTax is the tax breakdown of the order, Gross is the amount the customer is charged.
+type OrderTax struct {
+	Rate      float64
+	Inclusive bool
+	Net       Money
+	Tax       Money
+	Gross     Money
+}
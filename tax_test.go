@@ -0,0 +1,47 @@
+package jewerly
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTaxRate_Apply(t *testing.T) {
+	testTable := []struct {
+		name   string
+		rate   TaxRate
+		amount Money
+		want   OrderTax
+	}{
+		{
+			name:   "Inclusive",
+			rate:   TaxRate{Country: "IL", Rate: 17, Inclusive: true},
+			amount: 11700,
+			want:   OrderTax{Rate: 17, Inclusive: true, Net: 10000, Tax: 1700, Gross: 11700},
+		},
+		{
+			name:   "Inclusive Rounded",
+			rate:   TaxRate{Country: "DE", Rate: 19, Inclusive: true},
+			amount: 1999,
+			want:   OrderTax{Rate: 19, Inclusive: true, Net: 1680, Tax: 319, Gross: 1999},
+		},
+		{
+			name:   "Exclusive",
+			rate:   TaxRate{Country: "FR", Rate: 20},
+			amount: 1999,
+			want:   OrderTax{Rate: 20, Net: 1999, Tax: 400, Gross: 2399},
+		},
+		{
+			name:   "No Tax",
+			amount: 1999,
+			want:   OrderTax{Net: 1999, Gross: 1999},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := testCase.rate.Apply(testCase.amount)
+			assert.Equal(t, testCase.want, got)
+			assert.Equal(t, got.Gross, got.Net+got.Tax)
+		})
+	}
+}
@@ -5,17 +5,25 @@ import (
 	"time"
 )
 
+const (
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposePasswordReset     = "password_reset"
+)
+
 var (
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrUserAlreadyExists = errors.New("user with such email already exists")
+	ErrInvalidUserToken  = errors.New("token is invalid or expired")
 )
 
 type User struct {
-	Id           int64     `db:"id"`
-	Email        string    `db:"email"`
-	PasswordHash string    `db:"password_hash"`
-	FirstName    string    `db:"first_name"`
-	LastName     string    `db:"last_name"`
-	RegisteredAt time.Time `db:"registered_at"`
+	Id            int64     `json:"id" db:"id"`
+	Email         string    `json:"email" db:"email"`
+	PasswordHash  string    `json:"-" db:"password_hash"`
+	FirstName     string    `json:"first_name" db:"first_name"`
+	LastName      string    `json:"last_name" db:"last_name"`
+	RegisteredAt  time.Time `json:"registered_at" db:"registered_at"`
+	EmailVerified bool      `json:"email_verified" db:"email_verified"`
 }
 
 type AdminUser struct {
@@ -23,3 +31,24 @@ type AdminUser struct {
 	Login        string `db:"login"`
 	PasswordHash string `db:"password_hash"`
 }
+
+// UserToken is a single-use token sent to the customer by email, only its hash is stored.
+type UserToken struct {
+	UserId    int64
+	Hash      string
+	Purpose   string
+	ExpiresAt time.Time
+}
+
+type VerifyEmailInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ForgotPasswordInput struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordInput struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
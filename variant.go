@@ -0,0 +1,86 @@
+package jewerly
+
+import (
+	"errors"
+	"gopkg.in/guregu/null.v3"
+)
+
+const (
+	VariantOptionSize   = "size"
+	VariantOptionLength = "length"
+	VariantOptionMetal  = "metal"
+)
+
+var (
+	ErrVariantNotFound      = errors.New("product variant not found")
+	ErrVariantAlreadyExists = errors.New("product variant with such sku or option already exists")
+	ErrVariantRequired      = errors.New("product variant should be selected")
+	ErrInvalidVariantOption = errors.New("invalid variant option")
+	ErrStockFromVariants    = errors.New("stock of the product with variants is changed by its variants")
+)
+
+var variantOptions = map[string]bool{
+	VariantOptionSize:   true,
+	VariantOptionLength: true,
+	VariantOptionMetal:  true,
+}
+
+// ProductVariant is a size, chain length or metal option of the product with its own price and stock.
+// Price is the product price with the variant price delta applied.
+type ProductVariant struct {
+	Id         int    `json:"id" db:"id"`
+	ProductId  int    `json:"-" db:"product_id"`
+	SKU        string `json:"sku" db:"sku"`
+	Option     string `json:"option" db:"option_type"`
+	Value      string `json:"value" db:"option_value"`
+	PriceDelta Money  `json:"price_delta" db:"price_delta"`
+	Price      Money  `json:"price" db:"price"`
+	Stock      int    `json:"stock" db:"stock"`
+	InStock    bool   `json:"in_stock" db:"in_stock"`
+}
+
+type CreateVariantInput struct {
+	SKU        string `json:"sku" binding:"required"`
+	Option     string `json:"option" binding:"required"`
+	Value      string `json:"value" binding:"required"`
+	PriceDelta Money  `json:"price_delta"`
+	Stock      int    `json:"stock" binding:"min=0"`
+}
+
+func (i CreateVariantInput) Validate() error {
+	if !variantOptions[i.Option] {
+		return ErrInvalidVariantOption
+	}
+
+	return nil
+}
+
+type UpdateVariantInput struct {
+	SKU        null.String `json:"sku"`
+	Value      null.String `json:"value"`
+	PriceDelta NullMoney   `json:"price_delta"`
+	Stock      null.Int    `json:"stock"`
+}
+
+func (i UpdateVariantInput) Validate() error {
+	if (UpdateVariantInput{}) == i {
+		return errors.New("empty update variant input")
+	}
+
+	if i.Stock.Valid && i.Stock.Int64 < 0 {
+		return ErrNegativeStock
+	}
+
+	return nil
+}
+
+// FindVariant returns variant of the product by id.
+func (p ProductResponse) FindVariant(id int64) (ProductVariant, bool) {
+	for _, variant := range p.Variants {
+		if int64(variant.Id) == id {
+			return variant, true
+		}
+	}
+
+	return ProductVariant{}, false
+}